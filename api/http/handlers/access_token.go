@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"errors"
+	presenter "server/api/http/handlers/presentor"
+	accesstoken "server/internal/access_token"
+	"server/pkg/jwt"
+	"server/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// CreateAccessToken creates a personal access token for the current user.
+// @Summary Create access token
+// @Description Creates a named token for scripts and integrations, sent as a bearer token like a JWT. The read scope only allows reading requests, a board_id limits the token to that board. The secret is only returned in this response.
+// @Tags Access Tokens
+// @Accept  json
+// @Produce  json
+// @Param token body presenter.CreateAccessTokenReq true "Token to create"
+// @Success 201 {object} presenter.CreatedAccessTokenResp "the token with its secret"
+// @Failure 400 {object} map[string]interface{} "error: invalid name, scopes, expiry or board"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /access-tokens [post]
+func CreateAccessToken(accessTokenService *service.AccessTokenService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		var req presenter.CreateAccessTokenReq
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+
+		t := presenter.CreateAccessTokenReqToAccessToken(&req, userClaims.UserID)
+		secret, err := accessTokenService.Create(c.UserContext(), t, req.ExpiresInDays)
+		if err != nil {
+			return sendAccessTokenError(c, err)
+		}
+		data := presenter.CreatedAccessTokenResp{AccessTokenResp: presenter.AccessTokenToResp(*t), Token: secret}
+		return presenter.Created(c, "access token successfully created", data)
+	}
+}
+
+// GetAccessTokens lists the access tokens of the current user.
+// @Summary List my access tokens
+// @Description Lists the tokens of the current user that are not revoked, expired ones included, with their last use. Secrets are never listed.
+// @Tags Access Tokens
+// @Produce  json
+// @Success 200 {object} []presenter.AccessTokenResp "tokens"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /access-tokens [get]
+func GetAccessTokens(accessTokenService *service.AccessTokenService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+
+		tokens, err := accessTokenService.GetTokens(c.UserContext(), userClaims.UserID)
+		if err != nil {
+			return presenter.InternalServerError(c, err)
+		}
+		return presenter.OK(c, "access tokens successfully fetched", presenter.BatchAccessTokensToResp(tokens))
+	}
+}
+
+// RevokeAccessToken revokes an access token of the current user.
+// @Summary Revoke access token
+// @Description Revokes a token of the current user, the requests made with it are refused from then on.
+// @Tags Access Tokens
+// @Param tokenID path string true "Token ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{} "error: invalid token id"
+// @Failure 404 {object} map[string]interface{} "error: access token not found"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /access-tokens/{tokenID} [delete]
+func RevokeAccessToken(accessTokenService *service.AccessTokenService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		tokenID, err := uuid.Parse(c.Params("tokenID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given token_id format in path is not correct"))
+		}
+
+		if err := accessTokenService.Revoke(c.UserContext(), userClaims.UserID, tokenID); err != nil {
+			return sendAccessTokenError(c, err)
+		}
+		return presenter.NoContent(c)
+	}
+}
+
+func sendAccessTokenError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, accesstoken.ErrTokenNotFound):
+		return presenter.NotFound(c, err)
+	case errors.Is(err, accesstoken.ErrInvalidName), errors.Is(err, accesstoken.ErrInvalidScopes),
+		errors.Is(err, accesstoken.ErrInvalidExpiry), errors.Is(err, accesstoken.ErrNotBoardMember):
+		return presenter.BadRequest(c, err)
+	}
+	return presenter.InternalServerError(c, err)
+}
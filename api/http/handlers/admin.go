@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"errors"
+	presenter "server/api/http/handlers/presentor"
+	"server/internal/board"
+	"server/internal/user"
+	"server/pkg/jwt"
+	"server/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// GetAllUsers lists every user.
+// @Summary List users
+// @Description Lists every registered user with their global role and suspension. Admins only.
+// @Tags Admin
+// @Produce  json
+// @Param page query int false "Page number"
+// @Param page_size query int false "Page size"
+// @Success 200 {object} presenter.PaginationResponse[presenter.AdminUserResp] "paginated users"
+// @Failure 403 {object} map[string]interface{} "error: not an admin"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /admin/users [get]
+func GetAllUsers(adminService *service.AdminService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		page, pageSize := PageAndPageSize(c)
+
+		users, total, err := adminService.GetUsers(c.UserContext(), uint(page), uint(pageSize))
+		if err != nil {
+			return presenter.InternalServerError(c, err)
+		}
+		data := presenter.NewPagination(presenter.BatchUsersToAdminUserResp(users), uint(page), uint(pageSize), total)
+		return presenter.OK(c, "users successfully fetched", data)
+	}
+}
+
+// SuspendUser suspends a user.
+// @Summary Suspend user
+// @Description Keeps a user from logging in and refreshing their token. Admins only, not on themselves.
+// @Tags Admin
+// @Param userID path string true "User ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{} "error: invalid user id or own account"
+// @Failure 403 {object} map[string]interface{} "error: not an admin"
+// @Failure 404 {object} map[string]interface{} "error: user not found"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /admin/users/{userID}/suspend [post]
+func SuspendUser(serviceFactory ServiceFactory[*service.AdminService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		adminService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		userID, err := uuid.Parse(c.Params("userID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given user_id format in path is not correct"))
+		}
+
+		if err := adminService.SuspendUser(c.UserContext(), userClaims.UserID, userID); err != nil {
+			return sendAdminError(c, err)
+		}
+		return presenter.NoContent(c)
+	}
+}
+
+// UnsuspendUser lifts the suspension of a user.
+// @Summary Unsuspend user
+// @Description Lets a suspended user log in again. Admins only.
+// @Tags Admin
+// @Param userID path string true "User ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{} "error: invalid user id"
+// @Failure 403 {object} map[string]interface{} "error: not an admin"
+// @Failure 404 {object} map[string]interface{} "error: user not found"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /admin/users/{userID}/unsuspend [post]
+func UnsuspendUser(serviceFactory ServiceFactory[*service.AdminService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		adminService := serviceFactory(c.UserContext())
+
+		userID, err := uuid.Parse(c.Params("userID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given user_id format in path is not correct"))
+		}
+
+		if err := adminService.UnsuspendUser(c.UserContext(), userID); err != nil {
+			return sendAdminError(c, err)
+		}
+		return presenter.NoContent(c)
+	}
+}
+
+// GetAllBoards lists every board.
+// @Summary List boards
+// @Description Lists every board, private and archived ones included. Admins only.
+// @Tags Admin
+// @Produce  json
+// @Param page query int false "Page number"
+// @Param page_size query int false "Page size"
+// @Success 200 {object} presenter.PaginationResponse[presenter.UserBoard] "paginated boards"
+// @Failure 403 {object} map[string]interface{} "error: not an admin"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /admin/boards [get]
+func GetAllBoards(adminService *service.AdminService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		page, pageSize := PageAndPageSize(c)
+
+		boards, total, err := adminService.GetBoards(c.UserContext(), uint(page), uint(pageSize))
+		if err != nil {
+			return presenter.InternalServerError(c, err)
+		}
+		data := presenter.NewPagination(presenter.BatchBoardsToUserBoard(boards), uint(page), uint(pageSize), total)
+		return presenter.OK(c, "boards successfully fetched", data)
+	}
+}
+
+// ForceTransferOwnership hands a board over to a user.
+// @Summary Force ownership transfer
+// @Description Makes the given user the only owner of a board, adding them to it when needed; the former owners become maintainers. Admins only.
+// @Tags Admin
+// @Accept  json
+// @Param boardID path string true "Board ID"
+// @Param transfer body presenter.ForceTransferReq true "The new owner"
+// @Success 204
+// @Failure 400 {object} map[string]interface{} "error: invalid id"
+// @Failure 403 {object} map[string]interface{} "error: not an admin"
+// @Failure 404 {object} map[string]interface{} "error: board or user not found"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /admin/boards/{boardID}/ownership [post]
+func ForceTransferOwnership(serviceFactory ServiceFactory[*service.AdminService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		adminService := serviceFactory(c.UserContext())
+
+		boardID, err := uuid.Parse(c.Params("boardID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given board_id format in path is not correct"))
+		}
+		var req presenter.ForceTransferReq
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+		if err := BodyValidator(req); err != nil {
+			return presenter.BadRequest(c, err)
+		}
+
+		if err := adminService.ForceTransferOwnership(c.UserContext(), boardID, req.UserID); err != nil {
+			return sendAdminError(c, err)
+		}
+		return presenter.NoContent(c)
+	}
+}
+
+// GetSystemStats returns the system counters.
+// @Summary System stats
+// @Description Counts the users, suspended users, boards, archived boards, workspaces, tasks and comments. Admins only.
+// @Tags Admin
+// @Produce  json
+// @Success 200 {object} presenter.StatsResp "stats"
+// @Failure 403 {object} map[string]interface{} "error: not an admin"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /admin/stats [get]
+func GetSystemStats(adminService *service.AdminService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		s, err := adminService.GetStats(c.UserContext())
+		if err != nil {
+			return presenter.InternalServerError(c, err)
+		}
+		return presenter.OK(c, "stats successfully fetched", presenter.StatsToResp(s))
+	}
+}
+
+func sendAdminError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, user.ErrUserNotFound), errors.Is(err, board.ErrBoardNotFound):
+		return presenter.NotFound(c, err)
+	case errors.Is(err, service.ErrCantManageYourself):
+		return presenter.BadRequest(c, err)
+	}
+	return presenter.InternalServerError(c, err)
+}
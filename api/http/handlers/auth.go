@@ -5,6 +5,8 @@ import (
 	"fmt"
 	presenter "server/api/http/handlers/presentor"
 	"server/internal/user"
+	"server/internal/verification"
+	"server/pkg/jwt"
 	"server/service"
 	"strings"
 	"time"
@@ -23,8 +25,9 @@ import (
 // @Failure 400 {object} map[string]interface{} "error: bad request, invalid email or password, or email already exists"
 // @Failure 500 {object} map[string]interface{} "error: internal server error"
 // @Router /register [post]
-func RegisterUser(authService *service.AuthService) fiber.Handler {
+func RegisterUser(serviceFactory ServiceFactory[*service.AuthService]) fiber.Handler {
 	return func(c *fiber.Ctx) error {
+		authService := serviceFactory(c.UserContext())
 
 		var req presenter.UserRegisterReq
 
@@ -38,7 +41,7 @@ func RegisterUser(authService *service.AuthService) fiber.Handler {
 
 		u := presenter.UserRegisterToUserDomain(&req)
 
-		newUser, err := authService.CreateUser(c.Context(), u)
+		newUser, err := authService.CreateUser(c.UserContext(), u)
 		if err != nil {
 			if errors.Is(err, user.ErrInvalidEmail) || errors.Is(err, user.ErrInvalidPassword) {
 				return presenter.BadRequest(c, err)
@@ -47,7 +50,8 @@ func RegisterUser(authService *service.AuthService) fiber.Handler {
 				return presenter.Conflict(c, err)
 			}
 
-			return presenter.InternalServerError(c, err)
+			// rolls back the user when the invitations could not be handed over
+			return SendError(c, err, fiber.StatusInternalServerError)
 		}
 
 		return presenter.Created(c, "user successfully registered", fiber.Map{
@@ -58,13 +62,14 @@ func RegisterUser(authService *service.AuthService) fiber.Handler {
 
 // LoginUser logs in an existing user.
 // @Summary Login an existing user
-// @Description Authenticate a user with email and password.
+// @Description Authenticate a user with email and password. A user with two-factor authentication gets a short-lived challenge_token instead of the tokens, to send to /login/2fa with a code.
 // @Tags Auth
 // @Accept  json
 // @Produce  json
 // @Param user body presenter.UserLoginReq true "User Login details"
-// @Success 200 {object} map[string]interface{} "auth_token: the authentication token for the user"
+// @Success 200 {object} map[string]interface{} "auth_token: the authentication token for the user, or challenge_token when a code is required"
 // @Failure 400 {object} map[string]interface{} "error: bad request, invalid email or password"
+// @Failure 403 {object} map[string]interface{} "error: the account is suspended"
 // @Router /login [post]
 func LoginUser(authService *service.AuthService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -86,18 +91,56 @@ func LoginUser(authService *service.AuthService) fiber.Handler {
 			SessionOnly: true,
 		})
 
-		authToken, err := authService.Login(c.Context(), req.Email, req.Password)
+		authToken, err := authService.Login(c.Context(), req.Email, req.Password, c.Get(fiber.HeaderUserAgent), c.IP())
 		if err != nil {
+			if errors.Is(err, user.ErrUserSuspended) {
+				return presenter.Forbidden(c, err)
+			}
+			return presenter.BadRequest(c, err)
+		}
+		if authToken.ChallengeToken != "" {
+			return presenter.OK(c, "two-factor code required", fiber.Map{
+				"two_factor_required": true,
+				"challenge_token":     authToken.ChallengeToken,
+			})
+		}
+		return SendUserToken(c, authToken)
+	}
+}
 
+// LoginWithCode finishes the login of a user with two-factor authentication.
+// @Summary Login with a two-factor code
+// @Description Exchanges the challenge token of /login and a code of the authenticator app, or a recovery code, for the authentication and refresh tokens. Each code works once.
+// @Tags Auth
+// @Accept  json
+// @Produce  json
+// @Param code body presenter.LoginWithCodeReq true "Challenge token and code"
+// @Success 200 {object} map[string]interface{} "auth_token: the authentication token for the user"
+// @Failure 400 {object} map[string]interface{} "error: invalid code"
+// @Failure 401 {object} map[string]interface{} "error: invalid or expired challenge token, or one already used or tried with too many wrong codes"
+// @Failure 403 {object} map[string]interface{} "error: the account is suspended"
+// @Router /login/2fa [post]
+func LoginWithCode(authService *service.AuthService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var req presenter.LoginWithCodeReq
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+		if err := BodyValidator(req); err != nil {
 			return presenter.BadRequest(c, err)
 		}
+
+		authToken, err := authService.LoginWithCode(c.UserContext(), req.ChallengeToken, req.Code, c.Get(fiber.HeaderUserAgent), c.IP())
+		if err != nil {
+			return sendTwoFactorError(c, err)
+		}
 		return SendUserToken(c, authToken)
 	}
 }
 
 // RefreshToken refreshes the authentication token.
 // @Summary Refresh authentication token
-// @Description Refresh the user's authentication token using a valid refresh token.
+// @Description Exchanges a refresh token for a new authentication token and a new refresh token. A refresh token works once: presenting it again revokes its session.
 // @Tags Auth
 // @Accept  json
 // @Produce  json
@@ -105,15 +148,15 @@ func LoginUser(authService *service.AuthService) fiber.Handler {
 // @Failure 400 {object} map[string]interface{} "error: bad request, token should be provided"
 // @Failure 401 {object} map[string]interface{} "error: unauthorized, invalid or expired token"
 // @Security BearerAuth
-// @Router /refresh-token [post]
+// @Router /refresh [get]
 func RefreshToken(authService *service.AuthService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		refToken := c.GetReqHeaders()["Authorization"][0]
-		if len(refToken) == 0 {
+		parts := strings.Split(c.Get(fiber.HeaderAuthorization), " ")
+		if len(parts) != 2 || len(parts[1]) == 0 {
 			return SendError(c, errors.New("token should be provided"), fiber.StatusBadRequest)
 		}
-		pureToken := strings.Split(refToken, " ")[1]
-		authToken, err := authService.RefreshAuth(c.UserContext(), pureToken)
+		pureToken := parts[1]
+		authToken, err := authService.RefreshAuth(c.UserContext(), pureToken, c.IP())
 		if err != nil {
 
 			return presenter.Unauthorized(c, err)
@@ -122,3 +165,140 @@ func RefreshToken(authService *service.AuthService) fiber.Handler {
 		return SendUserToken(c, authToken)
 	}
 }
+
+// VerifyEmail verifies the email of a user.
+// @Summary Verify email
+// @Description Verifies the email of the user the token was mailed to. A token works once and expires after a while.
+// @Tags Auth
+// @Accept  json
+// @Param token body presenter.VerifyEmailReq true "The token from the mailed link"
+// @Success 204
+// @Failure 400 {object} map[string]interface{} "error: invalid, expired or used token"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Router /verify-email [post]
+func VerifyEmail(serviceFactory ServiceFactory[*service.AuthService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		authService := serviceFactory(c.UserContext())
+
+		var req presenter.VerifyEmailReq
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+		if err := BodyValidator(req); err != nil {
+			return presenter.BadRequest(c, err)
+		}
+
+		if err := authService.VerifyEmail(c.UserContext(), req.Token); err != nil {
+			return sendVerificationError(c, err)
+		}
+		return presenter.NoContent(c)
+	}
+}
+
+// ResendVerification mails the current user a new verification link.
+// @Summary Resend verification mail
+// @Description Mails the current user a new link to verify their email, the links mailed before stop working.
+// @Tags Auth
+// @Success 204
+// @Failure 400 {object} map[string]interface{} "error: the email is already verified"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /me/verification [post]
+func ResendVerification(serviceFactory ServiceFactory[*service.AuthService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		authService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+
+		if err := authService.ResendVerification(c.UserContext(), userClaims.UserID); err != nil {
+			return sendVerificationError(c, err)
+		}
+		return presenter.NoContent(c)
+	}
+}
+
+// ForgotPassword mails a link to reset a password.
+// @Summary Forgot password
+// @Description Mails a link to reset the password to the given email when an account uses it. The response is the same either way.
+// @Tags Auth
+// @Accept  json
+// @Param email body presenter.ForgotPasswordReq true "The email of the account"
+// @Success 204
+// @Failure 400 {object} map[string]interface{} "error: bad request"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Router /forgot-password [post]
+func ForgotPassword(serviceFactory ServiceFactory[*service.AuthService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		authService := serviceFactory(c.UserContext())
+
+		var req presenter.ForgotPasswordReq
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+		if err := BodyValidator(req); err != nil {
+			return presenter.BadRequest(c, err)
+		}
+
+		if err := authService.RequestPasswordReset(c.UserContext(), req.Email); err != nil {
+			return presenter.InternalServerError(c, err)
+		}
+		return presenter.NoContent(c)
+	}
+}
+
+// ResetPassword sets a new password with a mailed token.
+// @Summary Reset password
+// @Description Sets a new password for the user the token was mailed to and verifies their email. A token works once and expires after a while.
+// @Tags Auth
+// @Accept  json
+// @Param reset body presenter.ResetPasswordReq true "The token from the mailed link and the new password"
+// @Success 204
+// @Failure 400 {object} map[string]interface{} "error: invalid, expired or used token, or invalid password"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Router /reset-password [post]
+func ResetPassword(serviceFactory ServiceFactory[*service.AuthService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		authService := serviceFactory(c.UserContext())
+
+		var req presenter.ResetPasswordReq
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+		if err := BodyValidator(req); err != nil {
+			return presenter.BadRequest(c, err)
+		}
+
+		if err := authService.ResetPassword(c.UserContext(), req.Token, req.NewPassword); err != nil {
+			return sendVerificationError(c, err)
+		}
+		return presenter.NoContent(c)
+	}
+}
+
+func sendVerificationError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, verification.ErrInvalidToken), errors.Is(err, service.ErrEmailAlreadyVerified),
+		errors.Is(err, user.ErrInvalidPassword):
+		return presenter.BadRequest(c, err)
+	case errors.Is(err, user.ErrUserNotFound):
+		return presenter.NotFound(c, err)
+	}
+	return presenter.InternalServerError(c, err)
+}
+
+// GetJWKS publishes the keys tokens are verified with.
+// @Summary JSON Web Key Set
+// @Description Lists the public keys of the RS256 and EdDSA keys that are not retired, including the ones meant to sign next, so other services can verify the tokens. Served at /.well-known/jwks.json, outside the api prefix.
+// @Tags Auth
+// @Produce  json
+// @Success 200 {object} jwt.JWKS "the key set"
+// @Router /.well-known/jwks.json [get]
+func GetJWKS(keys *jwt.KeySet) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+		return c.JSON(keys.JWKS())
+	}
+}
@@ -5,6 +5,7 @@ import (
 	presenter "server/api/http/handlers/presentor"
 	"server/internal/board"
 	"server/internal/user"
+	userboardrole "server/internal/user_board_role"
 	"server/pkg/jwt"
 	"server/service"
 	"time"
@@ -23,6 +24,7 @@ import (
 // @Param uesrID path string true "User ID"
 // @Param page query int false "Page number"
 // @Param page_size query int false "Page size"
+// @Param archived query bool false "Include archived boards"
 // @Success 200 {object} presenter.BoardUserResp "boards: paginated list of user's boards"
 // @Failure 400 {object} map[string]interface{} "error: bad request, wrong claim type"
 // @Failure 500 {object} map[string]interface{} "error: internal server error"
@@ -37,7 +39,7 @@ func GetUserBoards(boardService *service.BoardService) fiber.Handler {
 		//query parameter
 		page, pageSize := PageAndPageSize(c)
 
-		boards, total, err := boardService.GetUserBoards(c.UserContext(), userClaims.UserID, uint(page), uint(pageSize))
+		boards, total, err := boardService.GetUserBoards(c.UserContext(), userClaims.UserID, uint(page), uint(pageSize), c.QueryBool("archived"))
 		if err != nil {
 			status := fiber.StatusInternalServerError
 			if errors.Is(err, user.ErrUserNotFound) {
@@ -64,6 +66,7 @@ func GetUserBoards(boardService *service.BoardService) fiber.Handler {
 // @Param userID path string true "User ID"
 // @Param page query int false "Page number"
 // @Param page_size query int false "Page size"
+// @Param archived query bool false "Include archived boards"
 // @Success 200 {object} presenter.BoardUserResp "boards: paginated list of public boards"
 // @Failure 400 {object} map[string]interface{} "error: bad request, wrong claim type"
 // @Failure 500 {object} map[string]interface{} "error: internal server error"
@@ -78,7 +81,7 @@ func GetPublicBoards(boardService *service.BoardService) fiber.Handler {
 		//query parameter
 		page, pageSize := PageAndPageSize(c)
 
-		boards, total, err := boardService.GetPublicBoards(c.UserContext(), userClaims.UserID, uint(page), uint(pageSize))
+		boards, total, err := boardService.GetPublicBoards(c.UserContext(), userClaims.UserID, uint(page), uint(pageSize), c.QueryBool("archived"))
 		if err != nil {
 			status := fiber.StatusInternalServerError
 			if errors.Is(err, user.ErrUserNotFound) {
@@ -133,13 +136,14 @@ func GetFullBoardByID(boardService *service.BoardService) fiber.Handler {
 
 // CreateUserBoard creates a new board for the user.
 // @Summary Create user board
-// @Description Create a new board for the authenticated user.
+// @Description Create a new board for the authenticated user. With workspace_id the board belongs to that workspace, which needs the admin or member workspace role, and its members get their default roles on it.
 // @Tags Boards
 // @Accept  json
 // @Produce  json
 // @Param board body presenter.CreateBoardReq true "Board details"
 // @Success 201 {object} presenter.CreateBoardResponse "board: the created board details"
 // @Failure 400 {object} map[string]interface{} "error: bad request, invalid board details"
+// @Failure 403 {object} map[string]interface{} "error: not allowed to create boards in the workspace"
 // @Failure 500 {object} map[string]interface{} "error: internal server error"
 // @Security BearerAuth
 // @Router /boards [post]
@@ -161,9 +165,12 @@ func CreateUserBoard(serviceFactory ServiceFactory[*service.BoardService]) fiber
 		b, ubr := presenter.UserBoardToBoard(&req, userClaims.UserID)
 		b.CreatedAt = time.Now()
 		if err := boardService.CreateBoard(c.UserContext(), b, ubr); err != nil {
-			if errors.Is(err, user.ErrUserNotFound) || errors.Is(err, board.ErrWrongType) || errors.Is(err, board.ErrInvalidName) {
+			if errors.Is(err, user.ErrUserNotFound) || errors.Is(err, board.ErrWrongType) || errors.Is(err, board.ErrWrongWIPPolicy) || errors.Is(err, board.ErrInvalidName) || errors.Is(err, board.ErrLongDescription) || errors.Is(err, board.ErrNoWorkspace) {
 				return presenter.BadRequest(c, err)
 			}
+			if errors.Is(err, service.ErrPermissionDenied) {
+				return presenter.Forbidden(c, err)
+			}
 
 			return presenter.InternalServerError(c, err)
 		}
@@ -172,49 +179,130 @@ func CreateUserBoard(serviceFactory ServiceFactory[*service.BoardService]) fiber
 	}
 }
 
-// InviteToBoard invites a user to a board.
-// @Summary Invite user to board
-// @Description Invite a user to a board with a specified role.
+// UpdateBoard partially updates a board.
+// @Summary Update board
+// @Description Changes the name, description, visibility (type) and wip policy of a board; fields left out are untouched. Owners only, the other members are notified.
 // @Tags Boards
 // @Accept  json
 // @Produce  json
-// @Param invite body presenter.InviteUserToBoard true "Invitation details"
-// @Success 200 {object} presenter.InviteMemberResponse "invite: the details of the invitation"
-// @Failure 400 {object} map[string]interface{} "error: bad request, invalid invitation details"
-// @Failure 403 {object} map[string]interface{} "error: forbidden, permission denied to invite"
+// @Param boardID path string true "Board ID"
+// @Param board body presenter.UpdateBoardReq true "Fields to update"
+// @Success 200 {object} presenter.UserBoard "the updated board"
+// @Failure 400 {object} map[string]interface{} "error: bad request, invalid fields"
+// @Failure 403 {object} map[string]interface{} "error: permission denied"
+// @Failure 404 {object} map[string]interface{} "error: board not found"
 // @Failure 500 {object} map[string]interface{} "error: internal server error"
 // @Security BearerAuth
-// @Router /boards/invite [post]
-func InviteToBoard(serviceFactory ServiceFactory[*service.BoardService]) fiber.Handler {
+// @Router /boards/{boardID} [patch]
+func UpdateBoard(serviceFactory ServiceFactory[*service.BoardService]) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		boardService := serviceFactory(c.UserContext())
 
-		var req presenter.InviteUserToBoard
-
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		boardID, err := uuid.Parse(c.Params("boardID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given board_id format in path is not correct"))
+		}
+		var req presenter.UpdateBoardReq
 		if err := c.BodyParser(&req); err != nil {
 			return SendError(c, err, fiber.StatusBadRequest)
 		}
 
+		b, err := boardService.UpdateBoard(c.UserContext(), userClaims.UserID, boardID, presenter.UpdateBoardReqToUpdateFields(&req))
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrPermissionDenied):
+				return presenter.Forbidden(c, err)
+			case errors.Is(err, board.ErrBoardNotFound):
+				return presenter.NotFound(c, err)
+			case errors.Is(err, board.ErrBoardArchived):
+				return presenter.Conflict(c, err)
+			case errors.Is(err, board.ErrInvalidName), errors.Is(err, board.ErrLongDescription),
+				errors.Is(err, board.ErrWrongType), errors.Is(err, board.ErrWrongWIPPolicy),
+				errors.Is(err, board.ErrNothingToUpdate), errors.Is(err, board.ErrNoWorkspace):
+				return presenter.BadRequest(c, err)
+			}
+			return presenter.InternalServerError(c, err)
+		}
+		return presenter.OK(c, "board successfully updated", presenter.BatchBoardsToUserBoard([]board.Board{*b})[0])
+	}
+}
+
+// ArchiveBoard archives a board.
+// @Summary Archive board
+// @Description Makes a board read-only for every role and hides it from the board lists unless archived=true is given. Owners only, the other members are notified.
+// @Tags Boards
+// @Produce  json
+// @Param boardID path string true "Board ID"
+// @Success 200 {object} presenter.UserBoard "the archived board"
+// @Failure 400 {object} map[string]interface{} "error: invalid board id"
+// @Failure 403 {object} map[string]interface{} "error: permission denied"
+// @Failure 404 {object} map[string]interface{} "error: board not found"
+// @Failure 409 {object} map[string]interface{} "error: board already archived"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /boards/{boardID}/archive [post]
+func ArchiveBoard(serviceFactory ServiceFactory[*service.BoardService]) fiber.Handler {
+	return setBoardArchived(serviceFactory, true)
+}
+
+// UnarchiveBoard brings an archived board back.
+// @Summary Unarchive board
+// @Description Makes an archived board writable and listed again. Owners only, the other members are notified.
+// @Tags Boards
+// @Produce  json
+// @Param boardID path string true "Board ID"
+// @Success 200 {object} presenter.UserBoard "the board"
+// @Failure 400 {object} map[string]interface{} "error: invalid board id"
+// @Failure 403 {object} map[string]interface{} "error: permission denied"
+// @Failure 404 {object} map[string]interface{} "error: board not found"
+// @Failure 409 {object} map[string]interface{} "error: board is not archived"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /boards/{boardID}/unarchive [post]
+func UnarchiveBoard(serviceFactory ServiceFactory[*service.BoardService]) fiber.Handler {
+	return setBoardArchived(serviceFactory, false)
+}
+
+func setBoardArchived(serviceFactory ServiceFactory[*service.BoardService], archive bool) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		boardService := serviceFactory(c.UserContext())
+
 		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
 		if !ok {
 			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
 		}
+		boardID, err := uuid.Parse(c.Params("boardID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given board_id format in path is not correct"))
+		}
 
-		ubr := presenter.InviteUserToBoardToUserBoardRole(&req)
-		if err := boardService.InviteUser(c.UserContext(), userClaims.UserID, req.Email, ubr); err != nil {
-			if errors.Is(err, service.ErrPermissionDeniedToInvite) {
+		var b *board.Board
+		message := "board successfully archived"
+		if archive {
+			b, err = boardService.ArchiveBoard(c.UserContext(), userClaims.UserID, boardID)
+		} else {
+			message = "board successfully unarchived"
+			b, err = boardService.UnarchiveBoard(c.UserContext(), userClaims.UserID, boardID)
+		}
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrPermissionDenied):
 				return presenter.Forbidden(c, err)
-			}
-			if errors.Is(err, user.ErrUserNotFound) || errors.Is(err, service.ErrAMember) || errors.Is(err, service.ErrOwnerExists) || errors.Is(err, service.ErrUndefinedRole) {
-				return presenter.BadRequest(c, err)
+			case errors.Is(err, board.ErrBoardNotFound):
+				return presenter.NotFound(c, err)
+			case errors.Is(err, board.ErrBoardArchived), errors.Is(err, board.ErrBoardNotArchived):
+				return presenter.Conflict(c, err)
 			}
 			return presenter.InternalServerError(c, err)
 		}
-		res := presenter.InviteMemberToInviteMemberResponse(ubr, req.Email)
-
-		return presenter.Created(c, "User successfully invited", res)
+		return presenter.OK(c, message, presenter.BatchBoardsToUserBoard([]board.Board{*b})[0])
 	}
 }
+
 // DeleteBoard deletes a board by its ID for the authenticated user.
 // @Summary Delete board
 // @Description Deletes a specific board by its ID for the authenticated user.
@@ -256,3 +344,316 @@ func DeleteBoard(boardService *service.BoardService) fiber.Handler {
 		return presenter.NoContent(c)
 	}
 }
+
+// GetBoardMembers lists the members of a board.
+// @Summary Get board members
+// @Description Lists the members of a board with their roles. Members of private boards only.
+// @Tags Boards
+// @Produce  json
+// @Param boardID path string true "Board ID"
+// @Success 200 {array} presenter.BoardMemberResp "members of the board"
+// @Failure 400 {object} map[string]interface{} "error: bad request"
+// @Failure 403 {object} map[string]interface{} "error: permission denied"
+// @Failure 404 {object} map[string]interface{} "error: board not found"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /boards/{boardID}/members [get]
+func GetBoardMembers(boardService *service.BoardService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		boardID, err := uuid.Parse(c.Params("boardID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given board_id format in path is not correct"))
+		}
+
+		members, err := boardService.GetBoardMembers(c.UserContext(), userClaims.UserID, boardID)
+		if err != nil {
+			return sendMembershipError(c, err)
+		}
+		return presenter.OK(c, "members successfully fetched.", presenter.BatchUserBoardRolesToBoardMemberResp(members))
+	}
+}
+
+// ChangeMemberRole changes the role of a board member.
+// @Summary Change member role
+// @Description Gives a member of the board a new role. Needs the set_role permission; the owner's role can not be changed.
+// @Tags Boards
+// @Accept  json
+// @Produce  json
+// @Param boardID path string true "Board ID"
+// @Param userID path string true "User ID of the member"
+// @Param role body presenter.ChangeMemberRoleReq true "New role"
+// @Success 200 {object} presenter.BoardMemberResp "the updated member"
+// @Failure 400 {object} map[string]interface{} "error: bad request, invalid role or not a member"
+// @Failure 403 {object} map[string]interface{} "error: permission denied"
+// @Failure 404 {object} map[string]interface{} "error: board not found"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /boards/{boardID}/members/{userID} [patch]
+func ChangeMemberRole(serviceFactory ServiceFactory[*service.BoardService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		boardService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		boardID, err := uuid.Parse(c.Params("boardID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given board_id format in path is not correct"))
+		}
+		memberID, err := uuid.Parse(c.Params("userID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given user_id format in path is not correct"))
+		}
+		var req presenter.ChangeMemberRoleReq
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+
+		member, err := boardService.ChangeMemberRole(c.UserContext(), userClaims.UserID, boardID, memberID, req.Role)
+		if err != nil {
+			return sendMembershipError(c, err)
+		}
+		return presenter.OK(c, "role successfully changed", presenter.UserBoardRoleToBoardMemberResp(*member))
+	}
+}
+
+// RemoveBoardMember removes a member from a board.
+// @Summary Remove board member
+// @Description Removes a non-owner member from the board and unassigns their tasks. Their comments move to the member given in comments_to, otherwise they are kept as they are.
+// @Tags Boards
+// @Produce  json
+// @Param boardID path string true "Board ID"
+// @Param userID path string true "User ID of the member"
+// @Param comments_to query string false "User ID of the member who takes over the comments"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{} "error: bad request, not a member or owner"
+// @Failure 403 {object} map[string]interface{} "error: permission denied"
+// @Failure 404 {object} map[string]interface{} "error: board not found"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /boards/{boardID}/members/{userID} [delete]
+func RemoveBoardMember(serviceFactory ServiceFactory[*service.BoardService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		boardService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		boardID, err := uuid.Parse(c.Params("boardID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given board_id format in path is not correct"))
+		}
+		memberID, err := uuid.Parse(c.Params("userID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given user_id format in path is not correct"))
+		}
+		var commentsTo *uuid.UUID
+		if q := c.Query("comments_to"); q != "" {
+			id, err := uuid.Parse(q)
+			if err != nil {
+				return presenter.BadRequest(c, errors.New("given comments_to format is not correct"))
+			}
+			commentsTo = &id
+		}
+
+		if err := boardService.RemoveMember(c.UserContext(), userClaims.UserID, boardID, memberID, commentsTo); err != nil {
+			return sendMembershipError(c, err)
+		}
+		return presenter.NoContent(c)
+	}
+}
+
+// LeaveBoard lets the logged-in user leave a board.
+// @Summary Leave board
+// @Description The logged-in user leaves the board. The owner can not leave.
+// @Tags Boards
+// @Produce  json
+// @Param boardID path string true "Board ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{} "error: bad request, not a member or owner"
+// @Failure 404 {object} map[string]interface{} "error: board not found"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /boards/{boardID}/leave [post]
+func LeaveBoard(serviceFactory ServiceFactory[*service.BoardService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		boardService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		boardID, err := uuid.Parse(c.Params("boardID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given board_id format in path is not correct"))
+		}
+
+		if err := boardService.LeaveBoard(c.UserContext(), userClaims.UserID, boardID); err != nil {
+			return sendMembershipError(c, err)
+		}
+		return presenter.NoContent(c)
+	}
+}
+
+// GetOwnershipTransfer returns the pending ownership transfer of a board.
+// @Summary Get ownership transfer
+// @Description Returns the pending ownership transfer of the board to its members.
+// @Tags Boards
+// @Produce  json
+// @Param boardID path string true "Board ID"
+// @Success 200 {object} presenter.OwnershipTransferResp "the pending transfer"
+// @Failure 400 {object} map[string]interface{} "error: bad request"
+// @Failure 403 {object} map[string]interface{} "error: permission denied"
+// @Failure 404 {object} map[string]interface{} "error: no pending transfer"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /boards/{boardID}/ownership-transfer [get]
+func GetOwnershipTransfer(boardService *service.BoardService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		boardID, err := uuid.Parse(c.Params("boardID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given board_id format in path is not correct"))
+		}
+
+		transfer, err := boardService.GetOwnershipTransfer(c.UserContext(), userClaims.UserID, boardID)
+		if err != nil {
+			return sendMembershipError(c, err)
+		}
+		return presenter.OK(c, "ownership transfer successfully fetched.", presenter.OwnershipTransferToResp(transfer))
+	}
+}
+
+// ProposeOwnershipTransfer offers the ownership of a board to another member.
+// @Summary Propose ownership transfer
+// @Description Offers the ownership of the board to another member, replacing any pending offer. With keep_ownership the proposer stays an owner and the member becomes a co-owner once they accept.
+// @Tags Boards
+// @Accept  json
+// @Produce  json
+// @Param boardID path string true "Board ID"
+// @Param transfer body presenter.ProposeOwnershipTransferReq true "Target member"
+// @Success 201 {object} presenter.OwnershipTransferResp "the pending transfer"
+// @Failure 400 {object} map[string]interface{} "error: bad request, not a member or already an owner"
+// @Failure 403 {object} map[string]interface{} "error: permission denied"
+// @Failure 404 {object} map[string]interface{} "error: board not found"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /boards/{boardID}/ownership-transfer [post]
+func ProposeOwnershipTransfer(serviceFactory ServiceFactory[*service.BoardService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		boardService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		boardID, err := uuid.Parse(c.Params("boardID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given board_id format in path is not correct"))
+		}
+		var req presenter.ProposeOwnershipTransferReq
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+
+		transfer, err := boardService.ProposeOwnershipTransfer(c.UserContext(), userClaims.UserID, boardID, req.UserID, req.KeepOwnership)
+		if err != nil {
+			return sendMembershipError(c, err)
+		}
+		return presenter.Created(c, "ownership transfer successfully proposed", presenter.OwnershipTransferToResp(transfer))
+	}
+}
+
+// AcceptOwnershipTransfer accepts the pending ownership transfer of a board.
+// @Summary Accept ownership transfer
+// @Description The member the ownership was offered to becomes an owner of the board.
+// @Tags Boards
+// @Produce  json
+// @Param boardID path string true "Board ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{} "error: bad request"
+// @Failure 403 {object} map[string]interface{} "error: the transfer is not addressed to the user"
+// @Failure 404 {object} map[string]interface{} "error: no pending transfer"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /boards/{boardID}/ownership-transfer/accept [post]
+func AcceptOwnershipTransfer(serviceFactory ServiceFactory[*service.BoardService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		boardService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		boardID, err := uuid.Parse(c.Params("boardID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given board_id format in path is not correct"))
+		}
+
+		accepted, err := boardService.AcceptOwnershipTransfer(c.UserContext(), userClaims.UserID, boardID)
+		if err != nil {
+			return sendMembershipError(c, err)
+		}
+		if !accepted {
+			// the void offer is gone now, which has to be committed
+			return presenter.NotFound(c, userboardrole.ErrTransferNotFound)
+		}
+		return presenter.NoContent(c)
+	}
+}
+
+// CancelOwnershipTransfer declines or withdraws the pending ownership transfer of a board.
+// @Summary Decline or cancel ownership transfer
+// @Description The member the ownership was offered to declines it, an owner withdraws it.
+// @Tags Boards
+// @Produce  json
+// @Param boardID path string true "Board ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{} "error: bad request"
+// @Failure 403 {object} map[string]interface{} "error: permission denied"
+// @Failure 404 {object} map[string]interface{} "error: no pending transfer"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /boards/{boardID}/ownership-transfer [delete]
+func CancelOwnershipTransfer(serviceFactory ServiceFactory[*service.BoardService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		boardService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		boardID, err := uuid.Parse(c.Params("boardID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given board_id format in path is not correct"))
+		}
+
+		if err := boardService.CancelOwnershipTransfer(c.UserContext(), userClaims.UserID, boardID); err != nil {
+			return sendMembershipError(c, err)
+		}
+		return presenter.NoContent(c)
+	}
+}
+
+func sendMembershipError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, service.ErrPermissionDenied), errors.Is(err, service.ErrNotTransferTarget):
+		return presenter.Forbidden(c, err)
+	case errors.Is(err, board.ErrBoardNotFound), errors.Is(err, userboardrole.ErrTransferNotFound):
+		return presenter.NotFound(c, err)
+	case errors.Is(err, service.ErrUserNotMember), errors.Is(err, service.ErrOwnerExists),
+		errors.Is(err, service.ErrUndefinedRole), errors.Is(err, service.ErrCantRemoveOwner),
+		errors.Is(err, service.ErrLastOwner), errors.Is(err, service.ErrCantManageYourself):
+		return presenter.BadRequest(c, err)
+	}
+	return presenter.InternalServerError(c, err)
+}
@@ -5,6 +5,7 @@ import (
 	presenter "server/api/http/handlers/presentor"
 	"server/internal/board"
 	"server/internal/column"
+	"server/pkg/fp"
 	"server/pkg/jwt"
 	"server/service"
 
@@ -48,6 +49,9 @@ func CreateColumns(columnService *service.ColumnService) fiber.Handler {
 			if errors.Is(err, service.ErrPermissionDeniedToDeleteColumn) {
 				presenter.Forbidden(c, err)
 			}
+			if errors.Is(err, board.ErrBoardArchived) {
+				return presenter.Conflict(c, err)
+			}
 			if errors.Is(err, board.ErrBoardNotFound) {
 				return presenter.BadRequest(c, err)
 			}
@@ -90,6 +94,9 @@ func DeleteColumn(columnService *service.ColumnService) fiber.Handler {
 			if errors.Is(err, service.ErrPermissionDeniedToDeleteColumn) {
 				presenter.Forbidden(c, err)
 			}
+			if errors.Is(err, board.ErrBoardArchived) {
+				return presenter.Conflict(c, err)
+			}
 			if errors.Is(err, column.ErrColumnNotEmpty) {
 				return presenter.BadRequest(c, err)
 			}
@@ -133,9 +140,12 @@ func ReorderColumns(serviceFactory ServiceFactory[*service.ColumnService]) fiber
 		cols, err := columnService.ReorderColumns(c.UserContext(), userClaims.UserID, boardID, newOrder)
 		if err != nil {
 			if errors.Is(err, service.ErrPermissionDeniedToDeleteColumn) {
-				presenter.Forbidden(c, err)
+				return presenter.Forbidden(c, err)
 			}
-			if errors.Is(err, column.ErrColumnNotFound) || errors.Is(err, column.ErrFailedToFetchColumns) || errors.Is(err, column.ErrFailedToUpdateColumn) || errors.Is(err, column.ErrInvalidColumnID) || errors.Is(err, column.ErrLengthMismatch) {
+			if errors.Is(err, board.ErrBoardArchived) {
+				return presenter.Conflict(c, err)
+			}
+			if errors.Is(err, column.ErrColumnNotFound) || errors.Is(err, column.ErrFailedToFetchColumns) || errors.Is(err, column.ErrFailedToUpdateColumn) || errors.Is(err, column.ErrInvalidColumnID) || errors.Is(err, column.ErrLengthMismatch) || errors.Is(err, column.ErrInvalidOrder) {
 				return presenter.BadRequest(c, err)
 			}
 			return presenter.InternalServerError(c, err)
@@ -144,3 +154,246 @@ func ReorderColumns(serviceFactory ServiceFactory[*service.ColumnService]) fiber
 		return presenter.OK(c, "Columns ReOrdered Successfully", res)
 	}
 }
+
+// UpdateColumnKind sets the kind of a column.
+// @Summary Update column kind
+// @Description Mark a column as backlog, in_progress or done. Tasks moved into a done column are completed and release the tasks depending on them, whatever the column is called.
+// @Tags Columns
+// @Accept  json
+// @Produce  json
+// @Param columnID path string true "Column ID"
+// @Param UpdateColumnKindRequest body presenter.UpdateColumnKindRequest true "New kind"
+// @Success 200 {object} presenter.ColumnResponseItem "Column kind updated successfully"
+// @Failure 400 {object} map[string]interface{} "Bad request, invalid column ID or kind"
+// @Failure 403 {object} map[string]interface{} "Forbidden, permission denied"
+// @Failure 404 {object} map[string]interface{} "Not found, column not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /columns/{columnID}/kind [put]
+func UpdateColumnKind(serviceFactory ServiceFactory[*service.ColumnService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		columnService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		columnID, err := uuid.Parse(c.Params("columnID"))
+		if err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+		var req presenter.UpdateColumnKindRequest
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+
+		col, err := columnService.UpdateColumnKind(c.UserContext(), userClaims.UserID, columnID, column.Kind(req.Kind))
+		if err != nil {
+			if errors.Is(err, service.ErrPermissionDenied) {
+				return presenter.Forbidden(c, err)
+			}
+			if errors.Is(err, board.ErrBoardArchived) {
+				return presenter.Conflict(c, err)
+			}
+			if errors.Is(err, column.ErrInvalidKind) {
+				return presenter.BadRequest(c, err)
+			}
+			if errors.Is(err, column.ErrColumnNotFound) {
+				return presenter.NotFound(c, err)
+			}
+			return presenter.InternalServerError(c, err)
+		}
+		return presenter.OK(c, "Column kind updated successfully", presenter.ColumnToColumnResponseItem(*col))
+	}
+}
+
+// UpdateColumn renames a column or changes its WIP limit.
+// @Summary Update column
+// @Description Rename a column and/or set its work in progress limit. Fields that are not given are left untouched; a wip_limit of 0 removes the limit. Depending on the board's wip_policy, putting a task in a full column is refused or only warned about.
+// @Tags Columns
+// @Accept  json
+// @Produce  json
+// @Param columnID path string true "Column ID"
+// @Param UpdateColumnRequest body presenter.UpdateColumnRequest true "Fields to update"
+// @Success 200 {object} presenter.ColumnResponseItem "Column updated successfully"
+// @Failure 400 {object} map[string]interface{} "Bad request, invalid column ID, name or nothing to update"
+// @Failure 403 {object} map[string]interface{} "Forbidden, permission denied"
+// @Failure 404 {object} map[string]interface{} "Not found, column not found"
+// @Failure 409 {object} map[string]interface{} "Conflict, the board already has a column with this name"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /columns/{columnID} [patch]
+func UpdateColumn(serviceFactory ServiceFactory[*service.ColumnService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		columnService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		columnID, err := uuid.Parse(c.Params("columnID"))
+		if err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+		var req presenter.UpdateColumnRequest
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+
+		col, err := columnService.UpdateColumn(c.UserContext(), userClaims.UserID, columnID, presenter.UpdateColumnRequestToUpdateFields(&req))
+		if err != nil {
+			if errors.Is(err, service.ErrPermissionDenied) {
+				return presenter.Forbidden(c, err)
+			}
+			if errors.Is(err, board.ErrBoardArchived) {
+				return presenter.Conflict(c, err)
+			}
+			if errors.Is(err, column.ErrInvalidName) || errors.Is(err, column.ErrNothingToUpdate) {
+				return presenter.BadRequest(c, err)
+			}
+			if errors.Is(err, column.ErrColumnNotFound) {
+				return presenter.NotFound(c, err)
+			}
+			if errors.Is(err, column.ErrDuplicateName) {
+				return SendError(c, err, fiber.StatusConflict)
+			}
+			return presenter.InternalServerError(c, err)
+		}
+		return presenter.OK(c, "Column updated successfully", presenter.ColumnToColumnResponseItem(*col))
+	}
+}
+
+// GetColumnTransitions lists the workflow of a board.
+// @Summary Get column transitions
+// @Description List the allowed moves between the columns of a board. Tasks may leave a column only through its transitions; columns without any transition are free.
+// @Tags Columns
+// @Produce  json
+// @Param boardID path string true "Board ID"
+// @Success 200 {object} []presenter.ColumnTransitionItem "Transitions fetched successfully"
+// @Failure 400 {object} map[string]interface{} "Bad request, invalid board ID"
+// @Failure 403 {object} map[string]interface{} "Forbidden, permission denied"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /boards/{boardID}/transitions [get]
+func GetColumnTransitions(columnService *service.ColumnService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		boardID, err := uuid.Parse(c.Params("boardID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given board_id format in path is not correct"))
+		}
+
+		transitions, err := columnService.GetTransitions(c.UserContext(), userClaims.UserID, boardID)
+		if err != nil {
+			if errors.Is(err, service.ErrPermissionDenied) {
+				return presenter.Forbidden(c, err)
+			}
+			return presenter.InternalServerError(c, err)
+		}
+		return presenter.OK(c, "Transitions fetched successfully", presenter.BatchTransitionToColumnTransitionItem(transitions))
+	}
+}
+
+// SetColumnTransitions replaces the workflow of a board.
+// @Summary Set column transitions
+// @Description Replace all the allowed moves between the columns of a board. Each transition may list the roles allowed to perform it, no roles means every role that can move tasks. An empty list removes every restriction.
+// @Tags Columns
+// @Accept  json
+// @Produce  json
+// @Param boardID path string true "Board ID"
+// @Param SetColumnTransitionsRequest body presenter.SetColumnTransitionsRequest true "Transitions"
+// @Success 200 {object} []presenter.ColumnTransitionItem "Transitions updated successfully"
+// @Failure 400 {object} map[string]interface{} "Bad request, invalid board ID, column or role"
+// @Failure 403 {object} map[string]interface{} "Forbidden, permission denied"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /boards/{boardID}/transitions [put]
+func SetColumnTransitions(serviceFactory ServiceFactory[*service.ColumnService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		columnService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		boardID, err := uuid.Parse(c.Params("boardID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given board_id format in path is not correct"))
+		}
+		var req presenter.SetColumnTransitionsRequest
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+
+		transitions := fp.Map(req.Transitions, presenter.ColumnTransitionItemToTransition)
+		transitions, err = columnService.SetTransitions(c.UserContext(), userClaims.UserID, boardID, transitions)
+		if err != nil {
+			if errors.Is(err, service.ErrPermissionDenied) {
+				return presenter.Forbidden(c, err)
+			}
+			if errors.Is(err, board.ErrBoardArchived) {
+				return presenter.Conflict(c, err)
+			}
+			if errors.Is(err, column.ErrInvalidTransition) || errors.Is(err, service.ErrUndefinedRole) {
+				return presenter.BadRequest(c, err)
+			}
+			return presenter.InternalServerError(c, err)
+		}
+		return presenter.OK(c, "Transitions updated successfully", presenter.BatchTransitionToColumnTransitionItem(transitions))
+	}
+}
+
+// MoveColumn moves a column to a position on its board.
+// @Summary Move column
+// @Description Put a column right after after_id and/or right before before_id, or last when neither is given. Only the moved column is written.
+// @Tags Columns
+// @Accept  json
+// @Produce  json
+// @Param columnID path string true "Column ID"
+// @Param MoveColumnRequest body presenter.MoveColumnRequest true "Target position"
+// @Success 200 {object} presenter.ColumnResponseItem "Column moved successfully"
+// @Failure 400 {object} map[string]interface{} "Bad request, invalid column ID or position"
+// @Failure 403 {object} map[string]interface{} "Forbidden, permission denied"
+// @Failure 404 {object} map[string]interface{} "Not found, column not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /columns/{columnID}/move [post]
+func MoveColumn(serviceFactory ServiceFactory[*service.ColumnService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		columnService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		columnID, err := uuid.Parse(c.Params("columnID"))
+		if err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+		var req presenter.MoveColumnRequest
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+
+		col, err := columnService.MoveColumn(c.UserContext(), userClaims.UserID, columnID, req.AfterID, req.BeforeID)
+		if err != nil {
+			if errors.Is(err, service.ErrPermissionDenied) {
+				return presenter.Forbidden(c, err)
+			}
+			if errors.Is(err, board.ErrBoardArchived) {
+				return presenter.Conflict(c, err)
+			}
+			if errors.Is(err, column.ErrInvalidPosition) {
+				return presenter.BadRequest(c, err)
+			}
+			if errors.Is(err, column.ErrColumnNotFound) {
+				return presenter.NotFound(c, err)
+			}
+			return presenter.InternalServerError(c, err)
+		}
+		return presenter.OK(c, "Column moved successfully", presenter.ColumnToColumnResponseItem(*col))
+	}
+}
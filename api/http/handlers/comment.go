@@ -3,6 +3,7 @@ package handlers
 import (
 	"errors"
 	presenter "server/api/http/handlers/presentor"
+	"server/internal/board"
 	"server/internal/task"
 	"server/pkg/jwt"
 	"server/service"
@@ -44,6 +45,9 @@ func CreateUserComment(serviceFactory ServiceFactory[*service.CommentService]) f
 			if errors.Is(err, service.ErrPermissionDenied) {
 				return presenter.Forbidden(c, err)
 			}
+			if errors.Is(err, board.ErrBoardArchived) {
+				return presenter.Conflict(c, err)
+			}
 			if errors.Is(err, task.ErrTaskNotFound) {
 				return presenter.BadRequest(c, err)
 			}
@@ -31,6 +31,23 @@ func SendError(c *fiber.Ctx, err error, status int) error {
 	})
 }
 
+// SendErrorWithDetails works like SendError and adds the given details next to the error message.
+func SendErrorWithDetails(c *fiber.Ctx, err error, status int, details map[string]any) error {
+	if status == 0 {
+		status = fiber.StatusInternalServerError
+	}
+
+	c.Locals(valuecontext.IsTxError, err)
+
+	body := map[string]any{
+		"error_msg": err.Error(),
+	}
+	for k, v := range details {
+		body[k] = v
+	}
+	return c.Status(status).JSON(body)
+}
+
 func SendUserToken(c *fiber.Ctx, authToken *service.UserToken) error {
 
 	return presenter.OK(c, "User successfully logged in", fiber.Map{
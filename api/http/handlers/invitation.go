@@ -0,0 +1,371 @@
+package handlers
+
+import (
+	"errors"
+	presenter "server/api/http/handlers/presentor"
+	"server/internal/board"
+	"server/internal/invitation"
+	"server/internal/user"
+	"server/pkg/jwt"
+	"server/service"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// InviteToBoard invites an email to a board.
+// @Summary Invite user to board
+// @Description Creates a pending invitation of an email to a board with a specified role. The email does not need an account yet; the invitation shows up for it once it registers.
+// @Tags Invitations
+// @Accept  json
+// @Produce  json
+// @Param invite body presenter.InviteUserToBoard true "Invitation details"
+// @Success 201 {object} presenter.InvitationResp "invite: the pending invitation"
+// @Failure 400 {object} map[string]interface{} "error: bad request, invalid invitation details"
+// @Failure 403 {object} map[string]interface{} "error: forbidden, permission denied to invite"
+// @Failure 409 {object} map[string]interface{} "error: a pending invitation already exists"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /boards/invite [post]
+func InviteToBoard(serviceFactory ServiceFactory[*service.InvitationService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		invitationService := serviceFactory(c.UserContext())
+
+		var req presenter.InviteUserToBoard
+
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+
+		inv := presenter.InviteUserToBoardToInvitation(&req)
+		if err := invitationService.InviteUser(c.UserContext(), userClaims.UserID, inv); err != nil {
+			return sendInvitationError(c, err)
+		}
+
+		return presenter.Created(c, "User successfully invited", presenter.InvitationToResp(*inv))
+	}
+}
+
+// GetBoardInvitations lists the pending invitations of a board.
+// @Summary Get board invitations
+// @Description Lists the pending, not expired invitations of a board. Needs the invite_users permission.
+// @Tags Invitations
+// @Produce  json
+// @Param boardID path string true "Board ID"
+// @Success 200 {array} presenter.InvitationResp "pending invitations"
+// @Failure 400 {object} map[string]interface{} "error: bad request"
+// @Failure 403 {object} map[string]interface{} "error: permission denied"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /boards/{boardID}/invitations [get]
+func GetBoardInvitations(invitationService *service.InvitationService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		boardID, err := uuid.Parse(c.Params("boardID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given board_id format in path is not correct"))
+		}
+
+		invitations, err := invitationService.GetBoardInvitations(c.UserContext(), userClaims.UserID, boardID)
+		if err != nil {
+			return sendInvitationError(c, err)
+		}
+		return presenter.OK(c, "invitations successfully fetched.", presenter.BatchInvitationsToResp(invitations))
+	}
+}
+
+// RevokeInvitation withdraws a pending invitation of a board.
+// @Summary Revoke invitation
+// @Description Withdraws a pending invitation of a board. Needs the invite_users permission.
+// @Tags Invitations
+// @Produce  json
+// @Param boardID path string true "Board ID"
+// @Param invitationID path string true "Invitation ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{} "error: bad request, invitation is not pending"
+// @Failure 403 {object} map[string]interface{} "error: permission denied"
+// @Failure 404 {object} map[string]interface{} "error: invitation not found"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /boards/{boardID}/invitations/{invitationID} [delete]
+func RevokeInvitation(serviceFactory ServiceFactory[*service.InvitationService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		invitationService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		boardID, err := uuid.Parse(c.Params("boardID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given board_id format in path is not correct"))
+		}
+		invitationID, err := uuid.Parse(c.Params("invitationID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given invitation_id format in path is not correct"))
+		}
+
+		if err := invitationService.RevokeInvitation(c.UserContext(), userClaims.UserID, boardID, invitationID); err != nil {
+			return sendInvitationError(c, err)
+		}
+		return presenter.NoContent(c)
+	}
+}
+
+// GetUserInvitations lists the pending invitations of the logged-in user.
+// @Summary Get my invitations
+// @Description Lists the pending, not expired invitations sent to the logged-in user.
+// @Tags Invitations
+// @Produce  json
+// @Success 200 {array} presenter.InvitationResp "pending invitations"
+// @Failure 400 {object} map[string]interface{} "error: bad request"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /invitations [get]
+func GetUserInvitations(invitationService *service.InvitationService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+
+		invitations, err := invitationService.GetUserInvitations(c.UserContext(), userClaims.UserID)
+		if err != nil {
+			return sendInvitationError(c, err)
+		}
+		return presenter.OK(c, "invitations successfully fetched.", presenter.BatchInvitationsToResp(invitations))
+	}
+}
+
+// AcceptInvitation accepts an invitation of the logged-in user.
+// @Summary Accept invitation
+// @Description The logged-in user joins the board of the invitation with its role.
+// @Tags Invitations
+// @Produce  json
+// @Param invitationID path string true "Invitation ID"
+// @Success 200 {object} presenter.JoinedBoardResp "the new membership"
+// @Failure 400 {object} map[string]interface{} "error: bad request, invitation expired or answered"
+// @Failure 404 {object} map[string]interface{} "error: invitation not found"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /invitations/{invitationID}/accept [post]
+func AcceptInvitation(serviceFactory ServiceFactory[*service.InvitationService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		invitationService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		invitationID, err := uuid.Parse(c.Params("invitationID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given invitation_id format in path is not correct"))
+		}
+
+		ubr, err := invitationService.AcceptInvitation(c.UserContext(), userClaims.UserID, invitationID)
+		if err != nil {
+			return sendInvitationError(c, err)
+		}
+		return presenter.OK(c, "invitation successfully accepted", presenter.UserBoardRoleToJoinedBoardResp(ubr))
+	}
+}
+
+// DeclineInvitation declines an invitation of the logged-in user.
+// @Summary Decline invitation
+// @Description The logged-in user refuses the invitation.
+// @Tags Invitations
+// @Produce  json
+// @Param invitationID path string true "Invitation ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{} "error: bad request, invitation expired or answered"
+// @Failure 404 {object} map[string]interface{} "error: invitation not found"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /invitations/{invitationID}/decline [post]
+func DeclineInvitation(serviceFactory ServiceFactory[*service.InvitationService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		invitationService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		invitationID, err := uuid.Parse(c.Params("invitationID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given invitation_id format in path is not correct"))
+		}
+
+		if err := invitationService.DeclineInvitation(c.UserContext(), userClaims.UserID, invitationID); err != nil {
+			return sendInvitationError(c, err)
+		}
+		return presenter.NoContent(c)
+	}
+}
+
+// CreateInviteLink creates a shareable link to join a board.
+// @Summary Create invite link
+// @Description Creates a signed link to join the board with a fixed role. max_uses and expires_in_hours are optional, 0 means no limit.
+// @Tags Invitations
+// @Accept  json
+// @Produce  json
+// @Param boardID path string true "Board ID"
+// @Param link body presenter.CreateInviteLinkReq true "Link settings"
+// @Success 201 {object} presenter.InviteLinkResp "the created link"
+// @Failure 400 {object} map[string]interface{} "error: bad request, invalid role"
+// @Failure 403 {object} map[string]interface{} "error: permission denied"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /boards/{boardID}/invite-links [post]
+func CreateInviteLink(serviceFactory ServiceFactory[*service.InvitationService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		invitationService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		boardID, err := uuid.Parse(c.Params("boardID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given board_id format in path is not correct"))
+		}
+		var req presenter.CreateInviteLinkReq
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+
+		link, err := invitationService.CreateInviteLink(c.UserContext(), userClaims.UserID, boardID, req.Role,
+			req.MaxUses, time.Duration(req.ExpiresInHours)*time.Hour)
+		if err != nil {
+			return sendInvitationError(c, err)
+		}
+		return presenter.Created(c, "invite link successfully created", presenter.InviteLinkToResp(*link))
+	}
+}
+
+// GetInviteLinks lists the invite links of a board.
+// @Summary Get invite links
+// @Description Lists every invite link of a board, revoked and used up ones included. Needs the invite_users permission.
+// @Tags Invitations
+// @Produce  json
+// @Param boardID path string true "Board ID"
+// @Success 200 {array} presenter.InviteLinkResp "invite links"
+// @Failure 400 {object} map[string]interface{} "error: bad request"
+// @Failure 403 {object} map[string]interface{} "error: permission denied"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /boards/{boardID}/invite-links [get]
+func GetInviteLinks(invitationService *service.InvitationService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		boardID, err := uuid.Parse(c.Params("boardID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given board_id format in path is not correct"))
+		}
+
+		links, err := invitationService.GetInviteLinks(c.UserContext(), userClaims.UserID, boardID)
+		if err != nil {
+			return sendInvitationError(c, err)
+		}
+		return presenter.OK(c, "invite links successfully fetched.", presenter.BatchInviteLinksToResp(links))
+	}
+}
+
+// RevokeInviteLink revokes an invite link of a board.
+// @Summary Revoke invite link
+// @Description Stops an invite link from being used. Needs the invite_users permission.
+// @Tags Invitations
+// @Produce  json
+// @Param boardID path string true "Board ID"
+// @Param linkID path string true "Link ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{} "error: bad request"
+// @Failure 403 {object} map[string]interface{} "error: permission denied"
+// @Failure 404 {object} map[string]interface{} "error: link not found"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /boards/{boardID}/invite-links/{linkID} [delete]
+func RevokeInviteLink(serviceFactory ServiceFactory[*service.InvitationService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		invitationService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		boardID, err := uuid.Parse(c.Params("boardID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given board_id format in path is not correct"))
+		}
+		linkID, err := uuid.Parse(c.Params("linkID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given link_id format in path is not correct"))
+		}
+
+		if err := invitationService.RevokeInviteLink(c.UserContext(), userClaims.UserID, boardID, linkID); err != nil {
+			return sendInvitationError(c, err)
+		}
+		return presenter.NoContent(c)
+	}
+}
+
+// JoinByInviteLink joins a board through an invite link.
+// @Summary Join board by invite link
+// @Description The logged-in user joins the board of the link with the role of the link.
+// @Tags Invitations
+// @Produce  json
+// @Param token path string true "Link token"
+// @Success 200 {object} presenter.JoinedBoardResp "the new membership"
+// @Failure 400 {object} map[string]interface{} "error: bad request, link revoked, expired or used up"
+// @Failure 404 {object} map[string]interface{} "error: link not found"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /invite-links/{token}/join [post]
+func JoinByInviteLink(serviceFactory ServiceFactory[*service.InvitationService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		invitationService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+
+		ubr, err := invitationService.JoinByLink(c.UserContext(), userClaims.UserID, c.Params("token"))
+		if err != nil {
+			return sendInvitationError(c, err)
+		}
+		return presenter.OK(c, "successfully joined the board", presenter.UserBoardRoleToJoinedBoardResp(ubr))
+	}
+}
+
+func sendInvitationError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, service.ErrPermissionDeniedToInvite):
+		return presenter.Forbidden(c, err)
+	case errors.Is(err, board.ErrBoardNotFound), errors.Is(err, invitation.ErrInvitationNotFound),
+		errors.Is(err, invitation.ErrLinkNotFound):
+		return presenter.NotFound(c, err)
+	case errors.Is(err, invitation.ErrPendingInvitationExists):
+		return presenter.Conflict(c, err)
+	case errors.Is(err, service.ErrAMember), errors.Is(err, service.ErrOwnerExists),
+		errors.Is(err, service.ErrUndefinedRole), errors.Is(err, invitation.ErrInvitationNotPending),
+		errors.Is(err, invitation.ErrInvitationExpired), errors.Is(err, invitation.ErrLinkRevoked),
+		errors.Is(err, invitation.ErrLinkExpired), errors.Is(err, invitation.ErrLinkExhausted),
+		errors.Is(err, service.ErrInviteeNotVerified):
+		return presenter.BadRequest(c, err)
+	case errors.Is(err, user.ErrEmailNotVerified):
+		return presenter.Forbidden(c, err)
+	}
+	return presenter.InternalServerError(c, err)
+}
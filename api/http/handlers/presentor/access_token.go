@@ -0,0 +1,58 @@
+package presenter
+
+import (
+	accesstoken "server/internal/access_token"
+	"server/pkg/fp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateAccessTokenReq struct {
+	Name          string     `json:"name" example:"ci deploy"`
+	Scopes        []string   `json:"scopes" example:"read"`
+	BoardID       *uuid.UUID `json:"board_id"` // limits the token to one board, left out for all boards
+	ExpiresInDays uint       `json:"expires_in_days" example:"30"`
+}
+
+type AccessTokenResp struct {
+	ID         uuid.UUID  `json:"token_id"`
+	Name       string     `json:"name" example:"ci deploy"`
+	Scopes     []string   `json:"scopes" example:"read"`
+	BoardID    *uuid.UUID `json:"board_id,omitempty"`
+	Prefix     string     `json:"prefix" example:"hfp_3kTz9aQ1"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type CreatedAccessTokenResp struct {
+	AccessTokenResp
+	Token string `json:"token"` // the secret, it is only shown this once
+}
+
+func CreateAccessTokenReqToAccessToken(req *CreateAccessTokenReq, userID uuid.UUID) *accesstoken.AccessToken {
+	return &accesstoken.AccessToken{
+		UserID:  userID,
+		Name:    req.Name,
+		Scopes:  fp.Map(req.Scopes, func(s string) accesstoken.Scope { return accesstoken.Scope(s) }),
+		BoardID: req.BoardID,
+	}
+}
+
+func AccessTokenToResp(t accesstoken.AccessToken) AccessTokenResp {
+	return AccessTokenResp{
+		ID:         t.ID,
+		Name:       t.Name,
+		Scopes:     fp.Map(t.Scopes, func(s accesstoken.Scope) string { return string(s) }),
+		BoardID:    t.BoardID,
+		Prefix:     t.Prefix,
+		ExpiresAt:  t.ExpiresAt,
+		LastUsedAt: t.LastUsedAt,
+		CreatedAt:  t.CreatedAt,
+	}
+}
+
+func BatchAccessTokensToResp(tokens []accesstoken.AccessToken) []AccessTokenResp {
+	return fp.Map(tokens, AccessTokenToResp)
+}
@@ -0,0 +1,60 @@
+package presenter
+
+import (
+	"server/internal/stats"
+	"server/internal/user"
+	"server/pkg/fp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AdminUserResp struct {
+	ID          uuid.UUID  `json:"user_id"`
+	FirstName   string     `json:"first_name"`
+	LastName    string     `json:"last_name"`
+	Email       string     `json:"email"`
+	Role        string     `json:"role" example:"user"`
+	SuspendedAt *time.Time `json:"suspended_at"`
+}
+
+type ForceTransferReq struct {
+	UserID uuid.UUID `json:"user_id" validate:"required"`
+}
+
+type StatsResp struct {
+	Users          int64 `json:"users"`
+	SuspendedUsers int64 `json:"suspended_users"`
+	Boards         int64 `json:"boards"`
+	ArchivedBoards int64 `json:"archived_boards"`
+	Workspaces     int64 `json:"workspaces"`
+	Tasks          int64 `json:"tasks"`
+	Comments       int64 `json:"comments"`
+}
+
+func UserToAdminUserResp(u user.User) AdminUserResp {
+	return AdminUserResp{
+		ID:          u.ID,
+		FirstName:   u.FirstName,
+		LastName:    u.LastName,
+		Email:       u.Email,
+		Role:        u.Role.String(),
+		SuspendedAt: u.SuspendedAt,
+	}
+}
+
+func BatchUsersToAdminUserResp(users []user.User) []AdminUserResp {
+	return fp.Map(users, UserToAdminUserResp)
+}
+
+func StatsToResp(s *stats.Stats) StatsResp {
+	return StatsResp{
+		Users:          s.Users,
+		SuspendedUsers: s.SuspendedUsers,
+		Boards:         s.Boards,
+		ArchivedBoards: s.ArchivedBoards,
+		Workspaces:     s.Workspaces,
+		Tasks:          s.Tasks,
+		Comments:       s.Comments,
+	}
+}
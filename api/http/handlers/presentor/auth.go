@@ -16,6 +16,24 @@ type UserLoginReq struct {
 	Password string `json:"password" validate:"required" example:"Abc@123"`
 }
 
+type LoginWithCodeReq struct {
+	ChallengeToken string `json:"challenge_token" validate:"required" example:"eyJhbGciOi..."`
+	Code           string `json:"code" validate:"required" example:"123456"` // a code of the app or a recovery code
+}
+
+type VerifyEmailReq struct {
+	Token string `json:"token" validate:"required" example:"kq3X0aL8..."`
+}
+
+type ForgotPasswordReq struct {
+	Email string `json:"email" validate:"required" example:"abc@gmail.com"`
+}
+
+type ResetPasswordReq struct {
+	Token       string `json:"token" validate:"required" example:"kq3X0aL8..."`
+	NewPassword string `json:"new_password" validate:"required" example:"Xyz@456"`
+}
+
 func UserRegisterToUserDomain(up *UserRegisterReq) *user.User {
 	return &user.User{
 		FirstName: up.FirstName,
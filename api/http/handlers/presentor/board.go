@@ -13,10 +13,14 @@ import (
 )
 
 type UserBoard struct {
-	ID        uuid.UUID `json:"board_id" example:"1e8d41b-a84e-41c6-9564-4e932fccf213"`
-	Name      string    `json:"name" example:"myboard123"`
-	Type      string    `json:"type" example:"private"`
-	CreatedAt time.Time `json:"created_at"`
+	ID          uuid.UUID  `json:"board_id" example:"1e8d41b-a84e-41c6-9564-4e932fccf213"`
+	Name        string     `json:"name" example:"myboard123"`
+	Description string     `json:"description" example:"planning of the next release"`
+	Type        string     `json:"type" example:"private"`
+	WIPPolicy   string     `json:"wip_policy" example:"enforce"`
+	ArchivedAt  *time.Time `json:"archived_at,omitempty"`
+	WorkspaceID *uuid.UUID `json:"workspace_id,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
 }
 
 type BoardUserResp struct {
@@ -26,10 +30,11 @@ type BoardUserResp struct {
 	Email     string    `json:"email"`
 	Role      string    `json:"role"`
 }
+
+// BoardColumnResp is a column of a board, they come in the order of the board.
 type BoardColumnResp struct {
 	ID    uuid.UUID       `json:"id"`
 	Name  string          `json:"name"`
-	Order uint            `json:"order"`
 	Tasks []BoardTaskResp `json:"tasks"`
 }
 type BoardTaskResp struct {
@@ -40,12 +45,15 @@ type BoardTaskResp struct {
 	StoryPoint uint      `json:"story_at"`
 }
 type FullBoardResp struct {
-	ID        uuid.UUID         `json:"board_id"`
-	Name      string            `json:"name"`
-	Type      string            `json:"type"`
-	CreatedAt time.Time         `json:"created_at"`
-	Users     []BoardUserResp   `json:"users"`
-	Columns   []BoardColumnResp `json:"columns"`
+	ID          uuid.UUID         `json:"board_id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Type        string            `json:"type"`
+	WIPPolicy   string            `json:"wip_policy"`
+	ArchivedAt  *time.Time        `json:"archived_at,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	Users       []BoardUserResp   `json:"users"`
+	Columns     []BoardColumnResp `json:"columns"`
 }
 
 func userToBoardUserResp(u user.User) BoardUserResp {
@@ -72,7 +80,6 @@ func columnToBoardColumnResp(c column.Column) BoardColumnResp {
 	return BoardColumnResp{
 		ID:    c.ID,
 		Name:  c.Name,
-		Order: c.OrderNum,
 		Tasks: tasksResp,
 	}
 }
@@ -91,21 +98,28 @@ func BoardToFullBoardResp(b board.Board) FullBoardResp {
 	usersResp := BatchUserToBoardUserResp(b.Users)
 	columnsResp := BatchColumnToBoardColumnResp(b.Columns)
 	return FullBoardResp{
-		ID:        b.ID,
-		Name:      b.Name,
-		Type:      b.Type,
-		CreatedAt: b.CreatedAt,
-		Users:     usersResp,
-		Columns:   columnsResp,
+		ID:          b.ID,
+		Name:        b.Name,
+		Description: b.Description,
+		Type:        b.Type,
+		WIPPolicy:   string(b.WIPPolicy),
+		ArchivedAt:  b.ArchivedAt,
+		CreatedAt:   b.CreatedAt,
+		Users:       usersResp,
+		Columns:     columnsResp,
 	}
 }
 
 func boardToUserBoard(b board.Board) UserBoard {
 	return UserBoard{
-		ID:        b.ID,
-		Name:      b.Name,
-		Type:      b.Type,
-		CreatedAt: b.CreatedAt,
+		ID:          b.ID,
+		Name:        b.Name,
+		Description: b.Description,
+		Type:        b.Type,
+		WIPPolicy:   string(b.WIPPolicy),
+		ArchivedAt:  b.ArchivedAt,
+		WorkspaceID: b.WorkspaceID,
+		CreatedAt:   b.CreatedAt,
 	}
 }
 
@@ -115,8 +129,11 @@ func BatchBoardsToUserBoard(boards []board.Board) []UserBoard {
 
 func UserBoardToBoard(userBoard *UserBoard, userID uuid.UUID) (*board.Board, *userboardrole.UserBoardRole) {
 	b := &board.Board{
-		Name: userBoard.Name,
-		Type: userBoard.Type,
+		Name:        userBoard.Name,
+		Description: userBoard.Description,
+		Type:        userBoard.Type,
+		WIPPolicy:   board.WIPPolicy(userBoard.WIPPolicy),
+		WorkspaceID: userBoard.WorkspaceID,
 	}
 	ubr := &userboardrole.UserBoardRole{
 		UserID: userID,
@@ -124,20 +141,6 @@ func UserBoardToBoard(userBoard *UserBoard, userID uuid.UUID) (*board.Board, *us
 	return b, ubr
 }
 
-type InviteUserToBoard struct {
-	ID      uuid.UUID `json:"user_board_role_id" example:"31e8d41b-a84e-41c6-9564-4e932fccf213"`
-	Email   string    `json:"email" example:"inviatee_email.com"`
-	BoardID uuid.UUID `json:"board_id" example:"aeec51f9-dde3-409d-9415-df771f5b8a62"`
-	Role    string    `json:"role" example:"editor"`
-}
-
-func InviteUserToBoardToUserBoardRole(inviteUserToBoard *InviteUserToBoard) *userboardrole.UserBoardRole {
-	return &userboardrole.UserBoardRole{
-		Role:    inviteUserToBoard.Role,
-		BoardID: inviteUserToBoard.BoardID,
-	}
-}
-
 func DeleteBoardParamToUserBoardRole(boardID uuid.UUID, userID uuid.UUID) *userboardrole.UserBoardRole {
 	return &userboardrole.UserBoardRole{
 		UserID:  userID,
@@ -146,41 +149,108 @@ func DeleteBoardParamToUserBoardRole(boardID uuid.UUID, userID uuid.UUID) *userb
 }
 
 type CreateBoardResponse struct {
-	ID        uuid.UUID            `json:"board_id"`
-	CreatedAt time.Time            `json:"created_at"`
-	Name      string               `json:"name"`
-	Type      string               `json:"type"`
-	Columns   []ColumnResponseItem `json:"columns"`
+	ID          uuid.UUID            `json:"board_id"`
+	CreatedAt   time.Time            `json:"created_at"`
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	Type        string               `json:"type"`
+	WIPPolicy   string               `json:"wip_policy"`
+	WorkspaceID *uuid.UUID           `json:"workspace_id,omitempty"`
+	Columns     []ColumnResponseItem `json:"columns"`
 }
 
 func BoardToCreateBoardResponse(b *board.Board) *CreateBoardResponse {
 	cols := BatchColumnToColumnResponseItem(b.Columns)
 	return &CreateBoardResponse{
-		ID:        b.ID,
-		CreatedAt: b.CreatedAt,
-		Name:      b.Name,
-		Type:      b.Type,
-		Columns:   cols,
+		ID:          b.ID,
+		CreatedAt:   b.CreatedAt,
+		Name:        b.Name,
+		Description: b.Description,
+		Type:        b.Type,
+		WIPPolicy:   string(b.WIPPolicy),
+		WorkspaceID: b.WorkspaceID,
+		Columns:     cols,
 	}
 }
 
-type InviteMemberResponse struct {
-	Email       string    `json:"email"`
-	Role        string    `json:"role"`
-	UserBoardID uuid.UUID `json:"user_board_role_id"`
-	BoardID     uuid.UUID `json:"board_id"`
+type CreateBoardReq struct {
+	Name        string     `json:"name" example:"myboard123"`
+	Type        string     `json:"type" example:"private(public, workspace)"`
+	WorkspaceID *uuid.UUID `json:"workspace_id,omitempty"`
+}
+
+type BoardMemberResp struct {
+	UserBoardRoleID uuid.UUID `json:"user_board_role_id"`
+	UserID          uuid.UUID `json:"user_id"`
+	FirstName       string    `json:"first_name"`
+	LastName        string    `json:"last_name"`
+	Email           string    `json:"email"`
+	Role            string    `json:"role" example:"editor"`
+	Inherited       bool      `json:"inherited"` // the role comes from the workspace role of the member
 }
 
-func InviteMemberToInviteMemberResponse(ubr *userboardrole.UserBoardRole, email string) *InviteMemberResponse {
-	return &InviteMemberResponse{
-		Role:        ubr.Role,
-		Email:       email,
-		UserBoardID: ubr.ID,
-		BoardID:     ubr.BoardID,
+func UserBoardRoleToBoardMemberResp(ubr userboardrole.UserBoardRole) BoardMemberResp {
+	resp := BoardMemberResp{
+		UserBoardRoleID: ubr.ID,
+		UserID:          ubr.UserID,
+		Role:            ubr.Role,
+		Inherited:       ubr.Inherited,
+	}
+	if ubr.User != nil {
+		resp.FirstName = ubr.User.FirstName
+		resp.LastName = ubr.User.LastName
+		resp.Email = ubr.User.Email
 	}
+	return resp
 }
 
-type CreateBoardReq struct {
-	Name string `json:"name" example:"myboard123"`
-	Type string `json:"type" example:"private(public)"`
+func BatchUserBoardRolesToBoardMemberResp(ubrs []userboardrole.UserBoardRole) []BoardMemberResp {
+	return fp.Map(ubrs, UserBoardRoleToBoardMemberResp)
+}
+
+type ChangeMemberRoleReq struct {
+	Role string `json:"role" example:"maintainer"`
+}
+
+type ProposeOwnershipTransferReq struct {
+	UserID        uuid.UUID `json:"user_id" example:"31e8d41b-a84e-41c6-9564-4e932fccf213"`
+	KeepOwnership bool      `json:"keep_ownership" example:"false"`
+}
+
+type OwnershipTransferResp struct {
+	BoardID       uuid.UUID `json:"board_id"`
+	FromUserID    uuid.UUID `json:"from_user_id"`
+	ToUserID      uuid.UUID `json:"to_user_id"`
+	KeepOwnership bool      `json:"keep_ownership"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func OwnershipTransferToResp(t *userboardrole.OwnershipTransfer) OwnershipTransferResp {
+	return OwnershipTransferResp{
+		BoardID:       t.BoardID,
+		FromUserID:    t.FromUserID,
+		ToUserID:      t.ToUserID,
+		KeepOwnership: t.KeepOwnership,
+		CreatedAt:     t.CreatedAt,
+	}
+}
+
+type UpdateBoardReq struct {
+	Name        *string `json:"name" example:"myboard123"`
+	Description *string `json:"description" example:"planning of the next release"`
+	Type        *string `json:"type" example:"public"`
+	WIPPolicy   *string `json:"wip_policy" example:"warn"`
+}
+
+func UpdateBoardReqToUpdateFields(req *UpdateBoardReq) *board.UpdateFields {
+	fields := &board.UpdateFields{
+		Name:        req.Name,
+		Description: req.Description,
+		Type:        req.Type,
+	}
+	if req.WIPPolicy != nil {
+		policy := board.WIPPolicy(*req.WIPPolicy)
+		fields.WIPPolicy = &policy
+	}
+	return fields
 }
@@ -15,6 +15,7 @@ type CreateColumnsRequest struct {
 
 type CreateColumnItem struct {
 	Name string `json:"name"`
+	Kind string `json:"kind" example:"in_progress"`
 }
 
 type CreateColumnsResponse struct {
@@ -22,10 +23,13 @@ type CreateColumnsResponse struct {
 	Message string               `json:"message"`
 }
 
+// ColumnResponseItem is a column, the columns of a board are ordered by rank and lists of them come in that order.
 type ColumnResponseItem struct {
-	ID    uuid.UUID `json:"id"`
-	Name  string    `json:"name"`
-	Order uint      `json:"order"`
+	ID       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	Rank     string    `json:"rank"`
+	Kind     string    `json:"kind"`
+	WIPLimit *uint     `json:"wip_limit"`
 }
 
 type GetColumnsResponse struct {
@@ -41,6 +45,7 @@ func CreateColumnsRequestToEntities(req CreateColumnsRequest, maxOrder uint) []e
 			Name:     col.Name,
 			BoardID:  req.BoardID,
 			OrderNum: maxOrder + uint(i) + 1,
+			Kind:     col.Kind,
 		}
 	}
 	return columns
@@ -50,9 +55,10 @@ func EntitiesToCreateColumnsResponse(columns []entities.Column) CreateColumnsRes
 	respItems := make([]ColumnResponseItem, len(columns))
 	for i, col := range columns {
 		respItems[i] = ColumnResponseItem{
-			ID:    col.ID,
-			Name:  col.Name,
-			Order: col.OrderNum,
+			ID:   col.ID,
+			Name: col.Name,
+			Rank: col.Rank,
+			Kind: col.Kind,
 		}
 	}
 	return CreateColumnsResponse{
@@ -63,9 +69,11 @@ func EntitiesToCreateColumnsResponse(columns []entities.Column) CreateColumnsRes
 
 func EntityToColumnResponse(c column.Column) ColumnResponseItem {
 	return ColumnResponseItem{
-		ID:    c.ID,
-		Name:  c.Name,
-		Order: c.OrderNum,
+		ID:       c.ID,
+		Name:     c.Name,
+		Rank:     c.Rank,
+		Kind:     string(c.Kind),
+		WIPLimit: c.WIPLimit,
 	}
 }
 
@@ -82,9 +90,11 @@ func EntitiesToGetColumnsResponse(columns []column.Column) GetColumnsResponse {
 
 func ColumnToColumnResponseItem(c column.Column) ColumnResponseItem {
 	return ColumnResponseItem{
-		ID:    c.ID,
-		Name:  c.Name,
-		Order: c.OrderNum,
+		ID:       c.ID,
+		Name:     c.Name,
+		Rank:     c.Rank,
+		Kind:     string(c.Kind),
+		WIPLimit: c.WIPLimit,
 	}
 }
 
@@ -108,3 +118,60 @@ func ReorderColumnsRequestToMap(req ReorderColumnsRequest) (uuid.UUID, map[uuid.
 	}
 	return req.BoardID, newOrder
 }
+
+type UpdateColumnKindRequest struct {
+	Kind string `json:"kind" example:"done"`
+}
+
+type UpdateColumnRequest struct {
+	Name     *string `json:"name" example:"review"`
+	WIPLimit *uint   `json:"wip_limit" example:"5"` // 0 removes the limit
+}
+
+func UpdateColumnRequestToUpdateFields(req *UpdateColumnRequest) *column.UpdateFields {
+	return &column.UpdateFields{
+		Name:     req.Name,
+		WIPLimit: req.WIPLimit,
+	}
+}
+
+type ColumnTransitionItem struct {
+	ID           uuid.UUID `json:"id"`
+	FromColumnID uuid.UUID `json:"from_column_id" validate:"required"`
+	ToColumnID   uuid.UUID `json:"to_column_id" validate:"required"`
+	Roles        []string  `json:"roles" example:"maintainer,owner"`
+}
+
+type SetColumnTransitionsRequest struct {
+	Transitions []ColumnTransitionItem `json:"transitions"`
+}
+
+func ColumnTransitionItemToTransition(item ColumnTransitionItem) column.Transition {
+	return column.Transition{
+		FromColumnID: item.FromColumnID,
+		ToColumnID:   item.ToColumnID,
+		Roles:        item.Roles,
+	}
+}
+
+func TransitionToColumnTransitionItem(t column.Transition) ColumnTransitionItem {
+	roles := t.Roles
+	if roles == nil {
+		roles = []string{}
+	}
+	return ColumnTransitionItem{
+		ID:           t.ID,
+		FromColumnID: t.FromColumnID,
+		ToColumnID:   t.ToColumnID,
+		Roles:        roles,
+	}
+}
+
+func BatchTransitionToColumnTransitionItem(ts []column.Transition) []ColumnTransitionItem {
+	return fp.Map(ts, TransitionToColumnTransitionItem)
+}
+
+type MoveColumnRequest struct {
+	AfterID  *uuid.UUID `json:"after_id"`
+	BeforeID *uuid.UUID `json:"before_id"`
+}
@@ -0,0 +1,102 @@
+package presenter
+
+import (
+	"server/internal/invitation"
+	userboardrole "server/internal/user_board_role"
+	"server/pkg/fp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type InviteUserToBoard struct {
+	Email   string    `json:"email" example:"inviatee_email.com"`
+	BoardID uuid.UUID `json:"board_id" example:"aeec51f9-dde3-409d-9415-df771f5b8a62"`
+	Role    string    `json:"role" example:"editor"`
+}
+
+func InviteUserToBoardToInvitation(req *InviteUserToBoard) *invitation.Invitation {
+	return &invitation.Invitation{
+		Email:   req.Email,
+		BoardID: req.BoardID,
+		Role:    req.Role,
+	}
+}
+
+type InvitationResp struct {
+	ID        uuid.UUID  `json:"invitation_id"`
+	BoardID   uuid.UUID  `json:"board_id"`
+	BoardName string     `json:"board_name,omitempty"`
+	Email     string     `json:"email"`
+	Role      string     `json:"role"`
+	Status    string     `json:"status" example:"pending"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func InvitationToResp(inv invitation.Invitation) InvitationResp {
+	return InvitationResp{
+		ID:        inv.ID,
+		BoardID:   inv.BoardID,
+		BoardName: inv.BoardName,
+		Email:     inv.Email,
+		Role:      inv.Role,
+		Status:    string(inv.Status),
+		ExpiresAt: inv.ExpiresAt,
+		CreatedAt: inv.CreatedAt,
+	}
+}
+
+func BatchInvitationsToResp(invitations []invitation.Invitation) []InvitationResp {
+	return fp.Map(invitations, InvitationToResp)
+}
+
+type JoinedBoardResp struct {
+	UserBoardRoleID uuid.UUID `json:"user_board_role_id"`
+	BoardID         uuid.UUID `json:"board_id"`
+	Role            string    `json:"role"`
+}
+
+func UserBoardRoleToJoinedBoardResp(ubr *userboardrole.UserBoardRole) JoinedBoardResp {
+	return JoinedBoardResp{
+		UserBoardRoleID: ubr.ID,
+		BoardID:         ubr.BoardID,
+		Role:            ubr.Role,
+	}
+}
+
+type CreateInviteLinkReq struct {
+	Role           string `json:"role" example:"viewer"`
+	MaxUses        uint   `json:"max_uses" example:"10"`
+	ExpiresInHours uint   `json:"expires_in_hours" example:"72"`
+}
+
+type InviteLinkResp struct {
+	ID        uuid.UUID  `json:"link_id"`
+	BoardID   uuid.UUID  `json:"board_id"`
+	Token     string     `json:"token"`
+	Role      string     `json:"role"`
+	MaxUses   uint       `json:"max_uses"`
+	Uses      uint       `json:"uses"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func InviteLinkToResp(l invitation.Link) InviteLinkResp {
+	return InviteLinkResp{
+		ID:        l.ID,
+		BoardID:   l.BoardID,
+		Token:     l.Token,
+		Role:      l.Role,
+		MaxUses:   l.MaxUses,
+		Uses:      l.Uses,
+		ExpiresAt: l.ExpiresAt,
+		RevokedAt: l.RevokedAt,
+		CreatedAt: l.CreatedAt,
+	}
+}
+
+func BatchInviteLinksToResp(links []invitation.Link) []InviteLinkResp {
+	return fp.Map(links, InviteLinkToResp)
+}
@@ -0,0 +1,87 @@
+package presenter
+
+import (
+	customrole "server/internal/custom_role"
+	"server/pkg/fp"
+	"server/pkg/rbac"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CustomRoleReq struct {
+	Name        string   `json:"name" example:"reviewer"`
+	Permissions []string `json:"permissions" example:"view_board,view_task,comment_any_task"`
+}
+
+type UpdateCustomRoleReq struct {
+	Name        *string  `json:"name" example:"reviewer"`
+	Permissions []string `json:"permissions" example:"view_board,view_task"`
+}
+
+type RoleResp struct {
+	ID          *uuid.UUID `json:"role_id,omitempty"`                                          // nil for the built-in roles
+	Role        string     `json:"role" example:"custom:2f1c0b7e-3c1a-4b8e-9f4e-0c1d2e3f4a5b"` // the value to give in invitations and role changes
+	Name        string     `json:"name" example:"reviewer"`
+	Permissions []string   `json:"permissions"`
+	BuiltIn     bool       `json:"built_in"`
+	BoardID     *uuid.UUID `json:"board_id,omitempty"`
+	WorkspaceID *uuid.UUID `json:"workspace_id,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+}
+
+var builtInRoles = []rbac.Role{rbac.RoleViewer, rbac.RoleEditor, rbac.RoleMaintainer, rbac.RoleOwner}
+
+func permissionsToStrings(permissions []rbac.Permission) []string {
+	return fp.Map(permissions, func(p rbac.Permission) string { return string(p) })
+}
+
+func stringsToPermissions(permissions []string) []rbac.Permission {
+	if permissions == nil {
+		return nil
+	}
+	return fp.Map(permissions, func(p string) rbac.Permission { return rbac.Permission(p) })
+}
+
+func CustomRoleReqToCustomRole(req *CustomRoleReq) *customrole.CustomRole {
+	return &customrole.CustomRole{
+		Name:        req.Name,
+		Permissions: stringsToPermissions(req.Permissions),
+	}
+}
+
+func UpdateCustomRoleReqToUpdateFields(req *UpdateCustomRoleReq) *customrole.UpdateFields {
+	return &customrole.UpdateFields{
+		Name:        req.Name,
+		Permissions: stringsToPermissions(req.Permissions),
+	}
+}
+
+func CustomRoleToResp(r customrole.CustomRole) RoleResp {
+	return RoleResp{
+		ID:          &r.ID,
+		Role:        string(r.Role()),
+		Name:        r.Name,
+		Permissions: permissionsToStrings(r.Permissions),
+		BoardID:     r.BoardID,
+		WorkspaceID: r.WorkspaceID,
+		CreatedAt:   &r.CreatedAt,
+	}
+}
+
+func BatchCustomRolesToResp(roles []customrole.CustomRole) []RoleResp {
+	return fp.Map(roles, CustomRoleToResp)
+}
+
+// BoardRolesToResp lists the built-in roles followed by the custom roles of a board.
+func BoardRolesToResp(roles []customrole.CustomRole) []RoleResp {
+	resp := fp.Map(builtInRoles, func(r rbac.Role) RoleResp {
+		return RoleResp{
+			Role:        string(r),
+			Name:        string(r),
+			Permissions: permissionsToStrings(rbac.RolePermissions[r]),
+			BuiltIn:     true,
+		}
+	})
+	return append(resp, BatchCustomRolesToResp(roles)...)
+}
@@ -0,0 +1,34 @@
+package presenter
+
+import (
+	"server/internal/session"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SessionResp struct {
+	ID         uuid.UUID `json:"session_id"`
+	Device     string    `json:"device" example:"Mozilla/5.0 (X11; Linux x86_64)"`
+	IP         string    `json:"ip" example:"203.0.113.7"`
+	Current    bool      `json:"current"` // the session of the token the request was made with
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+func BatchSessionsToResp(sessions []session.Session, currentID uuid.UUID) []SessionResp {
+	resp := make([]SessionResp, len(sessions))
+	for i, s := range sessions {
+		resp[i] = SessionResp{
+			ID:         s.ID,
+			Device:     s.Device,
+			IP:         s.IP,
+			Current:    s.ID == currentID,
+			CreatedAt:  s.CreatedAt,
+			LastUsedAt: s.LastUsedAt,
+			ExpiresAt:  s.ExpiresAt,
+		}
+	}
+	return resp
+}
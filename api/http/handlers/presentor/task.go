@@ -1,10 +1,13 @@
 package presenter
 
 import (
+	"fmt"
 	"server/internal/comment"
 	"server/internal/task"
 	"server/internal/user"
 	"server/pkg/fp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -46,6 +49,7 @@ type TaskReorderRespItem struct {
 	ID    uuid.UUID `json:"id"`
 	Title string    `json:"title"`
 	Order uint      `json:"order"`
+	Rank  string    `json:"rank"`
 }
 
 func TaskToTaskReorderResp(t task.Task) TaskReorderRespItem {
@@ -53,6 +57,7 @@ func TaskToTaskReorderResp(t task.Task) TaskReorderRespItem {
 		ID:    t.ID,
 		Title: t.Title,
 		Order: t.Order,
+		Rank:  t.Rank,
 	}
 }
 func BatchTaskToTaskReorderRespItem(cols []task.Task) []TaskReorderRespItem {
@@ -64,10 +69,39 @@ type DependentTasks struct {
 	DependsOnTaskIDs []uuid.UUID `json:"depends_on_task_ids" validate:"required"`
 }
 
+// MoveTaskReq places a task right after after_id and/or right before before_id, at the end of the
+// column when both are missing. column_id defaults to the task's current column.
+type MoveTaskReq struct {
+	ColumnID *uuid.UUID `json:"column_id"`
+	AfterID  *uuid.UUID `json:"after_id"`
+	BeforeID *uuid.UUID `json:"before_id"`
+}
+
 type UpdateTaskColReq struct {
 	ColumnID uuid.UUID `json:"column_id" validate:"required"`
 }
 
+type UpdateTaskReq struct {
+	Title          *string    `json:"title"`
+	Description    *string    `json:"desc"`
+	StartAt        *time.Time `json:"start_at"`
+	EndAt          *time.Time `json:"end_at"`
+	StoryPoint     *uint      `json:"story_point"`
+	AssigneeUserID *uuid.UUID `json:"assignee_user_id"`
+	ColumnID       *uuid.UUID `json:"column_id"`
+}
+
+func UpdateTaskReqToUpdateFields(req *UpdateTaskReq) *task.UpdateFields {
+	return &task.UpdateFields{
+		Title:          req.Title,
+		Description:    req.Description,
+		StartAt:        req.StartAt,
+		EndAt:          req.EndAt,
+		StoryPoint:     req.StoryPoint,
+		AssigneeUserID: req.AssigneeUserID,
+	}
+}
+
 func AddDependencyReqToTask(dependentTasksReq *DependentTasks, userID uuid.UUID) *task.Task {
 	return &task.Task{
 		ID:               dependentTasksReq.ID,
@@ -207,6 +241,8 @@ type UpdatedTaskResp struct {
 	Title       string     `json:"title"`
 	Description string     `json:"description"`
 	Order       uint       `json:"order"`
+	Rank        string     `json:"rank"`
+	ColumnID    uuid.UUID  `json:"column_id"`
 	StartAt     *time.Time `json:"start_at"`
 	EndAt       *time.Time `json:"end_at"`
 	StoryPoint  uint       `json:"story_point"`
@@ -218,6 +254,8 @@ func TaskToUpdatedTaskResp(t task.Task) UpdatedTaskResp {
 		Title:       t.Title,
 		Description: t.Description,
 		Order:       t.Order,
+		Rank:        t.Rank,
+		ColumnID:    t.ColumnID,
 		StartAt:     t.StartAt,
 		EndAt:       t.EndAt,
 		StoryPoint:  t.StoryPoint,
@@ -309,3 +347,205 @@ func DomainTaskToCreateTaskResp(task *task.Task) *CreateTaskResp {
 		DependsOn:      dependsOnTasks,
 	}
 }
+
+type TrashTaskResp struct {
+	ID        uuid.UUID  `json:"id"`
+	Title     string     `json:"title"`
+	ParentID  *uuid.UUID `json:"parent_id"`
+	ColumnID  uuid.UUID  `json:"column_id"`
+	DeletedAt *time.Time `json:"deleted_at"`
+}
+
+func TaskToTrashTaskResp(t task.Task) TrashTaskResp {
+	return TrashTaskResp{
+		ID:        t.ID,
+		Title:     t.Title,
+		ParentID:  t.ParentID,
+		ColumnID:  t.ColumnID,
+		DeletedAt: t.DeletedAt,
+	}
+}
+
+func BatchTaskToTrashTaskResp(tasks []task.Task) []TrashTaskResp {
+	return fp.Map(tasks, TaskToTrashTaskResp)
+}
+
+type TaskProgressResp struct {
+	TotalTasks       uint    `json:"total_tasks"`
+	DoneTasks        uint    `json:"done_tasks"`
+	TotalStoryPoints uint    `json:"total_story_points"`
+	DoneStoryPoints  uint    `json:"done_story_points"`
+	Percentage       float64 `json:"percentage"`
+}
+
+type TaskTreeResp struct {
+	ID         uuid.UUID        `json:"id"`
+	Title      string           `json:"title"`
+	ColumnID   uuid.UUID        `json:"column_id"`
+	StoryPoint uint             `json:"story_point"`
+	IsDone     bool             `json:"is_done"`
+	Progress   TaskProgressResp `json:"progress"`
+	Subtasks   []TaskTreeResp   `json:"subtasks"`
+}
+
+func TreeNodeToTaskTreeResp(n *task.TreeNode) TaskTreeResp {
+	return TaskTreeResp{
+		ID:         n.Task.ID,
+		Title:      n.Task.Title,
+		ColumnID:   n.Task.ColumnID,
+		StoryPoint: n.Task.StoryPoint,
+		IsDone:     n.Task.IsDone,
+		Progress: TaskProgressResp{
+			TotalTasks:       n.Progress.TotalTasks,
+			DoneTasks:        n.Progress.DoneTasks,
+			TotalStoryPoints: n.Progress.TotalStoryPoints,
+			DoneStoryPoints:  n.Progress.DoneStoryPoints,
+			Percentage:       n.Progress.Percentage,
+		},
+		Subtasks: fp.Map(n.Children, TreeNodeToTaskTreeResp),
+	}
+}
+
+type TaskDependenciesResp struct {
+	ID          uuid.UUID            `json:"id"`
+	Title       string               `json:"title"`
+	Transitive  bool                 `json:"transitive"`
+	DependsOn   []TaskDependTaskResp `json:"depends_on"`
+	DependentBy []TaskDependTaskResp `json:"dependent_by"`
+}
+
+func TaskToTaskDependenciesResp(t task.Task, transitive bool) TaskDependenciesResp {
+	return TaskDependenciesResp{
+		ID:          t.ID,
+		Title:       t.Title,
+		Transitive:  transitive,
+		DependsOn:   BatchTaskToTaskDependTaskResp(t.DependsOn),
+		DependentBy: BatchTaskToTaskDependTaskResp(t.DependentBy),
+	}
+}
+
+type DependencyGraphNodeResp struct {
+	ID       uuid.UUID  `json:"id"`
+	Title    string     `json:"title"`
+	ColumnID uuid.UUID  `json:"column_id"`
+	ParentID *uuid.UUID `json:"parent_id"`
+	IsDone   bool       `json:"is_done"`
+}
+
+type DependencyGraphEdgeResp struct {
+	From uuid.UUID `json:"from"` // dependent task
+	To   uuid.UUID `json:"to"`   // task it depends on
+}
+
+type DependencyGraphResp struct {
+	Nodes []DependencyGraphNodeResp `json:"nodes"`
+	Edges []DependencyGraphEdgeResp `json:"edges"`
+	DOT   string                    `json:"dot"`
+}
+
+func TaskToDependencyGraphNodeResp(t task.Task) DependencyGraphNodeResp {
+	return DependencyGraphNodeResp{
+		ID:       t.ID,
+		Title:    t.Title,
+		ColumnID: t.ColumnID,
+		ParentID: t.ParentID,
+		IsDone:   t.IsDone,
+	}
+}
+
+func TaskDependencyToDependencyGraphEdgeResp(d task.TaskDependency) DependencyGraphEdgeResp {
+	return DependencyGraphEdgeResp{
+		From: d.DependentTaskID,
+		To:   d.DependencyTaskID,
+	}
+}
+
+func DependencyGraphToResp(g *task.DependencyGraph) DependencyGraphResp {
+	return DependencyGraphResp{
+		Nodes: fp.Map(g.Nodes, TaskToDependencyGraphNodeResp),
+		Edges: fp.Map(g.Edges, TaskDependencyToDependencyGraphEdgeResp),
+		DOT:   DependencyGraphToDOT(g),
+	}
+}
+
+// DependencyGraphToDOT renders the graph in Graphviz DOT, done tasks are drawn filled.
+func DependencyGraphToDOT(g *task.DependencyGraph) string {
+	var sb strings.Builder
+	sb.WriteString("digraph dependencies {\n")
+	sb.WriteString("\trankdir=LR;\n")
+	sb.WriteString("\tnode [shape=box];\n")
+	for _, n := range g.Nodes {
+		attrs := fmt.Sprintf("label=%s", strconv.Quote(n.Title))
+		if n.IsDone {
+			attrs += ", style=filled"
+		}
+		sb.WriteString(fmt.Sprintf("\t\"%s\" [%s];\n", n.ID, attrs))
+	}
+	for _, e := range g.Edges {
+		sb.WriteString(fmt.Sprintf("\t\"%s\" -> \"%s\";\n", e.DependentTaskID, e.DependencyTaskID))
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+type ScheduleItemResp struct {
+	ID                  uuid.UUID  `json:"id"`
+	Title               string     `json:"title"`
+	StartAt             *time.Time `json:"start_at"`
+	EndAt               *time.Time `json:"end_at"`
+	DurationHours       float64    `json:"duration_hours"`
+	EarliestStartHours  float64    `json:"earliest_start_hours"`
+	EarliestFinishHours float64    `json:"earliest_finish_hours"`
+	LatestStartHours    float64    `json:"latest_start_hours"`
+	LatestFinishHours   float64    `json:"latest_finish_hours"`
+	SlackHours          float64    `json:"slack_hours"`
+	Critical            bool       `json:"critical"`
+}
+
+type ScheduleConflictResp struct {
+	TaskID           uuid.UUID `json:"task_id"`
+	DependencyTaskID uuid.UUID `json:"dependency_task_id"`
+	EndAt            time.Time `json:"end_at"`
+	DependencyEndAt  time.Time `json:"dependency_end_at"`
+}
+
+type ScheduleResp struct {
+	TotalDurationHours float64                `json:"total_duration_hours"`
+	CriticalPath       []uuid.UUID            `json:"critical_path"`
+	Tasks              []ScheduleItemResp     `json:"tasks"`
+	Conflicts          []ScheduleConflictResp `json:"conflicts"`
+}
+
+func ScheduleItemToResp(i task.ScheduleItem) ScheduleItemResp {
+	return ScheduleItemResp{
+		ID:                  i.Task.ID,
+		Title:               i.Task.Title,
+		StartAt:             i.Task.StartAt,
+		EndAt:               i.Task.EndAt,
+		DurationHours:       i.Duration.Hours(),
+		EarliestStartHours:  i.EarliestStart.Hours(),
+		EarliestFinishHours: i.EarliestFinish.Hours(),
+		LatestStartHours:    i.LatestStart.Hours(),
+		LatestFinishHours:   i.LatestFinish.Hours(),
+		SlackHours:          i.Slack.Hours(),
+		Critical:            i.Critical,
+	}
+}
+
+func ScheduleConflictToResp(c task.ScheduleConflict) ScheduleConflictResp {
+	return ScheduleConflictResp{
+		TaskID:           c.TaskID,
+		DependencyTaskID: c.DependencyTaskID,
+		EndAt:            c.EndAt,
+		DependencyEndAt:  c.DependencyEndAt,
+	}
+}
+
+func ScheduleToResp(s *task.Schedule) ScheduleResp {
+	return ScheduleResp{
+		TotalDurationHours: s.TotalDuration.Hours(),
+		CriticalPath:       s.CriticalPath,
+		Tasks:              fp.Map(s.Items, ScheduleItemToResp),
+		Conflicts:          fp.Map(s.Conflicts, ScheduleConflictToResp),
+	}
+}
@@ -0,0 +1,89 @@
+package presenter
+
+import (
+	"server/internal/board"
+	boardtemplate "server/internal/board_template"
+	"server/pkg/fp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SaveTemplateReq struct {
+	Name         string `json:"name" example:"sprint board"`
+	Description  string `json:"description" example:"two weeks sprint with review"`
+	IncludeTasks bool   `json:"include_tasks"`
+}
+
+func SaveTemplateReqToTemplate(req *SaveTemplateReq) *boardtemplate.Template {
+	return &boardtemplate.Template{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+}
+
+type TemplateColumnResp struct {
+	Position uint   `json:"position"`
+	Name     string `json:"name"`
+	Kind     string `json:"kind" example:"in_progress"`
+	WIPLimit *uint  `json:"wip_limit"`
+}
+
+type TemplateTaskResp struct {
+	ColumnPosition uint   `json:"column_position"`
+	Title          string `json:"title"`
+	Description    string `json:"description"`
+	StoryPoint     uint   `json:"story_point"`
+}
+
+type TemplateResp struct {
+	ID          uuid.UUID            `json:"template_id"`
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	Type        string               `json:"type" example:"private"`
+	WIPPolicy   string               `json:"wip_policy" example:"enforce"`
+	CreatedAt   time.Time            `json:"created_at"`
+	Columns     []TemplateColumnResp `json:"columns"`
+	Tasks       []TemplateTaskResp   `json:"tasks,omitempty"`
+}
+
+func TemplateToResp(t boardtemplate.Template) TemplateResp {
+	return TemplateResp{
+		ID:          t.ID,
+		Name:        t.Name,
+		Description: t.Description,
+		Type:        t.Type,
+		WIPPolicy:   string(t.WIPPolicy),
+		CreatedAt:   t.CreatedAt,
+		Columns: fp.Map(t.Columns, func(c boardtemplate.Column) TemplateColumnResp {
+			return TemplateColumnResp{Position: c.Position, Name: c.Name, Kind: string(c.Kind), WIPLimit: c.WIPLimit}
+		}),
+		Tasks: fp.Map(t.Tasks, func(tt boardtemplate.Task) TemplateTaskResp {
+			return TemplateTaskResp{ColumnPosition: tt.ColumnPosition, Title: tt.Title, Description: tt.Description, StoryPoint: tt.StoryPoint}
+		}),
+	}
+}
+
+func BatchTemplatesToResp(templates []boardtemplate.Template) []TemplateResp {
+	return fp.Map(templates, TemplateToResp)
+}
+
+// NewBoardReq names the board created from a template or a clone, the type defaults to the one of the source.
+type NewBoardReq struct {
+	Name string `json:"name" example:"myboard123"`
+	Type string `json:"type" example:"private"`
+}
+
+func NewBoardReqToBoard(req *NewBoardReq) *board.Board {
+	return &board.Board{
+		Name: req.Name,
+		Type: req.Type,
+	}
+}
+
+type CloneBoardReq struct {
+	NewBoardReq
+	IncludeTasks        bool `json:"include_tasks"`
+	IncludeMembers      bool `json:"include_members"`
+	IncludeDependencies bool `json:"include_dependencies"`
+}
@@ -0,0 +1,37 @@
+package presenter
+
+import twofactor "server/internal/two_factor"
+
+type TwoFactorCodeReq struct {
+	Code string `json:"code" validate:"required" example:"123456"`
+}
+
+type DisableTwoFactorReq struct {
+	Password string `json:"password" validate:"required" example:"Abc@123"`
+	Code     string `json:"code" validate:"required" example:"123456"` // a code of the app or a recovery code
+}
+
+type RecoveryCodesReq struct {
+	Password string `json:"password" validate:"required" example:"Abc@123"`
+}
+
+type TwoFactorEnrollmentResp struct {
+	Secret          string `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+	ProvisioningURI string `json:"provisioning_uri" example:"otpauth://totp/HeisenFlow:valid_email@folan.com?secret=JBSWY3DPEHPK3PXP&issuer=HeisenFlow"` // to show as a QR code
+}
+
+type RecoveryCodesResp struct {
+	RecoveryCodes []string `json:"recovery_codes" example:"k7mq2-x9tfa"` // only shown this once
+}
+
+type TwoFactorStatusResp struct {
+	Enabled           bool `json:"enabled"`
+	RecoveryCodesLeft uint `json:"recovery_codes_left"`
+}
+
+func TwoFactorStatusToResp(s *twofactor.Status) TwoFactorStatusResp {
+	return TwoFactorStatusResp{
+		Enabled:           s.Enabled,
+		RecoveryCodesLeft: s.RecoveryCodesLeft,
+	}
+}
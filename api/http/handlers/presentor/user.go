@@ -0,0 +1,52 @@
+package presenter
+
+import (
+	"server/internal/user"
+
+	"github.com/google/uuid"
+)
+
+type ProfileResp struct {
+	ID            uuid.UUID `json:"user_id"`
+	FirstName     string    `json:"first_name" example:"yourname"`
+	LastName      string    `json:"last_name" example:"yourlastname"`
+	Email         string    `json:"email" example:"abc@gmail.com"`
+	Role          string    `json:"role" example:"user"`
+	AvatarURL     string    `json:"avatar_url" example:"/assets/avatars/abc.png"`
+	EmailVerified bool      `json:"email_verified"`
+}
+
+type UpdateProfileReq struct {
+	FirstName *string `json:"first_name" example:"yourname"`
+	LastName  *string `json:"last_name" example:"yourlastname"`
+	Email     *string `json:"email" example:"abc@gmail.com"`
+}
+
+type ChangePasswordReq struct {
+	OldPassword string `json:"old_password" validate:"required" example:"Abc@123"`
+	NewPassword string `json:"new_password" validate:"required" example:"Xyz@456"`
+}
+
+type DeleteAccountReq struct {
+	Password string `json:"password" validate:"required" example:"Abc@123"`
+}
+
+func UserToProfileResp(u *user.User) ProfileResp {
+	return ProfileResp{
+		ID:            u.ID,
+		FirstName:     u.FirstName,
+		LastName:      u.LastName,
+		Email:         u.Email,
+		Role:          u.Role.String(),
+		AvatarURL:     u.AvatarURL,
+		EmailVerified: u.IsVerified(),
+	}
+}
+
+func UpdateProfileReqToUpdateFields(req *UpdateProfileReq) *user.UpdateFields {
+	return &user.UpdateFields{
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+		Email:     req.Email,
+	}
+}
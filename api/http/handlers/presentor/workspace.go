@@ -0,0 +1,79 @@
+package presenter
+
+import (
+	"server/internal/workspace"
+	"server/pkg/fp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateWorkspaceReq struct {
+	Name        string `json:"name" example:"Acme"`
+	Description string `json:"description" example:"boards of the acme company"`
+}
+
+type WorkspaceResp struct {
+	ID          uuid.UUID `json:"workspace_id"`
+	Name        string    `json:"name" example:"Acme"`
+	Description string    `json:"description" example:"boards of the acme company"`
+	Role        string    `json:"role" example:"admin"` // role of the current user
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type AddWorkspaceMemberReq struct {
+	Email string `json:"email" example:"jane@example.com"`
+	Role  string `json:"role" example:"member"`
+}
+
+type WorkspaceRoleReq struct {
+	Role string `json:"role" example:"guest"`
+}
+
+type WorkspaceMemberResp struct {
+	UserID    uuid.UUID `json:"user_id"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role" example:"member"`
+	JoinedAt  time.Time `json:"joined_at"`
+}
+
+func CreateWorkspaceReqToWorkspace(req *CreateWorkspaceReq) *workspace.Workspace {
+	return &workspace.Workspace{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+}
+
+func WorkspaceToResp(w workspace.Workspace) WorkspaceResp {
+	return WorkspaceResp{
+		ID:          w.ID,
+		Name:        w.Name,
+		Description: w.Description,
+		Role:        string(w.MyRole),
+		CreatedAt:   w.CreatedAt,
+	}
+}
+
+func BatchWorkspacesToResp(workspaces []workspace.Workspace) []WorkspaceResp {
+	return fp.Map(workspaces, WorkspaceToResp)
+}
+
+func WorkspaceMemberToResp(m workspace.Member) WorkspaceMemberResp {
+	resp := WorkspaceMemberResp{
+		UserID:   m.UserID,
+		Role:     string(m.Role),
+		JoinedAt: m.JoinedAt,
+	}
+	if m.User != nil {
+		resp.FirstName = m.User.FirstName
+		resp.LastName = m.User.LastName
+		resp.Email = m.User.Email
+	}
+	return resp
+}
+
+func BatchWorkspaceMembersToResp(members []workspace.Member) []WorkspaceMemberResp {
+	return fp.Map(members, WorkspaceMemberToResp)
+}
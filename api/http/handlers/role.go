@@ -0,0 +1,343 @@
+package handlers
+
+import (
+	"errors"
+	presenter "server/api/http/handlers/presentor"
+	"server/internal/board"
+	customrole "server/internal/custom_role"
+	"server/internal/workspace"
+	"server/pkg/jwt"
+	"server/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+var errWrongRoleID = errors.New("given role_id format in path is not correct")
+
+// GetBoardRoles lists the roles of a board.
+// @Summary Get board roles
+// @Description Lists the built-in roles and the custom roles that can be given on a board, its own and the ones of its workspace.
+// @Tags Roles
+// @Produce  json
+// @Param boardID path string true "Board ID"
+// @Success 200 {array} presenter.RoleResp "roles"
+// @Failure 400 {object} map[string]interface{} "error: invalid board id"
+// @Failure 403 {object} map[string]interface{} "error: permission denied"
+// @Failure 404 {object} map[string]interface{} "error: board not found"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /boards/{boardID}/roles [get]
+func GetBoardRoles(roleService *service.RoleService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		boardID, err := uuid.Parse(c.Params("boardID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given board_id format in path is not correct"))
+		}
+
+		roles, err := roleService.GetBoardRoles(c.UserContext(), userClaims.UserID, boardID)
+		if err != nil {
+			return sendRoleError(c, err)
+		}
+		return presenter.OK(c, "roles successfully fetched", presenter.BoardRolesToResp(roles))
+	}
+}
+
+// CreateBoardRole creates a custom role on a board.
+// @Summary Create board role
+// @Description Defines a role of a board out of the given permissions. Needs manage_roles (owners).
+// @Tags Roles
+// @Accept  json
+// @Produce  json
+// @Param boardID path string true "Board ID"
+// @Param role body presenter.CustomRoleReq true "Role name and permissions"
+// @Success 201 {object} presenter.RoleResp "the new role"
+// @Failure 400 {object} map[string]interface{} "error: invalid name or permissions"
+// @Failure 403 {object} map[string]interface{} "error: permission denied"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /boards/{boardID}/roles [post]
+func CreateBoardRole(serviceFactory ServiceFactory[*service.RoleService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		roleService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		boardID, err := uuid.Parse(c.Params("boardID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given board_id format in path is not correct"))
+		}
+		var req presenter.CustomRoleReq
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+
+		r := presenter.CustomRoleReqToCustomRole(&req)
+		if err := roleService.CreateBoardRole(c.UserContext(), userClaims.UserID, boardID, r); err != nil {
+			return sendRoleError(c, err)
+		}
+		return presenter.Created(c, "role successfully created", presenter.CustomRoleToResp(*r))
+	}
+}
+
+// UpdateBoardRole edits a custom role of a board.
+// @Summary Update board role
+// @Description Renames a custom role of a board or replaces its permissions; members holding it get the new permissions right away. Needs manage_roles (owners).
+// @Tags Roles
+// @Accept  json
+// @Produce  json
+// @Param boardID path string true "Board ID"
+// @Param roleID path string true "Role ID"
+// @Param role body presenter.UpdateCustomRoleReq true "Fields to update"
+// @Success 200 {object} presenter.RoleResp "the updated role"
+// @Failure 400 {object} map[string]interface{} "error: invalid name or permissions"
+// @Failure 403 {object} map[string]interface{} "error: permission denied"
+// @Failure 404 {object} map[string]interface{} "error: role not found"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /boards/{boardID}/roles/{roleID} [patch]
+func UpdateBoardRole(serviceFactory ServiceFactory[*service.RoleService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		roleService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		boardID, err := uuid.Parse(c.Params("boardID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given board_id format in path is not correct"))
+		}
+		roleID, err := uuid.Parse(c.Params("roleID"))
+		if err != nil {
+			return presenter.BadRequest(c, errWrongRoleID)
+		}
+		var req presenter.UpdateCustomRoleReq
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+
+		r, err := roleService.UpdateBoardRole(c.UserContext(), userClaims.UserID, boardID, roleID,
+			presenter.UpdateCustomRoleReqToUpdateFields(&req))
+		if err != nil {
+			return sendRoleError(c, err)
+		}
+		return presenter.OK(c, "role successfully updated", presenter.CustomRoleToResp(*r))
+	}
+}
+
+// DeleteBoardRole deletes a custom role of a board.
+// @Summary Delete board role
+// @Description Deletes a custom role of a board once no member holds it. Needs manage_roles (owners).
+// @Tags Roles
+// @Param boardID path string true "Board ID"
+// @Param roleID path string true "Role ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{} "error: invalid id"
+// @Failure 403 {object} map[string]interface{} "error: permission denied"
+// @Failure 404 {object} map[string]interface{} "error: role not found"
+// @Failure 409 {object} map[string]interface{} "error: role is held by members"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /boards/{boardID}/roles/{roleID} [delete]
+func DeleteBoardRole(serviceFactory ServiceFactory[*service.RoleService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		roleService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		boardID, err := uuid.Parse(c.Params("boardID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given board_id format in path is not correct"))
+		}
+		roleID, err := uuid.Parse(c.Params("roleID"))
+		if err != nil {
+			return presenter.BadRequest(c, errWrongRoleID)
+		}
+
+		if err := roleService.DeleteBoardRole(c.UserContext(), userClaims.UserID, boardID, roleID); err != nil {
+			return sendRoleError(c, err)
+		}
+		return presenter.NoContent(c)
+	}
+}
+
+// GetWorkspaceRoles lists the custom roles of a workspace.
+// @Summary Get workspace roles
+// @Description Lists the custom roles shared by every board of a workspace, open to its members.
+// @Tags Roles
+// @Produce  json
+// @Param workspaceID path string true "Workspace ID"
+// @Success 200 {array} presenter.RoleResp "roles"
+// @Failure 400 {object} map[string]interface{} "error: invalid workspace id"
+// @Failure 403 {object} map[string]interface{} "error: not a member of the workspace"
+// @Failure 404 {object} map[string]interface{} "error: workspace not found"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /workspaces/{workspaceID}/roles [get]
+func GetWorkspaceRoles(roleService *service.RoleService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		workspaceID, err := uuid.Parse(c.Params("workspaceID"))
+		if err != nil {
+			return presenter.BadRequest(c, errWrongWorkspaceID)
+		}
+
+		roles, err := roleService.GetWorkspaceRoles(c.UserContext(), userClaims.UserID, workspaceID)
+		if err != nil {
+			return sendRoleError(c, err)
+		}
+		return presenter.OK(c, "roles successfully fetched", presenter.BatchCustomRolesToResp(roles))
+	}
+}
+
+// CreateWorkspaceRole creates a custom role for the boards of a workspace.
+// @Summary Create workspace role
+// @Description Defines a role that can be given on every board of the workspace. Admins only.
+// @Tags Roles
+// @Accept  json
+// @Produce  json
+// @Param workspaceID path string true "Workspace ID"
+// @Param role body presenter.CustomRoleReq true "Role name and permissions"
+// @Success 201 {object} presenter.RoleResp "the new role"
+// @Failure 400 {object} map[string]interface{} "error: invalid name or permissions"
+// @Failure 403 {object} map[string]interface{} "error: permission denied"
+// @Failure 404 {object} map[string]interface{} "error: workspace not found"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /workspaces/{workspaceID}/roles [post]
+func CreateWorkspaceRole(serviceFactory ServiceFactory[*service.RoleService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		roleService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		workspaceID, err := uuid.Parse(c.Params("workspaceID"))
+		if err != nil {
+			return presenter.BadRequest(c, errWrongWorkspaceID)
+		}
+		var req presenter.CustomRoleReq
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+
+		r := presenter.CustomRoleReqToCustomRole(&req)
+		if err := roleService.CreateWorkspaceRole(c.UserContext(), userClaims.UserID, workspaceID, r); err != nil {
+			return sendRoleError(c, err)
+		}
+		return presenter.Created(c, "role successfully created", presenter.CustomRoleToResp(*r))
+	}
+}
+
+// UpdateWorkspaceRole edits a custom role of a workspace.
+// @Summary Update workspace role
+// @Description Renames a custom role of a workspace or replaces its permissions on every board. Admins only.
+// @Tags Roles
+// @Accept  json
+// @Produce  json
+// @Param workspaceID path string true "Workspace ID"
+// @Param roleID path string true "Role ID"
+// @Param role body presenter.UpdateCustomRoleReq true "Fields to update"
+// @Success 200 {object} presenter.RoleResp "the updated role"
+// @Failure 400 {object} map[string]interface{} "error: invalid name or permissions"
+// @Failure 403 {object} map[string]interface{} "error: permission denied"
+// @Failure 404 {object} map[string]interface{} "error: role not found"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /workspaces/{workspaceID}/roles/{roleID} [patch]
+func UpdateWorkspaceRole(serviceFactory ServiceFactory[*service.RoleService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		roleService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		workspaceID, err := uuid.Parse(c.Params("workspaceID"))
+		if err != nil {
+			return presenter.BadRequest(c, errWrongWorkspaceID)
+		}
+		roleID, err := uuid.Parse(c.Params("roleID"))
+		if err != nil {
+			return presenter.BadRequest(c, errWrongRoleID)
+		}
+		var req presenter.UpdateCustomRoleReq
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+
+		r, err := roleService.UpdateWorkspaceRole(c.UserContext(), userClaims.UserID, workspaceID, roleID,
+			presenter.UpdateCustomRoleReqToUpdateFields(&req))
+		if err != nil {
+			return sendRoleError(c, err)
+		}
+		return presenter.OK(c, "role successfully updated", presenter.CustomRoleToResp(*r))
+	}
+}
+
+// DeleteWorkspaceRole deletes a custom role of a workspace.
+// @Summary Delete workspace role
+// @Description Deletes a custom role of a workspace once no member of its boards holds it. Admins only.
+// @Tags Roles
+// @Param workspaceID path string true "Workspace ID"
+// @Param roleID path string true "Role ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{} "error: invalid id"
+// @Failure 403 {object} map[string]interface{} "error: permission denied"
+// @Failure 404 {object} map[string]interface{} "error: role not found"
+// @Failure 409 {object} map[string]interface{} "error: role is held by members"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /workspaces/{workspaceID}/roles/{roleID} [delete]
+func DeleteWorkspaceRole(serviceFactory ServiceFactory[*service.RoleService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		roleService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		workspaceID, err := uuid.Parse(c.Params("workspaceID"))
+		if err != nil {
+			return presenter.BadRequest(c, errWrongWorkspaceID)
+		}
+		roleID, err := uuid.Parse(c.Params("roleID"))
+		if err != nil {
+			return presenter.BadRequest(c, errWrongRoleID)
+		}
+
+		if err := roleService.DeleteWorkspaceRole(c.UserContext(), userClaims.UserID, workspaceID, roleID); err != nil {
+			return sendRoleError(c, err)
+		}
+		return presenter.NoContent(c)
+	}
+}
+
+func sendRoleError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, service.ErrPermissionDenied):
+		return presenter.Forbidden(c, err)
+	case errors.Is(err, board.ErrBoardNotFound), errors.Is(err, workspace.ErrWorkspaceNotFound),
+		errors.Is(err, customrole.ErrRoleNotFound):
+		return presenter.NotFound(c, err)
+	case errors.Is(err, customrole.ErrRoleInUse):
+		return presenter.Conflict(c, err)
+	case errors.Is(err, customrole.ErrInvalidName), errors.Is(err, customrole.ErrUnknownPermission),
+		errors.Is(err, customrole.ErrNoPermissions), errors.Is(err, customrole.ErrNothingToUpdate):
+		return presenter.BadRequest(c, err)
+	}
+	return presenter.InternalServerError(c, err)
+}
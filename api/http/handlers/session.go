@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"errors"
+	presenter "server/api/http/handlers/presentor"
+	"server/internal/session"
+	"server/pkg/jwt"
+	"server/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Logout ends the current session.
+// @Summary Logout
+// @Description Revokes the session of the token the request is made with, its refresh token stops working. The authentication token stays valid until it expires.
+// @Tags Auth
+// @Success 204
+// @Failure 404 {object} map[string]interface{} "error: session not found"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /logout [post]
+func Logout(authService *service.AuthService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+
+		if err := authService.Logout(c.UserContext(), userClaims.UserID, userClaims.SessionID); err != nil {
+			return sendSessionError(c, err)
+		}
+		return presenter.NoContent(c)
+	}
+}
+
+// LogoutAll ends every session of the current user.
+// @Summary Logout everywhere
+// @Description Revokes every session of the current user, on all devices.
+// @Tags Auth
+// @Success 204
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /logout-all [post]
+func LogoutAll(authService *service.AuthService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+
+		if err := authService.LogoutAll(c.UserContext(), userClaims.UserID); err != nil {
+			return sendSessionError(c, err)
+		}
+		return presenter.NoContent(c)
+	}
+}
+
+// GetSessions lists the active sessions of the current user.
+// @Summary List my sessions
+// @Description Lists the sessions of the current user that are neither revoked nor expired with their device, ip and last use, the last used first.
+// @Tags Auth
+// @Produce  json
+// @Success 200 {object} []presenter.SessionResp "sessions"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /sessions [get]
+func GetSessions(authService *service.AuthService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+
+		sessions, err := authService.GetSessions(c.UserContext(), userClaims.UserID)
+		if err != nil {
+			return presenter.InternalServerError(c, err)
+		}
+		return presenter.OK(c, "sessions successfully fetched", presenter.BatchSessionsToResp(sessions, userClaims.SessionID))
+	}
+}
+
+// RevokeSession ends one session of the current user.
+// @Summary Revoke session
+// @Description Revokes a session of the current user, e.g. of a lost device.
+// @Tags Auth
+// @Param sessionID path string true "Session ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{} "error: invalid session id"
+// @Failure 404 {object} map[string]interface{} "error: session not found"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /sessions/{sessionID} [delete]
+func RevokeSession(authService *service.AuthService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		sessionID, err := uuid.Parse(c.Params("sessionID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given session_id format in path is not correct"))
+		}
+
+		if err := authService.Logout(c.UserContext(), userClaims.UserID, sessionID); err != nil {
+			return sendSessionError(c, err)
+		}
+		return presenter.NoContent(c)
+	}
+}
+
+func sendSessionError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, session.ErrSessionNotFound) {
+		return presenter.NotFound(c, err)
+	}
+	return presenter.InternalServerError(c, err)
+}
@@ -23,8 +23,9 @@ import (
 // @Produce  json
 // @Param task body presenter.UserTask true "Task details"
 // @Success 201 {object} presenter.CreateTaskResp "response: details of created task"
-// @Failure 400 {object} map[string]interface{} "error: bad request, invalid task details"
+// @Failure 400 {object} map[string]interface{} "error: bad request, invalid task details or dependency on another board"
 // @Failure 403 {object} map[string]interface{} "error: forbidden, permission denied"
+// @Failure 409 {object} map[string]interface{} "error: conflict, the first column is at its WIP limit"
 // @Failure 502 {object} map[string]interface{} "error: bad gateway, not a member, user not found, board not found, or other error"
 // @Failure 500 {object} map[string]interface{} "error: internal server error"
 // @Security BearerAuth
@@ -51,19 +52,26 @@ func CreateTask(serviceFactory ServiceFactory[*service.TaskService]) fiber.Handl
 
 		t := presenter.UserTaskToTask(&req, userClaims.UserID)
 
-		if err := taskService.CreateTask(c.UserContext(), t); err != nil {
+		warning, err := taskService.CreateTask(c.UserContext(), t)
+		if err != nil {
 			status := fiber.StatusInternalServerError
 			if errors.Is(err, service.ErrPermissionDenied) {
 				status = fiber.StatusForbidden
 			}
+			if errors.Is(err, column.ErrWIPLimitExceeded) || errors.Is(err, board.ErrBoardArchived) {
+				status = fiber.StatusConflict
+			}
 			if errors.Is(err, service.ErrNotMember) || errors.Is(err, user.ErrUserNotFound) || errors.Is(err, board.ErrBoardNotFound) || errors.Is(err, service.ErrCantAssigned) || errors.Is(err, task.ErrInvalidStoryPoint) {
 				status = fiber.StatusBadGateway
 			}
+			if errors.Is(err, task.ErrCrossBoardDependency) {
+				status = fiber.StatusBadRequest
+			}
 
 			return SendError(c, err, status)
 		}
 		res := presenter.DomainTaskToCreateTaskResp(t)
-		return presenter.Created(c, "Task created successfully", res)
+		return presenter.Created(c, withWIPWarning("Task created successfully", warning), res)
 	}
 }
 
@@ -75,9 +83,10 @@ func CreateTask(serviceFactory ServiceFactory[*service.TaskService]) fiber.Handl
 // @Produce  json
 // @Param dependency body presenter.DependentTasks true "Dependency details"
 // @Success 201 {object} map[string]interface{} "response: details of added task dependency"
-// @Failure 400 {object} map[string]interface{} "error: bad request, invalid dependency details"
+// @Failure 400 {object} map[string]interface{} "error: bad request, invalid dependency details or dependency on another board"
 // @Failure 403 {object} map[string]interface{} "error: forbidden, permission denied"
-// @Failure 502 {object} map[string]interface{} "error: bad gateway, circular dependency, task not found, or other error"
+// @Failure 409 {object} map[string]interface{} "error: conflict, circular dependency (the loop is in cycle) or archived board"
+// @Failure 502 {object} map[string]interface{} "error: bad gateway, task not found, or other error"
 // @Failure 500 {object} map[string]interface{} "error: internal server error"
 // @Security BearerAuth
 // @Router /tasks/dependency [post]
@@ -108,9 +117,20 @@ func AddDependency(serviceFactory ServiceFactory[*service.TaskService]) fiber.Ha
 			if errors.Is(err, service.ErrPermissionDenied) {
 				status = fiber.StatusForbidden
 			}
-			if errors.Is(err, task.ErrCircularDependency) || errors.Is(err, task.ErrTaskNotFound) || errors.Is(err, task.ErrFailedToFindDependsOnTasks) {
+			if errors.Is(err, board.ErrBoardArchived) || errors.Is(err, task.ErrCircularDependency) {
+				status = fiber.StatusConflict
+			}
+			if errors.Is(err, task.ErrTaskNotFound) || errors.Is(err, task.ErrFailedToFindDependsOnTasks) {
 				status = fiber.StatusBadGateway
 			}
+			if errors.Is(err, task.ErrCrossBoardDependency) {
+				status = fiber.StatusBadRequest
+			}
+
+			var cycleErr *task.CircularDependencyError
+			if errors.As(err, &cycleErr) {
+				return SendErrorWithDetails(c, err, status, map[string]any{"cycle": cycleErr.Path})
+			}
 
 			return SendError(c, err, status)
 		}
@@ -166,7 +186,9 @@ func GetFullTaskByID(taskService *service.TaskService) fiber.Handler {
 // @Param taskID path string true "Task ID"
 // @Param UpdateTaskColReq body presenter.UpdateTaskColReq true "Update Task Column Request"
 // @Success 200 {object} presenter.UpdatedTaskResp "Task successfully updated"
-// @Failure 400 {object} map[string]interface{} "Bad request, invalid task ID or column ID, or dependent task issues"
+// @Failure 400 {object} map[string]interface{} "Bad request, invalid task ID or column ID, dependent task issues, or a move the board workflow does not allow"
+// @Failure 403 {object} map[string]interface{} "Forbidden, permission denied"
+// @Failure 409 {object} map[string]interface{} "Conflict, the column is at its WIP limit"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Security BearerAuth
 // @Router /tasks/{taskID}/column [put]
@@ -191,19 +213,121 @@ func UpdateTaskColumnByID(serviceFactory ServiceFactory[*service.TaskService]) f
 		if err != nil {
 			return presenter.BadRequest(c, errors.New("given task_id format in path is not correct"))
 		}
-		updatedTask, err := taskService.UpdateTaskColumnByID(c.UserContext(), userClaims.UserID, taskID, req.ColumnID)
+		updatedTask, warning, err := taskService.UpdateTaskColumnByID(c.UserContext(), userClaims.UserID, taskID, req.ColumnID)
 		if err != nil {
 			if errors.Is(err, task.ErrTaskNotFound) || errors.Is(err, task.ErrColumnNotFound) || errors.Is(err, task.ErrCantDoneDependentTask) {
 				return presenter.BadRequest(c, err)
 			}
+			if errors.Is(err, column.ErrWIPLimitExceeded) {
+				return SendError(c, err, fiber.StatusConflict)
+			}
+			var transitionErr *column.TransitionNotAllowedError
+			if errors.As(err, &transitionErr) {
+				return SendErrorWithDetails(c, err, fiber.StatusBadRequest, map[string]any{"allowed_columns": transitionErr.Allowed})
+			}
+			if errors.Is(err, service.ErrPermissionDenied) {
+				return presenter.Forbidden(c, err)
+			}
+			if errors.Is(err, board.ErrBoardArchived) {
+				return presenter.Conflict(c, err)
+			}
 
 			return presenter.InternalServerError(c, err)
 		}
 		data := presenter.TaskToUpdatedTaskResp(*updatedTask)
-		return presenter.OK(c, "task successfully updated.", data)
+		return presenter.OK(c, withWIPWarning("task successfully updated.", warning), data)
 	}
 }
 
+// UpdateTask partially updates a task by its ID.
+// @Summary Update task
+// @Description Update any of the title, description, dates, story point, assignee or column of a task. Fields that are not given are left untouched.
+// @Tags Tasks
+// @Accept  json
+// @Produce  json
+// @Param taskID path string true "Task ID"
+// @Param UpdateTaskReq body presenter.UpdateTaskReq true "Update Task Request"
+// @Success 200 {object} presenter.UpdatedTaskResp "Task successfully updated"
+// @Failure 400 {object} map[string]interface{} "Bad request, invalid task fields, assignee or column"
+// @Failure 403 {object} map[string]interface{} "Forbidden, permission denied"
+// @Failure 409 {object} map[string]interface{} "Conflict, the column is at its WIP limit"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /tasks/{taskID} [patch]
+func UpdateTask(serviceFactory ServiceFactory[*service.TaskService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		taskService := serviceFactory(c.UserContext())
+		var req presenter.UpdateTaskReq
+
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		taskID, err := uuid.Parse(c.Params("taskID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given task_id format in path is not correct"))
+		}
+
+		fields := presenter.UpdateTaskReqToUpdateFields(&req)
+		if fields.IsEmpty() && req.ColumnID == nil {
+			return SendError(c, task.ErrNothingToUpdate, fiber.StatusBadRequest)
+		}
+
+		var (
+			updatedTask *task.Task
+			warning     *column.WIPLimitExceededError
+		)
+		if !fields.IsEmpty() {
+			updatedTask, err = taskService.UpdateTask(c.UserContext(), userClaims.UserID, taskID, fields)
+			if err != nil {
+				return sendUpdateTaskError(c, err)
+			}
+		}
+		if req.ColumnID != nil {
+			updatedTask, warning, err = taskService.UpdateTaskColumnByID(c.UserContext(), userClaims.UserID, taskID, *req.ColumnID)
+			if err != nil {
+				return sendUpdateTaskError(c, err)
+			}
+		}
+		data := presenter.TaskToUpdatedTaskResp(*updatedTask)
+		return presenter.OK(c, withWIPWarning("task successfully updated.", warning), data)
+	}
+}
+
+func sendUpdateTaskError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	if errors.Is(err, service.ErrPermissionDenied) {
+		status = fiber.StatusForbidden
+	}
+	if errors.Is(err, column.ErrWIPLimitExceeded) || errors.Is(err, board.ErrBoardArchived) {
+		status = fiber.StatusConflict
+	}
+	if errors.Is(err, task.ErrTaskNotFound) || errors.Is(err, task.ErrColumnNotFound) || errors.Is(err, task.ErrCantDoneDependentTask) ||
+		errors.Is(err, task.ErrEmptyTitle) || errors.Is(err, task.ErrLongTitle) || errors.Is(err, task.ErrLongDescription) ||
+		errors.Is(err, task.ErrTitleInvalidCharacter) || errors.Is(err, task.ErrDescInvalidCharacter) ||
+		errors.Is(err, task.ErrInvalidStoryPoint) || errors.Is(err, task.ErrInvalidTimeRange) || errors.Is(err, task.ErrNothingToUpdate) ||
+		errors.Is(err, service.ErrNotMember) || errors.Is(err, service.ErrCantAssigned) {
+		status = fiber.StatusBadRequest
+	}
+	var transitionErr *column.TransitionNotAllowedError
+	if errors.As(err, &transitionErr) {
+		return SendErrorWithDetails(c, err, fiber.StatusBadRequest, map[string]any{"allowed_columns": transitionErr.Allowed})
+	}
+	return SendError(c, err, status)
+}
+
+// withWIPWarning appends the warning of a board that does not enforce WIP limits to a success message.
+func withWIPWarning(message string, warning *column.WIPLimitExceededError) string {
+	if warning == nil {
+		return message
+	}
+	return message + " warning: " + warning.Error()
+}
+
 // ReorderTasks reorders the tasks of a board.
 // @Summary Reorder Tasks
 // @Description Reorder the tasks of a board for the authenticated user.
@@ -236,12 +360,412 @@ func ReorderTasks(serviceFactory ServiceFactory[*service.TaskService]) fiber.Han
 			if errors.Is(err, service.ErrPermissionDeniedToDeleteColumn) {
 				return presenter.Forbidden(c, err)
 			}
+			if errors.Is(err, board.ErrBoardArchived) {
+				return presenter.Conflict(c, err)
+			}
 			if errors.Is(err, column.ErrColumnNotFound) || errors.Is(err, column.ErrFailedToFetchColumns) || errors.Is(err, column.ErrFailedToUpdateColumn) || errors.Is(err, column.ErrInvalidColumnID) || errors.Is(err, column.ErrLengthMismatch) {
 				return presenter.BadRequest(c, err)
 			}
+			if errors.Is(err, task.ErrInvalidTaskID) || errors.Is(err, task.ErrLengthMismatch) || errors.Is(err, task.ErrInvalidOrder) {
+				return presenter.BadRequest(c, err)
+			}
 			return presenter.InternalServerError(c, err)
 		}
 		res := presenter.BatchTaskToTaskReorderRespItem(tasks)
 		return presenter.OK(c, "Tasks ReOrdered Successfully", res)
 	}
 }
+
+// DeleteTask moves a task and all of its subtasks to the board trash.
+// @Summary Delete task
+// @Description Soft delete a task with its subtasks and remove its dependencies. The task can be restored from the board trash.
+// @Tags Tasks
+// @Accept  json
+// @Produce  json
+// @Param taskID path string true "Task ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{} "Bad request, invalid task ID or task not found"
+// @Failure 403 {object} map[string]interface{} "Forbidden, permission denied"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /tasks/{taskID} [delete]
+func DeleteTask(serviceFactory ServiceFactory[*service.TaskService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		taskService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		taskID, err := uuid.Parse(c.Params("taskID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given task_id format in path is not correct"))
+		}
+
+		if err := taskService.DeleteTask(c.UserContext(), userClaims.UserID, taskID); err != nil {
+			status := fiber.StatusInternalServerError
+			if errors.Is(err, service.ErrPermissionDenied) {
+				status = fiber.StatusForbidden
+			}
+			if errors.Is(err, board.ErrBoardArchived) {
+				status = fiber.StatusConflict
+			}
+			if errors.Is(err, task.ErrTaskNotFound) {
+				status = fiber.StatusBadRequest
+			}
+			return SendError(c, err, status)
+		}
+		return presenter.NoContent(c)
+	}
+}
+
+// RestoreTask brings a task and the subtasks deleted with it back from the board trash.
+// @Summary Restore task
+// @Description Restore a deleted task together with the subtasks that were deleted with it.
+// @Tags Tasks
+// @Accept  json
+// @Produce  json
+// @Param taskID path string true "Task ID"
+// @Success 200 {object} presenter.UpdatedTaskResp "Task successfully restored"
+// @Failure 400 {object} map[string]interface{} "Bad request, invalid task ID, task not in trash or parent task in trash"
+// @Failure 403 {object} map[string]interface{} "Forbidden, permission denied"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /tasks/{taskID}/restore [post]
+func RestoreTask(serviceFactory ServiceFactory[*service.TaskService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		taskService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		taskID, err := uuid.Parse(c.Params("taskID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given task_id format in path is not correct"))
+		}
+
+		restoredTask, err := taskService.RestoreTask(c.UserContext(), userClaims.UserID, taskID)
+		if err != nil {
+			status := fiber.StatusInternalServerError
+			if errors.Is(err, service.ErrPermissionDenied) {
+				status = fiber.StatusForbidden
+			}
+			if errors.Is(err, board.ErrBoardArchived) {
+				status = fiber.StatusConflict
+			}
+			if errors.Is(err, task.ErrTaskNotFound) || errors.Is(err, task.ErrTaskNotDeleted) || errors.Is(err, task.ErrParentTaskDeleted) {
+				status = fiber.StatusBadRequest
+			}
+			return SendError(c, err, status)
+		}
+		data := presenter.TaskToUpdatedTaskResp(*restoredTask)
+		return presenter.OK(c, "task successfully restored.", data)
+	}
+}
+
+// GetBoardTrash lists the deleted tasks of a board.
+// @Summary Get board trash
+// @Description Retrieve the deleted tasks of a board, most recently deleted first.
+// @Tags Boards
+// @Accept  json
+// @Produce  json
+// @Param boardID path string true "Board ID"
+// @Param page query int false "Page number"
+// @Param page_size query int false "Page size"
+// @Success 200 {object} []presenter.TrashTaskResp "tasks: paginated list of deleted tasks"
+// @Failure 400 {object} map[string]interface{} "Bad request, invalid board ID"
+// @Failure 403 {object} map[string]interface{} "Forbidden, permission denied"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /boards/{boardID}/trash [get]
+func GetBoardTrash(taskService *service.TaskService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		boardID, err := uuid.Parse(c.Params("boardID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given board_id format in path is not correct"))
+		}
+		page, pageSize := PageAndPageSize(c)
+
+		tasks, total, err := taskService.GetBoardTrash(c.UserContext(), userClaims.UserID, boardID, uint(page), uint(pageSize))
+		if err != nil {
+			if errors.Is(err, service.ErrPermissionDenied) {
+				return presenter.Forbidden(c, err)
+			}
+			return presenter.InternalServerError(c, err)
+		}
+		data := presenter.NewPagination(
+			presenter.BatchTaskToTrashTaskResp(tasks),
+			uint(page),
+			uint(pageSize),
+			total,
+		)
+		return presenter.OK(c, "trash successfully fetched.", data)
+	}
+}
+
+// GetTaskTree retrieves a task with its whole subtask tree and progress.
+// @Summary Get task tree
+// @Description Retrieve a task and all of its subtasks at any depth, each node with its completion percentage and done/total story points rolled up from its subtree.
+// @Tags Tasks
+// @Accept  json
+// @Produce  json
+// @Param taskID path string true "Task ID"
+// @Success 200 {object} presenter.TaskTreeResp "Task tree successfully fetched"
+// @Failure 400 {object} map[string]interface{} "Bad request, invalid task ID or task not found"
+// @Failure 403 {object} map[string]interface{} "Forbidden, permission denied"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /tasks/{taskID}/tree [get]
+func GetTaskTree(taskService *service.TaskService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		taskID, err := uuid.Parse(c.Params("taskID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given task_id format in path is not correct"))
+		}
+
+		tree, err := taskService.GetTaskTree(c.UserContext(), userClaims.UserID, taskID)
+		if err != nil {
+			if errors.Is(err, service.ErrPermissionDenied) {
+				return presenter.Forbidden(c, err)
+			}
+			if errors.Is(err, task.ErrTaskNotFound) {
+				return presenter.BadRequest(c, err)
+			}
+			return presenter.InternalServerError(c, err)
+		}
+		data := presenter.TreeNodeToTaskTreeResp(tree)
+		return presenter.OK(c, "task tree successfully fetched.", data)
+	}
+}
+
+// RemoveDependency removes dependencies between tasks.
+// @Summary Remove task dependency
+// @Description Remove the given dependencies of a task for the authenticated user.
+// @Tags Tasks
+// @Accept  json
+// @Produce  json
+// @Param dependency body presenter.DependentTasks true "Dependency details"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{} "error: bad request, invalid dependency details, task or dependency not found"
+// @Failure 403 {object} map[string]interface{} "error: forbidden, permission denied"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /tasks/dependency [delete]
+func RemoveDependency(serviceFactory ServiceFactory[*service.TaskService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		taskService := serviceFactory(c.UserContext())
+
+		var req presenter.DependentTasks
+
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+
+		err := BodyValidator(req)
+		if err != nil {
+			return presenter.BadRequest(c, err)
+		}
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+
+		t := presenter.AddDependencyReqToTask(&req, userClaims.UserID)
+
+		if err := taskService.RemoveDependency(c.UserContext(), t); err != nil {
+			status := fiber.StatusInternalServerError
+			if errors.Is(err, service.ErrPermissionDenied) {
+				status = fiber.StatusForbidden
+			}
+			if errors.Is(err, board.ErrBoardArchived) {
+				status = fiber.StatusConflict
+			}
+			if errors.Is(err, task.ErrTaskNotFound) || errors.Is(err, task.ErrDependencyNotFound) {
+				status = fiber.StatusBadRequest
+			}
+
+			return SendError(c, err, status)
+		}
+
+		return presenter.NoContent(c)
+	}
+}
+
+// GetTaskDependencies lists the dependencies of a task in both directions.
+// @Summary Get task dependencies
+// @Description Retrieve the tasks a task depends on and the tasks depending on it. With transitive=true the whole chain is followed in each direction.
+// @Tags Tasks
+// @Accept  json
+// @Produce  json
+// @Param taskID path string true "Task ID"
+// @Param transitive query bool false "Follow dependencies transitively"
+// @Success 200 {object} presenter.TaskDependenciesResp "Dependencies successfully fetched"
+// @Failure 400 {object} map[string]interface{} "Bad request, invalid task ID or task not found"
+// @Failure 403 {object} map[string]interface{} "Forbidden, permission denied"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /tasks/{taskID}/dependencies [get]
+func GetTaskDependencies(taskService *service.TaskService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		taskID, err := uuid.Parse(c.Params("taskID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given task_id format in path is not correct"))
+		}
+		transitive := c.QueryBool("transitive")
+
+		t, err := taskService.GetDependencies(c.UserContext(), userClaims.UserID, taskID, transitive)
+		if err != nil {
+			if errors.Is(err, service.ErrPermissionDenied) {
+				return presenter.Forbidden(c, err)
+			}
+			if errors.Is(err, task.ErrTaskNotFound) {
+				return presenter.BadRequest(c, err)
+			}
+			return presenter.InternalServerError(c, err)
+		}
+		data := presenter.TaskToTaskDependenciesResp(*t, transitive)
+		return presenter.OK(c, "dependencies successfully fetched.", data)
+	}
+}
+
+// GetBoardDependencyGraph returns the dependency graph of a board.
+// @Summary Get board dependency graph
+// @Description Retrieve the tasks of a board as nodes and their dependencies as edges, along with a Graphviz DOT rendering. With format=dot only the DOT text is returned.
+// @Tags Boards
+// @Accept  json
+// @Produce  json
+// @Param boardID path string true "Board ID"
+// @Param format query string false "Response format, json (default) or dot"
+// @Success 200 {object} presenter.DependencyGraphResp "Dependency graph successfully fetched"
+// @Failure 400 {object} map[string]interface{} "Bad request, invalid board ID or board not found"
+// @Failure 403 {object} map[string]interface{} "Forbidden, permission denied"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /boards/{boardID}/dependency-graph [get]
+func GetBoardDependencyGraph(taskService *service.TaskService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		boardID, err := uuid.Parse(c.Params("boardID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given board_id format in path is not correct"))
+		}
+
+		graph, err := taskService.GetBoardDependencyGraph(c.UserContext(), userClaims.UserID, boardID)
+		if err != nil {
+			if errors.Is(err, service.ErrPermissionDenied) {
+				return presenter.Forbidden(c, err)
+			}
+			if errors.Is(err, board.ErrBoardNotFound) {
+				return presenter.BadRequest(c, err)
+			}
+			return presenter.InternalServerError(c, err)
+		}
+		if c.Query("format") == "dot" {
+			c.Set(fiber.HeaderContentType, "text/vnd.graphviz")
+			return c.SendString(presenter.DependencyGraphToDOT(graph))
+		}
+		data := presenter.DependencyGraphToResp(graph)
+		return presenter.OK(c, "dependency graph successfully fetched.", data)
+	}
+}
+
+// GetBoardSchedule computes the schedule and critical path of a board.
+// @Summary Get board schedule
+// @Description Topologically sort the dependency graph of a board and compute earliest/latest start, finish and slack for every task, flag the critical path and list tasks that end before one of their dependencies. Durations come from start_at/end_at, or from story points (one day each) when a task has no dates.
+// @Tags Boards
+// @Accept  json
+// @Produce  json
+// @Param boardID path string true "Board ID"
+// @Success 200 {object} presenter.ScheduleResp "Schedule successfully computed"
+// @Failure 400 {object} map[string]interface{} "Bad request, invalid board ID or board not found"
+// @Failure 403 {object} map[string]interface{} "Forbidden, permission denied"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /boards/{boardID}/schedule [get]
+func GetBoardSchedule(taskService *service.TaskService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		boardID, err := uuid.Parse(c.Params("boardID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given board_id format in path is not correct"))
+		}
+
+		schedule, err := taskService.GetBoardSchedule(c.UserContext(), userClaims.UserID, boardID)
+		if err != nil {
+			if errors.Is(err, service.ErrPermissionDenied) {
+				return presenter.Forbidden(c, err)
+			}
+			if errors.Is(err, board.ErrBoardNotFound) {
+				return presenter.BadRequest(c, err)
+			}
+			return presenter.InternalServerError(c, err)
+		}
+		data := presenter.ScheduleToResp(schedule)
+		return presenter.OK(c, "schedule successfully computed.", data)
+	}
+}
+
+// MoveTask moves a task to a position in a column.
+// @Summary Move task
+// @Description Put a task right after after_id and/or right before before_id, or at the end of the column when neither is given. Only the moved task is written. When column_id names another column, the task is moved there first with the same checks as PUT /tasks/{taskID}/column.
+// @Tags Tasks
+// @Accept  json
+// @Produce  json
+// @Param taskID path string true "Task ID"
+// @Param MoveTaskReq body presenter.MoveTaskReq true "Target position"
+// @Success 200 {object} presenter.UpdatedTaskResp "Task successfully moved"
+// @Failure 400 {object} map[string]interface{} "Bad request, invalid task ID, column or position"
+// @Failure 403 {object} map[string]interface{} "Forbidden, permission denied"
+// @Failure 409 {object} map[string]interface{} "Conflict, the column is at its WIP limit"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /tasks/{taskID}/move [post]
+func MoveTask(serviceFactory ServiceFactory[*service.TaskService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		taskService := serviceFactory(c.UserContext())
+		var req presenter.MoveTaskReq
+
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		taskID, err := uuid.Parse(c.Params("taskID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given task_id format in path is not correct"))
+		}
+
+		movedTask, warning, err := taskService.MoveTask(c.UserContext(), userClaims.UserID, taskID, req.ColumnID, req.AfterID, req.BeforeID)
+		if err != nil {
+			if errors.Is(err, task.ErrInvalidPosition) {
+				return presenter.BadRequest(c, err)
+			}
+			return sendUpdateTaskError(c, err)
+		}
+		data := presenter.TaskToUpdatedTaskResp(*movedTask)
+		return presenter.OK(c, withWIPWarning("task successfully moved.", warning), data)
+	}
+}
@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	"errors"
+	presenter "server/api/http/handlers/presentor"
+	"server/internal/board"
+	boardtemplate "server/internal/board_template"
+	"server/internal/column"
+	"server/internal/task"
+	"server/pkg/jwt"
+	"server/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// SaveBoardAsTemplate saves the layout of a board as a template.
+// @Summary Save board as template
+// @Description Saves the columns, their order and the settings of a board, and its top-level tasks when include_tasks is set, as a template of the current user. The board must be public or visible to the user.
+// @Tags Templates
+// @Accept  json
+// @Produce  json
+// @Param boardID path string true "Board ID"
+// @Param template body presenter.SaveTemplateReq true "Template details"
+// @Success 201 {object} presenter.TemplateResp "the new template"
+// @Failure 400 {object} map[string]interface{} "error: bad request, invalid template"
+// @Failure 403 {object} map[string]interface{} "error: permission denied"
+// @Failure 404 {object} map[string]interface{} "error: board not found"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /boards/{boardID}/template [post]
+func SaveBoardAsTemplate(serviceFactory ServiceFactory[*service.TemplateService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		templateService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		boardID, err := uuid.Parse(c.Params("boardID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given board_id format in path is not correct"))
+		}
+		var req presenter.SaveTemplateReq
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+
+		t := presenter.SaveTemplateReqToTemplate(&req)
+		if err := templateService.SaveAsTemplate(c.UserContext(), userClaims.UserID, boardID, t, req.IncludeTasks); err != nil {
+			return sendTemplateError(c, err)
+		}
+		return presenter.Created(c, "template successfully saved", presenter.TemplateToResp(*t))
+	}
+}
+
+// GetUserTemplates lists the templates of the current user.
+// @Summary Get my templates
+// @Description Lists the templates saved by the current user, newest first, without their sample tasks.
+// @Tags Templates
+// @Produce  json
+// @Success 200 {array} presenter.TemplateResp "templates"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /templates [get]
+func GetUserTemplates(templateService *service.TemplateService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+
+		templates, err := templateService.GetUserTemplates(c.UserContext(), userClaims.UserID)
+		if err != nil {
+			return presenter.InternalServerError(c, err)
+		}
+		return presenter.OK(c, "templates successfully fetched", presenter.BatchTemplatesToResp(templates))
+	}
+}
+
+// GetTemplate returns a template with its sample tasks.
+// @Summary Get template
+// @Description Returns a template of the current user with its columns and sample tasks.
+// @Tags Templates
+// @Produce  json
+// @Param templateID path string true "Template ID"
+// @Success 200 {object} presenter.TemplateResp "the template"
+// @Failure 400 {object} map[string]interface{} "error: invalid template id"
+// @Failure 403 {object} map[string]interface{} "error: permission denied"
+// @Failure 404 {object} map[string]interface{} "error: template not found"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /templates/{templateID} [get]
+func GetTemplate(templateService *service.TemplateService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		templateID, err := uuid.Parse(c.Params("templateID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given template_id format in path is not correct"))
+		}
+
+		t, err := templateService.GetTemplate(c.UserContext(), userClaims.UserID, templateID)
+		if err != nil {
+			return sendTemplateError(c, err)
+		}
+		return presenter.OK(c, "template successfully fetched", presenter.TemplateToResp(*t))
+	}
+}
+
+// DeleteTemplate deletes a template of the current user.
+// @Summary Delete template
+// @Description Deletes a template of the current user; boards created from it are kept.
+// @Tags Templates
+// @Param templateID path string true "Template ID"
+// @Success 204 "template deleted"
+// @Failure 400 {object} map[string]interface{} "error: invalid template id"
+// @Failure 403 {object} map[string]interface{} "error: permission denied"
+// @Failure 404 {object} map[string]interface{} "error: template not found"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /templates/{templateID} [delete]
+func DeleteTemplate(templateService *service.TemplateService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		templateID, err := uuid.Parse(c.Params("templateID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given template_id format in path is not correct"))
+		}
+
+		if err := templateService.DeleteTemplate(c.UserContext(), userClaims.UserID, templateID); err != nil {
+			return sendTemplateError(c, err)
+		}
+		return presenter.NoContent(c)
+	}
+}
+
+// CreateBoardFromTemplate creates a board from a template.
+// @Summary Create board from template
+// @Description Creates a board owned by the current user with the columns, settings and sample tasks of one of their templates. The type defaults to the one of the template.
+// @Tags Templates
+// @Accept  json
+// @Produce  json
+// @Param templateID path string true "Template ID"
+// @Param board body presenter.NewBoardReq true "New board"
+// @Success 201 {object} presenter.CreateBoardResponse "the new board"
+// @Failure 400 {object} map[string]interface{} "error: bad request, invalid board"
+// @Failure 403 {object} map[string]interface{} "error: permission denied"
+// @Failure 404 {object} map[string]interface{} "error: template not found"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /templates/{templateID}/boards [post]
+func CreateBoardFromTemplate(serviceFactory ServiceFactory[*service.TemplateService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		templateService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		templateID, err := uuid.Parse(c.Params("templateID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given template_id format in path is not correct"))
+		}
+		var req presenter.NewBoardReq
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+
+		b := presenter.NewBoardReqToBoard(&req)
+		if err := templateService.CreateBoardFromTemplate(c.UserContext(), userClaims.UserID, templateID, b); err != nil {
+			return sendTemplateError(c, err)
+		}
+		return presenter.Created(c, "Board created successfully", presenter.BoardToCreateBoardResponse(b))
+	}
+}
+
+// CloneBoard copies a board.
+// @Summary Clone board
+// @Description Creates a board owned by the current user with the columns, workflow and settings of another board, optionally with its tasks, members and task dependencies. Copying members needs the invite_users permission on the source board.
+// @Tags Boards
+// @Accept  json
+// @Produce  json
+// @Param boardID path string true "Board ID"
+// @Param board body presenter.CloneBoardReq true "New board and what to copy"
+// @Success 201 {object} presenter.CreateBoardResponse "the new board"
+// @Failure 400 {object} map[string]interface{} "error: bad request, invalid board"
+// @Failure 403 {object} map[string]interface{} "error: permission denied"
+// @Failure 404 {object} map[string]interface{} "error: board not found"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /boards/{boardID}/clone [post]
+func CloneBoard(serviceFactory ServiceFactory[*service.TemplateService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		templateService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		boardID, err := uuid.Parse(c.Params("boardID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given board_id format in path is not correct"))
+		}
+		var req presenter.CloneBoardReq
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+
+		b := presenter.NewBoardReqToBoard(&req.NewBoardReq)
+		opts := service.CloneOptions{
+			Tasks:        req.IncludeTasks,
+			Members:      req.IncludeMembers,
+			Dependencies: req.IncludeDependencies,
+		}
+		if err := templateService.CloneBoard(c.UserContext(), userClaims.UserID, boardID, b, opts); err != nil {
+			return sendTemplateError(c, err)
+		}
+		return presenter.Created(c, "Board created successfully", presenter.BoardToCreateBoardResponse(b))
+	}
+}
+
+func sendTemplateError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, service.ErrPermissionDenied):
+		return presenter.Forbidden(c, err)
+	case errors.Is(err, board.ErrBoardNotFound), errors.Is(err, boardtemplate.ErrTemplateNotFound):
+		return presenter.NotFound(c, err)
+	case errors.Is(err, boardtemplate.ErrInvalidName), errors.Is(err, boardtemplate.ErrNoColumns),
+		errors.Is(err, boardtemplate.ErrInvalidTask), errors.Is(err, service.ErrDependenciesNeedTasks),
+		errors.Is(err, board.ErrInvalidName), errors.Is(err, board.ErrWrongType),
+		errors.Is(err, board.ErrWrongWIPPolicy), errors.Is(err, board.ErrLongDescription), errors.Is(err, board.ErrNoWorkspace),
+		errors.Is(err, column.ErrInvalidName), errors.Is(err, column.ErrInvalidKind),
+		errors.Is(err, task.ErrEmptyTitle), errors.Is(err, task.ErrLongTitle), errors.Is(err, task.ErrLongDescription):
+		return presenter.BadRequest(c, err)
+	}
+	return presenter.InternalServerError(c, err)
+}
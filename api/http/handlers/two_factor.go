@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"errors"
+	presenter "server/api/http/handlers/presentor"
+	twofactor "server/internal/two_factor"
+	"server/internal/user"
+	"server/pkg/jwt"
+	"server/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetTwoFactorStatus tells whether two-factor authentication is on for the current user.
+// @Summary Two-factor status
+// @Description Tells whether two-factor authentication is enabled and how many unused recovery codes are left.
+// @Tags Two-Factor
+// @Produce  json
+// @Success 200 {object} presenter.TwoFactorStatusResp "status"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /me/2fa [get]
+func GetTwoFactorStatus(authService *service.AuthService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+
+		status, err := authService.GetTwoFactorStatus(c.UserContext(), userClaims.UserID)
+		if err != nil {
+			return presenter.InternalServerError(c, err)
+		}
+		return presenter.OK(c, "two-factor status successfully fetched", presenter.TwoFactorStatusToResp(status))
+	}
+}
+
+// EnrollTwoFactor starts the setup of two-factor authentication.
+// @Summary Enroll in two-factor authentication
+// @Description Creates a TOTP secret for the current user and returns it with an otpauth provisioning URI to show as a QR code. It stays pending until a first code is confirmed at /me/2fa/enable, enrolling again replaces it.
+// @Tags Two-Factor
+// @Produce  json
+// @Success 201 {object} presenter.TwoFactorEnrollmentResp "secret and provisioning uri"
+// @Failure 409 {object} map[string]interface{} "error: already enabled"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /me/2fa [post]
+func EnrollTwoFactor(serviceFactory ServiceFactory[*service.AuthService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		authService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+
+		enrollment, err := authService.EnrollTwoFactor(c.UserContext(), userClaims.UserID)
+		if err != nil {
+			return sendTwoFactorError(c, err)
+		}
+		return presenter.Created(c, "two-factor secret successfully created", presenter.TwoFactorEnrollmentResp{
+			Secret:          enrollment.Secret,
+			ProvisioningURI: enrollment.ProvisioningURI,
+		})
+	}
+}
+
+// EnableTwoFactor confirms the first code and turns two-factor authentication on.
+// @Summary Enable two-factor authentication
+// @Description Turns two-factor authentication on once a code of the enrolled secret is confirmed and returns the recovery codes, they are not shown again.
+// @Tags Two-Factor
+// @Accept  json
+// @Produce  json
+// @Param code body presenter.TwoFactorCodeReq true "A code of the authenticator app"
+// @Success 200 {object} presenter.RecoveryCodesResp "recovery codes"
+// @Failure 400 {object} map[string]interface{} "error: invalid code or not enrolled"
+// @Failure 409 {object} map[string]interface{} "error: already enabled"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /me/2fa/enable [post]
+func EnableTwoFactor(serviceFactory ServiceFactory[*service.AuthService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		authService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		var req presenter.TwoFactorCodeReq
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+		if err := BodyValidator(req); err != nil {
+			return presenter.BadRequest(c, err)
+		}
+
+		codes, err := authService.EnableTwoFactor(c.UserContext(), userClaims.UserID, req.Code)
+		if err != nil {
+			return sendTwoFactorError(c, err)
+		}
+		return presenter.OK(c, "two-factor authentication successfully enabled", presenter.RecoveryCodesResp{RecoveryCodes: codes})
+	}
+}
+
+// DisableTwoFactor turns two-factor authentication off.
+// @Summary Disable two-factor authentication
+// @Description Turns two-factor authentication off and drops the recovery codes once the password and a code, of the app or a recovery one, are confirmed.
+// @Tags Two-Factor
+// @Accept  json
+// @Param confirmation body presenter.DisableTwoFactorReq true "Password and code"
+// @Success 204
+// @Failure 400 {object} map[string]interface{} "error: wrong password, invalid code or not enabled"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /me/2fa [delete]
+func DisableTwoFactor(serviceFactory ServiceFactory[*service.AuthService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		authService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		var req presenter.DisableTwoFactorReq
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+		if err := BodyValidator(req); err != nil {
+			return presenter.BadRequest(c, err)
+		}
+
+		if err := authService.DisableTwoFactor(c.UserContext(), userClaims.UserID, req.Password, req.Code); err != nil {
+			return sendTwoFactorError(c, err)
+		}
+		return presenter.NoContent(c)
+	}
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the current user.
+// @Summary Regenerate recovery codes
+// @Description Replaces the recovery codes once the password is confirmed, the former ones stop working. The new codes are not shown again.
+// @Tags Two-Factor
+// @Accept  json
+// @Produce  json
+// @Param confirmation body presenter.RecoveryCodesReq true "Password"
+// @Success 200 {object} presenter.RecoveryCodesResp "recovery codes"
+// @Failure 400 {object} map[string]interface{} "error: wrong password or not enabled"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /me/2fa/recovery-codes [post]
+func RegenerateRecoveryCodes(serviceFactory ServiceFactory[*service.AuthService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		authService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		var req presenter.RecoveryCodesReq
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+		if err := BodyValidator(req); err != nil {
+			return presenter.BadRequest(c, err)
+		}
+
+		codes, err := authService.RegenerateRecoveryCodes(c.UserContext(), userClaims.UserID, req.Password)
+		if err != nil {
+			return sendTwoFactorError(c, err)
+		}
+		return presenter.OK(c, "recovery codes successfully regenerated", presenter.RecoveryCodesResp{RecoveryCodes: codes})
+	}
+}
+
+func sendTwoFactorError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, twofactor.ErrInvalidCode), errors.Is(err, twofactor.ErrNotEnrolled),
+		errors.Is(err, twofactor.ErrNotEnabled), errors.Is(err, user.ErrWrongPassword):
+		return presenter.BadRequest(c, err)
+	case errors.Is(err, twofactor.ErrAlreadyEnabled):
+		return presenter.Conflict(c, err)
+	case errors.Is(err, user.ErrUserSuspended):
+		return presenter.Forbidden(c, err)
+	case errors.Is(err, user.ErrUserNotFound):
+		return presenter.NotFound(c, err)
+	case errors.Is(err, service.ErrInvalidChallenge), errors.Is(err, twofactor.ErrChallengeUsed):
+		return SendError(c, err, fiber.StatusUnauthorized)
+	}
+	return presenter.InternalServerError(c, err)
+}
@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	presenter "server/api/http/handlers/presentor"
+	"server/internal/user"
+	"server/pkg/jwt"
+	"server/service"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	avatarDir     = "assets/avatars"
+	maxAvatarSize = 2 << 20
+)
+
+var (
+	errInvalidAvatar = errors.New("the avatar must be a png, jpeg, gif or webp image of at most 2MB")
+	avatarTypes      = map[string]string{
+		"image/png":  ".png",
+		"image/jpeg": ".jpg",
+		"image/gif":  ".gif",
+		"image/webp": ".webp",
+	}
+)
+
+// GetProfile returns the profile of the current user.
+// @Summary Get my profile
+// @Description Returns the names, email, global role and avatar of the current user.
+// @Tags Users
+// @Produce  json
+// @Success 200 {object} presenter.ProfileResp "profile"
+// @Failure 404 {object} map[string]interface{} "error: user not found"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /me [get]
+func GetProfile(userService *service.UserService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+
+		u, err := userService.GetProfile(c.UserContext(), userClaims.UserID)
+		if err != nil {
+			return sendUserError(c, err)
+		}
+		return presenter.OK(c, "profile successfully fetched", presenter.UserToProfileResp(u))
+	}
+}
+
+// UpdateProfile changes the names and email of the current user.
+// @Summary Update my profile
+// @Description Changes the first name, last name and email of the current user; fields left out are untouched. An email can only be used by one account.
+// @Tags Users
+// @Accept  json
+// @Produce  json
+// @Param profile body presenter.UpdateProfileReq true "Fields to update"
+// @Success 200 {object} presenter.ProfileResp "the updated profile"
+// @Failure 400 {object} map[string]interface{} "error: invalid fields"
+// @Failure 409 {object} map[string]interface{} "error: email already exists"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /me [patch]
+func UpdateProfile(serviceFactory ServiceFactory[*service.UserService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		var req presenter.UpdateProfileReq
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+
+		u, err := userService.UpdateProfile(c.UserContext(), userClaims.UserID, presenter.UpdateProfileReqToUpdateFields(&req))
+		if err != nil {
+			return sendUserError(c, err)
+		}
+		return presenter.OK(c, "profile successfully updated", presenter.UserToProfileResp(u))
+	}
+}
+
+// UploadAvatar replaces the avatar of the current user.
+// @Summary Upload my avatar
+// @Description Stores a png, jpeg, gif or webp image of at most 2MB as the avatar of the current user, it is served under /assets/avatars.
+// @Tags Users
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param avatar formData file true "Avatar image"
+// @Success 200 {object} presenter.ProfileResp "the updated profile"
+// @Failure 400 {object} map[string]interface{} "error: missing or invalid image"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /me/avatar [put]
+func UploadAvatar(userService *service.UserService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		file, err := c.FormFile("avatar")
+		if err != nil {
+			return presenter.BadRequest(c, err)
+		}
+		if file.Size > maxAvatarSize {
+			return presenter.BadRequest(c, errInvalidAvatar)
+		}
+
+		// the type is sniffed from the content, the file name can not be trusted
+		f, err := file.Open()
+		if err != nil {
+			return presenter.InternalServerError(c, err)
+		}
+		head := make([]byte, 512)
+		n, _ := f.Read(head)
+		f.Close()
+		ext, ok := avatarTypes[http.DetectContentType(head[:n])]
+		if !ok {
+			return presenter.BadRequest(c, errInvalidAvatar)
+		}
+
+		if err := os.MkdirAll(avatarDir, 0o755); err != nil {
+			return presenter.InternalServerError(c, err)
+		}
+		name := fmt.Sprintf("%s-%d%s", userClaims.UserID, time.Now().UnixNano(), ext)
+		if err := c.SaveFile(file, filepath.Join(avatarDir, name)); err != nil {
+			return presenter.InternalServerError(c, err)
+		}
+
+		avatarURL := "/" + avatarDir + "/" + name
+		oldURL, err := userService.SetAvatar(c.UserContext(), userClaims.UserID, avatarURL)
+		if err != nil {
+			os.Remove(filepath.Join(avatarDir, name))
+			return sendUserError(c, err)
+		}
+		if strings.HasPrefix(oldURL, "/"+avatarDir+"/") {
+			os.Remove(filepath.Join(avatarDir, filepath.Base(oldURL)))
+		}
+
+		u, err := userService.GetProfile(c.UserContext(), userClaims.UserID)
+		if err != nil {
+			return sendUserError(c, err)
+		}
+		return presenter.OK(c, "avatar successfully uploaded", presenter.UserToProfileResp(u))
+	}
+}
+
+// ChangePassword changes the password of the current user.
+// @Summary Change my password
+// @Description Sets a new password once the current one is confirmed and logs the other sessions out.
+// @Tags Users
+// @Accept  json
+// @Param passwords body presenter.ChangePasswordReq true "Current and new password"
+// @Success 204
+// @Failure 400 {object} map[string]interface{} "error: wrong current password or invalid new password"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /me/password [post]
+func ChangePassword(serviceFactory ServiceFactory[*service.UserService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		var req presenter.ChangePasswordReq
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+		if err := BodyValidator(req); err != nil {
+			return presenter.BadRequest(c, err)
+		}
+
+		if err := userService.ChangePassword(c.UserContext(), userClaims.UserID, userClaims.SessionID, req.OldPassword, req.NewPassword); err != nil {
+			return sendUserError(c, err)
+		}
+		return presenter.NoContent(c)
+	}
+}
+
+// DeleteAccount deletes the account of the current user.
+// @Summary Delete my account
+// @Description Deletes the account once the password is confirmed. Boards the user is the only owner of go to their highest ranked other member or are deleted when nobody else is on them, workspaces they are the last admin of get a new admin. Board roles are removed and comments are kept under an anonymized name.
+// @Tags Users
+// @Accept  json
+// @Param account body presenter.DeleteAccountReq true "Password"
+// @Success 204
+// @Failure 400 {object} map[string]interface{} "error: wrong password"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /me [delete]
+func DeleteAccount(serviceFactory ServiceFactory[*service.UserService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		var req presenter.DeleteAccountReq
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+		if err := BodyValidator(req); err != nil {
+			return presenter.BadRequest(c, err)
+		}
+
+		if err := userService.DeleteAccount(c.UserContext(), userClaims.UserID, req.Password); err != nil {
+			return sendUserError(c, err)
+		}
+		return presenter.NoContent(c)
+	}
+}
+
+func sendUserError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, user.ErrUserNotFound):
+		return presenter.NotFound(c, err)
+	case errors.Is(err, user.ErrEmailAlreadyExists):
+		return presenter.Conflict(c, err)
+	case errors.Is(err, user.ErrInvalidEmail), errors.Is(err, user.ErrInvalidName),
+		errors.Is(err, user.ErrInvalidPassword), errors.Is(err, user.ErrWrongPassword),
+		errors.Is(err, user.ErrNothingToUpdate):
+		return presenter.BadRequest(c, err)
+	}
+	return presenter.InternalServerError(c, err)
+}
@@ -0,0 +1,307 @@
+package handlers
+
+import (
+	"errors"
+	presenter "server/api/http/handlers/presentor"
+	"server/internal/user"
+	"server/internal/workspace"
+	"server/pkg/jwt"
+	"server/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+var errWrongWorkspaceID = errors.New("given workspace_id format in path is not correct")
+
+// CreateWorkspace creates a workspace.
+// @Summary Create workspace
+// @Description Creates a workspace with the current user as its first admin.
+// @Tags Workspaces
+// @Accept  json
+// @Produce  json
+// @Param workspace body presenter.CreateWorkspaceReq true "Workspace details"
+// @Success 201 {object} presenter.WorkspaceResp "the new workspace"
+// @Failure 400 {object} map[string]interface{} "error: bad request, invalid workspace details"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /workspaces [post]
+func CreateWorkspace(serviceFactory ServiceFactory[*service.WorkspaceService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		workspaceService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		var req presenter.CreateWorkspaceReq
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+
+		w := presenter.CreateWorkspaceReqToWorkspace(&req)
+		if err := workspaceService.CreateWorkspace(c.UserContext(), userClaims.UserID, w); err != nil {
+			return sendWorkspaceError(c, err)
+		}
+		return presenter.Created(c, "workspace successfully created", presenter.WorkspaceToResp(*w))
+	}
+}
+
+// GetUserWorkspaces lists the workspaces of the current user.
+// @Summary Get my workspaces
+// @Description Lists the workspaces the current user is a member of, with their role in each.
+// @Tags Workspaces
+// @Produce  json
+// @Success 200 {array} presenter.WorkspaceResp "workspaces"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /workspaces [get]
+func GetUserWorkspaces(workspaceService *service.WorkspaceService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+
+		workspaces, err := workspaceService.GetUserWorkspaces(c.UserContext(), userClaims.UserID)
+		if err != nil {
+			return presenter.InternalServerError(c, err)
+		}
+		return presenter.OK(c, "workspaces successfully fetched", presenter.BatchWorkspacesToResp(workspaces))
+	}
+}
+
+// GetWorkspace returns a workspace.
+// @Summary Get workspace
+// @Description Returns a workspace to its members.
+// @Tags Workspaces
+// @Produce  json
+// @Param workspaceID path string true "Workspace ID"
+// @Success 200 {object} presenter.WorkspaceResp "the workspace"
+// @Failure 400 {object} map[string]interface{} "error: invalid workspace id"
+// @Failure 403 {object} map[string]interface{} "error: not a member of the workspace"
+// @Failure 404 {object} map[string]interface{} "error: workspace not found"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /workspaces/{workspaceID} [get]
+func GetWorkspace(workspaceService *service.WorkspaceService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		workspaceID, err := uuid.Parse(c.Params("workspaceID"))
+		if err != nil {
+			return presenter.BadRequest(c, errWrongWorkspaceID)
+		}
+
+		w, err := workspaceService.GetWorkspace(c.UserContext(), userClaims.UserID, workspaceID)
+		if err != nil {
+			return sendWorkspaceError(c, err)
+		}
+		return presenter.OK(c, "workspace successfully fetched", presenter.WorkspaceToResp(*w))
+	}
+}
+
+// GetWorkspaceMembers lists the members of a workspace.
+// @Summary Get workspace members
+// @Description The member directory of a workspace with the workspace role of everyone, open to all members.
+// @Tags Workspaces
+// @Produce  json
+// @Param workspaceID path string true "Workspace ID"
+// @Success 200 {array} presenter.WorkspaceMemberResp "members"
+// @Failure 400 {object} map[string]interface{} "error: invalid workspace id"
+// @Failure 403 {object} map[string]interface{} "error: not a member of the workspace"
+// @Failure 404 {object} map[string]interface{} "error: workspace not found"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /workspaces/{workspaceID}/members [get]
+func GetWorkspaceMembers(workspaceService *service.WorkspaceService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		workspaceID, err := uuid.Parse(c.Params("workspaceID"))
+		if err != nil {
+			return presenter.BadRequest(c, errWrongWorkspaceID)
+		}
+
+		members, err := workspaceService.GetMembers(c.UserContext(), userClaims.UserID, workspaceID)
+		if err != nil {
+			return sendWorkspaceError(c, err)
+		}
+		return presenter.OK(c, "members successfully fetched", presenter.BatchWorkspaceMembersToResp(members))
+	}
+}
+
+// GetWorkspaceBoards lists the boards of a workspace.
+// @Summary Get workspace boards
+// @Description Lists the active boards of a workspace the current user has a role on and its public boards.
+// @Tags Workspaces
+// @Produce  json
+// @Param workspaceID path string true "Workspace ID"
+// @Success 200 {array} presenter.UserBoard "boards"
+// @Failure 400 {object} map[string]interface{} "error: invalid workspace id"
+// @Failure 403 {object} map[string]interface{} "error: not a member of the workspace"
+// @Failure 404 {object} map[string]interface{} "error: workspace not found"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /workspaces/{workspaceID}/boards [get]
+func GetWorkspaceBoards(workspaceService *service.WorkspaceService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		workspaceID, err := uuid.Parse(c.Params("workspaceID"))
+		if err != nil {
+			return presenter.BadRequest(c, errWrongWorkspaceID)
+		}
+
+		boards, err := workspaceService.GetBoards(c.UserContext(), userClaims.UserID, workspaceID)
+		if err != nil {
+			return sendWorkspaceError(c, err)
+		}
+		return presenter.OK(c, "boards successfully fetched", presenter.BatchBoardsToUserBoard(boards))
+	}
+}
+
+// AddWorkspaceMember adds a user to a workspace.
+// @Summary Add workspace member
+// @Description Adds the user with the given email to the workspace with a role (admin, member or guest). Admins only; the user gets the default board roles of the role on the boards of the workspace.
+// @Tags Workspaces
+// @Accept  json
+// @Produce  json
+// @Param workspaceID path string true "Workspace ID"
+// @Param member body presenter.AddWorkspaceMemberReq true "Email and role"
+// @Success 201 {object} presenter.WorkspaceMemberResp "the new member"
+// @Failure 400 {object} map[string]interface{} "error: invalid role or unknown user"
+// @Failure 403 {object} map[string]interface{} "error: permission denied"
+// @Failure 404 {object} map[string]interface{} "error: workspace not found"
+// @Failure 409 {object} map[string]interface{} "error: already a member"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /workspaces/{workspaceID}/members [post]
+func AddWorkspaceMember(serviceFactory ServiceFactory[*service.WorkspaceService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		workspaceService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		workspaceID, err := uuid.Parse(c.Params("workspaceID"))
+		if err != nil {
+			return presenter.BadRequest(c, errWrongWorkspaceID)
+		}
+		var req presenter.AddWorkspaceMemberReq
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+
+		m, err := workspaceService.AddMember(c.UserContext(), userClaims.UserID, workspaceID, req.Email, workspace.Role(req.Role))
+		if err != nil {
+			return sendWorkspaceError(c, err)
+		}
+		return presenter.Created(c, "member successfully added", presenter.WorkspaceMemberToResp(*m))
+	}
+}
+
+// ChangeWorkspaceMemberRole changes the role of a workspace member.
+// @Summary Change workspace member role
+// @Description Changes the workspace role of a member, their inherited board roles follow it. Admins only; the last admin can not be demoted.
+// @Tags Workspaces
+// @Accept  json
+// @Produce  json
+// @Param workspaceID path string true "Workspace ID"
+// @Param userID path string true "User ID"
+// @Param role body presenter.WorkspaceRoleReq true "New role"
+// @Success 200 {object} presenter.WorkspaceMemberResp "the member"
+// @Failure 400 {object} map[string]interface{} "error: invalid role or last admin"
+// @Failure 403 {object} map[string]interface{} "error: permission denied"
+// @Failure 404 {object} map[string]interface{} "error: workspace or member not found"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /workspaces/{workspaceID}/members/{userID} [patch]
+func ChangeWorkspaceMemberRole(serviceFactory ServiceFactory[*service.WorkspaceService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		workspaceService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		workspaceID, err := uuid.Parse(c.Params("workspaceID"))
+		if err != nil {
+			return presenter.BadRequest(c, errWrongWorkspaceID)
+		}
+		memberID, err := uuid.Parse(c.Params("userID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given user_id format in path is not correct"))
+		}
+		var req presenter.WorkspaceRoleReq
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, err, fiber.StatusBadRequest)
+		}
+
+		m, err := workspaceService.ChangeMemberRole(c.UserContext(), userClaims.UserID, workspaceID, memberID, workspace.Role(req.Role))
+		if err != nil {
+			return sendWorkspaceError(c, err)
+		}
+		return presenter.OK(c, "role successfully changed", presenter.WorkspaceMemberToResp(*m))
+	}
+}
+
+// RemoveWorkspaceMember removes a member from a workspace.
+// @Summary Remove workspace member
+// @Description Removes a member from the workspace, by an admin or by the member themselves to leave it. Their inherited board roles are removed, roles given on the boards are kept. The last admin can not leave.
+// @Tags Workspaces
+// @Param workspaceID path string true "Workspace ID"
+// @Param userID path string true "User ID"
+// @Success 204 "member removed"
+// @Failure 400 {object} map[string]interface{} "error: last admin"
+// @Failure 403 {object} map[string]interface{} "error: permission denied"
+// @Failure 404 {object} map[string]interface{} "error: workspace or member not found"
+// @Failure 500 {object} map[string]interface{} "error: internal server error"
+// @Security BearerAuth
+// @Router /workspaces/{workspaceID}/members/{userID} [delete]
+func RemoveWorkspaceMember(serviceFactory ServiceFactory[*service.WorkspaceService]) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		workspaceService := serviceFactory(c.UserContext())
+
+		userClaims, ok := c.Locals(UserClaimKey).(*jwt.UserClaims)
+		if !ok {
+			return SendError(c, errWrongClaimType, fiber.StatusBadRequest)
+		}
+		workspaceID, err := uuid.Parse(c.Params("workspaceID"))
+		if err != nil {
+			return presenter.BadRequest(c, errWrongWorkspaceID)
+		}
+		memberID, err := uuid.Parse(c.Params("userID"))
+		if err != nil {
+			return presenter.BadRequest(c, errors.New("given user_id format in path is not correct"))
+		}
+
+		if err := workspaceService.RemoveMember(c.UserContext(), userClaims.UserID, workspaceID, memberID); err != nil {
+			return sendWorkspaceError(c, err)
+		}
+		return presenter.NoContent(c)
+	}
+}
+
+func sendWorkspaceError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, service.ErrPermissionDenied):
+		return presenter.Forbidden(c, err)
+	case errors.Is(err, workspace.ErrWorkspaceNotFound), errors.Is(err, workspace.ErrMemberNotFound):
+		return presenter.NotFound(c, err)
+	case errors.Is(err, workspace.ErrAlreadyMember):
+		return presenter.Conflict(c, err)
+	case errors.Is(err, workspace.ErrInvalidName), errors.Is(err, workspace.ErrLongDescription),
+		errors.Is(err, workspace.ErrInvalidRole), errors.Is(err, workspace.ErrLastAdmin),
+		errors.Is(err, user.ErrUserNotFound), errors.Is(err, service.ErrInviteeNotVerified):
+		return presenter.BadRequest(c, err)
+	}
+	return presenter.InternalServerError(c, err)
+}
@@ -1,15 +1,50 @@
 package middlewares
 
 import (
+	"context"
 	"errors"
 	"server/api/http/handlers"
 	"server/pkg/jwt"
+	"server/pkg/valuecontext"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func Auth(secret []byte) fiber.Handler {
+// TokenVerifier checks the bearer tokens, JWT access tokens as well as personal access tokens.
+type TokenVerifier interface {
+	VerifyToken(ctx context.Context, token string) (*jwt.UserClaims, error)
+}
+
+// Auth lets the requests with a valid access token or personal access token through. A personal access
+// token without the write scope only gets through with reading requests, one limited to a board puts a
+// board scope on the request.
+func Auth(verifier TokenVerifier) fiber.Handler {
+	return auth(verifier, allowScopedTokens)
+}
+
+// AccountAuth works like Auth but turns away the personal access tokens limited to a board, for the routes
+// that are not about one board, like listing the boards of the user or creating a new one.
+func AccountAuth(verifier TokenVerifier) fiber.Handler {
+	return auth(verifier, denyScopedTokens)
+}
+
+// SessionAuth lets only the requests with an access token of a logged in session through, for the
+// account settings that a personal access token must not reach.
+func SessionAuth(verifier TokenVerifier) fiber.Handler {
+	return auth(verifier, denyPersonalTokens)
+}
+
+// tokenPolicy tells which personal access tokens a route accepts.
+type tokenPolicy int
+
+const (
+	allowScopedTokens tokenPolicy = iota
+	denyScopedTokens
+	denyPersonalTokens
+)
+
+func auth(verifier TokenVerifier, policy tokenPolicy) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		authorization := c.Get("Authorization")
 
@@ -25,11 +60,26 @@ func Auth(secret []byte) fiber.Handler {
 
 		//pureToken := parts[1]
 		pureToken := parts[1]
-		claims, err := jwt.ParseToken(pureToken, secret)
+		claims, err := verifier.VerifyToken(c.UserContext(), pureToken)
 		if err != nil {
 			return handlers.SendError(c, err, fiber.StatusUnauthorized)
 		}
 
+		if claims.Type == jwt.TokenTypePersonal {
+			if policy == denyPersonalTokens {
+				return handlers.SendError(c, errors.New("personal access tokens can not be used here, log in instead"), fiber.StatusForbidden)
+			}
+			if policy == denyScopedTokens && claims.BoardID != nil {
+				return handlers.SendError(c, errors.New("this access token is limited to a board"), fiber.StatusForbidden)
+			}
+			if claims.ReadOnly && c.Method() != fiber.MethodGet && c.Method() != fiber.MethodHead {
+				return handlers.SendError(c, errors.New("this access token is read-only"), fiber.StatusForbidden)
+			}
+			if claims.BoardID != nil {
+				valuecontext.SetBoardScope(c.UserContext(), &valuecontext.BoardScope{UserID: claims.UserID, BoardID: *claims.BoardID})
+			}
+		}
+
 		c.Locals(jwt.UserClaimKey, claims)
 
 		return c.Next()
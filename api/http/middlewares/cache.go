@@ -1,19 +1,48 @@
 package middlewares
 
 import (
+	"strconv"
+	"sync/atomic"
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cache"
-	"time"
+	"github.com/gofiber/fiber/v2/utils"
 )
 
+// CacheVersion is part of the key of every response cached with it, bumping it makes them all stale at once.
+type CacheVersion struct {
+	v atomic.Uint64
+}
+
+// PublicBoardsCache versions the cached list of public boards.
+var PublicBoardsCache = &CacheVersion{}
+
 // SetupCacheMiddleware Define a function to configure cache middleware
-func SetupCacheMiddleware(expMinutes int) fiber.Handler {
+func SetupCacheMiddleware(expMinutes int, version *CacheVersion) fiber.Handler {
 	exp := time.Duration(expMinutes)
 	return cache.New(cache.Config{
 		Next: func(c *fiber.Ctx) bool {
 			return c.Query("noCache") == "true"
 		},
+		KeyGenerator: func(c *fiber.Ctx) string {
+			// the query holds the page and filters
+			return utils.CopyString(c.OriginalURL()) + "#" + strconv.FormatUint(version.v.Load(), 10)
+		},
 		Expiration:   exp * time.Minute,
 		CacheControl: true,
 	})
 }
+
+// InvalidateCache drops the responses cached under version once the request succeeded.
+func InvalidateCache(version *CacheVersion) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if err := c.Next(); err != nil {
+			return err
+		}
+		if c.Response().StatusCode() < fiber.StatusBadRequest {
+			version.v.Add(1)
+		}
+		return nil
+	}
+}
@@ -10,8 +10,9 @@ import (
 	"github.com/gofiber/storage/redis/v3"
 )
 
-// SetupLimiterMiddleware Define a function to configure limiter middleware
-func SetupLimiterMiddleware(durationMinutes int, max int, cfg config.Redis) fiber.Handler {
+// SetupLimiterMiddleware Define a function to configure limiter middleware, limiters of different names count
+// the requests of an IP apart.
+func SetupLimiterMiddleware(name string, durationMinutes int, max int, cfg config.Redis) fiber.Handler {
 	// Create limiter middleware with customized settings
 	exp := time.Duration(durationMinutes)
 	return limiter.New(limiter.Config{
@@ -20,6 +21,9 @@ func SetupLimiterMiddleware(durationMinutes int, max int, cfg config.Redis) fibe
 			// Example: Limit requests only for localhost (disable for local testing)
 			return c.IP() == "127.0.0.1"
 		},
+		KeyGenerator: func(c *fiber.Ctx) string {
+			return name + ":" + c.IP()
+		},
 		Max:        max,
 		Expiration: exp * time.Minute,
 		Storage: redis.New(redis.Config{
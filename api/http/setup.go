@@ -14,6 +14,7 @@ import (
 	"server/api/http/middlewares"
 	"server/config"
 	_ "server/docs"
+	"server/internal/user"
 	"server/pkg/adapters"
 	"server/service"
 )
@@ -24,6 +25,7 @@ func Run(cfg config.Config, app *service.AppContainer) {
 	})
 	// Serve static files from the "assets" directory
 	fiberApp.Static("/assets", "./assets")
+	fiberApp.Get("/.well-known/jwks.json", handlers.GetJWKS(app.TokenKeys()))
 
 	api := fiberApp.Group("/api/v1", middlewares.SetUserContext())
 
@@ -33,14 +35,23 @@ func Run(cfg config.Config, app *service.AppContainer) {
 	// register global routes
 	registerGlobalRoutes(api, app,
 		createGroupLogger("global"),
-		middlewares.SetupLimiterMiddleware(1, 1, cfg.Redis),
+		middlewares.SetupLimiterMiddleware("global", 1, 1, cfg.Redis),
+		// a login and its two-factor step come together, the password guesses are what this one is for
+		middlewares.SetupLimiterMiddleware("login", 1, 5, cfg.Redis),
 	)
-	secret := []byte(cfg.Server.TokenSecret)
-	registerBoardRoutes(api, app, secret, createGroupLogger("boards"))
-	registerTaskRoutes(api, app, secret, createGroupLogger("tasks"))
-	registerColumnRoutes(api, app, secret, createGroupLogger("columns"))
-	registerNotificationRoutes(api, app, secret, createGroupLogger("notifs"))
-	registerCommentRoutes(api, app, secret, createGroupLogger("comments"))
+	verifier := app.AuthService()
+	registerBoardRoutes(api, app, verifier, createGroupLogger("boards"))
+	registerTaskRoutes(api, app, verifier, createGroupLogger("tasks"))
+	registerColumnRoutes(api, app, verifier, createGroupLogger("columns"))
+	registerNotificationRoutes(api, app, verifier, createGroupLogger("notifs"))
+	registerCommentRoutes(api, app, verifier, createGroupLogger("comments"))
+	registerInvitationRoutes(api, app, verifier, createGroupLogger("invitations"))
+	registerTemplateRoutes(api, app, verifier, createGroupLogger("board_templates"))
+	registerWorkspaceRoutes(api, app, verifier, createGroupLogger("workspaces"))
+	registerAdminRoutes(api, app, verifier, createGroupLogger("admin"))
+	registerUserRoutes(api, app, verifier, createGroupLogger("users"))
+	registerSessionRoutes(api, app, verifier, createGroupLogger("sessions"))
+	registerAccessTokenRoutes(api, app, verifier, createGroupLogger("access_tokens"))
 
 	log.Fatal(fiberApp.Listen(fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.HTTPPort)))
 }
@@ -57,99 +68,593 @@ func registerUIRoutes(router fiber.Router, app *service.AppContainer, loggerMidd
 	router.Get("/metrics", monitor.New(monitor.Config{Title: "HeisenFlow Metrics Page"}))
 }
 
-func registerGlobalRoutes(router fiber.Router, app *service.AppContainer, loggerMiddleWare fiber.Handler, limiterMiddleWare fiber.Handler) {
+func registerGlobalRoutes(router fiber.Router, app *service.AppContainer, loggerMiddleWare fiber.Handler, limiterMiddleWare fiber.Handler,
+	loginLimiterMiddleWare fiber.Handler) {
 	router.Use(loggerMiddleWare)
-	router.Post("/register", limiterMiddleWare, handlers.RegisterUser(app.AuthService()))
-	router.Post("/login", handlers.LoginUser(app.AuthService()))
+	router.Post("/register", limiterMiddleWare,
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		handlers.RegisterUser(app.AuthServiceFromCtx),
+	)
+	router.Post("/login", loginLimiterMiddleWare, handlers.LoginUser(app.AuthService()))
+	router.Post("/login/2fa", limiterMiddleWare, handlers.LoginWithCode(app.AuthService()))
 	router.Get("/refresh", handlers.RefreshToken(app.AuthService()))
+
+	router.Post("/verify-email",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		handlers.VerifyEmail(app.AuthServiceFromCtx),
+	)
+	router.Post("/forgot-password", limiterMiddleWare,
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		handlers.ForgotPassword(app.AuthServiceFromCtx),
+	)
+	router.Post("/reset-password",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		handlers.ResetPassword(app.AuthServiceFromCtx),
+	)
 }
 
 func userRoleChecker() fiber.Handler {
 	return middlewares.RoleChecker("user")
 }
 
-func registerBoardRoutes(router fiber.Router, app *service.AppContainer, secret []byte, loggerMiddleWare fiber.Handler) {
+func registerBoardRoutes(router fiber.Router, app *service.AppContainer, verifier middlewares.TokenVerifier, loggerMiddleWare fiber.Handler) {
 	router = router.Group("/boards")
 	router.Use(loggerMiddleWare)
 
 	router.Post("",
 		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
-		middlewares.Auth(secret),
+		middlewares.AccountAuth(verifier),
+		middlewares.InvalidateCache(middlewares.PublicBoardsCache),
 		handlers.CreateUserBoard(app.BoardServiceFromCtx),
 	)
 	router.Get("/my-boards",
-		middlewares.Auth(secret),
+		middlewares.AccountAuth(verifier),
 		handlers.GetUserBoards(app.BoardService()),
 	)
 	router.Get("/publics",
-		middlewares.Auth(secret),
-		middlewares.SetupCacheMiddleware(5),
+		middlewares.AccountAuth(verifier),
+		middlewares.SetupCacheMiddleware(5, middlewares.PublicBoardsCache),
 		handlers.GetPublicBoards(app.BoardService()),
 	)
 	router.Get("/:boardID",
-		middlewares.Auth(secret),
+		middlewares.Auth(verifier),
 		handlers.GetFullBoardByID(app.BoardService()),
 	)
 
+	router.Patch("/:boardID",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.Auth(verifier),
+		// names and visibility show up in the public list
+		middlewares.InvalidateCache(middlewares.PublicBoardsCache),
+		handlers.UpdateBoard(app.BoardServiceFromCtx),
+	)
+
 	router.Delete("/:boardID",
-		middlewares.Auth(secret),
+		middlewares.Auth(verifier),
+		middlewares.InvalidateCache(middlewares.PublicBoardsCache),
 		handlers.DeleteBoard(app.BoardService()),
 	)
 
+	router.Post("/:boardID/archive",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.Auth(verifier),
+		middlewares.InvalidateCache(middlewares.PublicBoardsCache),
+		handlers.ArchiveBoard(app.BoardServiceFromCtx),
+	)
+
+	router.Post("/:boardID/unarchive",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.Auth(verifier),
+		middlewares.InvalidateCache(middlewares.PublicBoardsCache),
+		handlers.UnarchiveBoard(app.BoardServiceFromCtx),
+	)
+
+	router.Post("/:boardID/template",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.AccountAuth(verifier),
+		handlers.SaveBoardAsTemplate(app.TemplateServiceFromCtx),
+	)
+
+	router.Post("/:boardID/clone",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.AccountAuth(verifier),
+		middlewares.InvalidateCache(middlewares.PublicBoardsCache),
+		handlers.CloneBoard(app.TemplateServiceFromCtx),
+	)
+
+	router.Get("/:boardID/trash",
+		middlewares.Auth(verifier),
+		handlers.GetBoardTrash(app.TaskService()),
+	)
+
+	router.Get("/:boardID/dependency-graph",
+		middlewares.Auth(verifier),
+		handlers.GetBoardDependencyGraph(app.TaskService()),
+	)
+
+	router.Get("/:boardID/transitions",
+		middlewares.Auth(verifier),
+		handlers.GetColumnTransitions(app.ColumnService()),
+	)
+
+	router.Put("/:boardID/transitions",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.Auth(verifier),
+		handlers.SetColumnTransitions(app.ColumnServiceFromCtx),
+	)
+
+	router.Get("/:boardID/schedule",
+		middlewares.Auth(verifier),
+		handlers.GetBoardSchedule(app.TaskService()),
+	)
+
+	router.Get("/:boardID/members",
+		middlewares.Auth(verifier),
+		handlers.GetBoardMembers(app.BoardService()),
+	)
+
+	router.Patch("/:boardID/members/:userID",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.Auth(verifier),
+		handlers.ChangeMemberRole(app.BoardServiceFromCtx),
+	)
+
+	router.Get("/:boardID/roles",
+		middlewares.Auth(verifier),
+		handlers.GetBoardRoles(app.RoleService()),
+	)
+
+	router.Post("/:boardID/roles",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.Auth(verifier),
+		handlers.CreateBoardRole(app.RoleServiceFromCtx),
+	)
+
+	router.Patch("/:boardID/roles/:roleID",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.Auth(verifier),
+		handlers.UpdateBoardRole(app.RoleServiceFromCtx),
+	)
+
+	router.Delete("/:boardID/roles/:roleID",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.Auth(verifier),
+		handlers.DeleteBoardRole(app.RoleServiceFromCtx),
+	)
+
+	router.Delete("/:boardID/members/:userID",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.Auth(verifier),
+		handlers.RemoveBoardMember(app.BoardServiceFromCtx),
+	)
+
+	router.Post("/:boardID/leave",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.Auth(verifier),
+		handlers.LeaveBoard(app.BoardServiceFromCtx),
+	)
+
+	router.Get("/:boardID/ownership-transfer",
+		middlewares.Auth(verifier),
+		handlers.GetOwnershipTransfer(app.BoardService()),
+	)
+
+	router.Post("/:boardID/ownership-transfer",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.Auth(verifier),
+		handlers.ProposeOwnershipTransfer(app.BoardServiceFromCtx),
+	)
+
+	router.Post("/:boardID/ownership-transfer/accept",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.Auth(verifier),
+		handlers.AcceptOwnershipTransfer(app.BoardServiceFromCtx),
+	)
+
+	router.Delete("/:boardID/ownership-transfer",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.Auth(verifier),
+		handlers.CancelOwnershipTransfer(app.BoardServiceFromCtx),
+	)
+
+	router.Get("/:boardID/invitations",
+		middlewares.Auth(verifier),
+		handlers.GetBoardInvitations(app.InvitationService()),
+	)
+
+	router.Delete("/:boardID/invitations/:invitationID",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.Auth(verifier),
+		handlers.RevokeInvitation(app.InvitationServiceFromCtx),
+	)
+
+	router.Get("/:boardID/invite-links",
+		middlewares.Auth(verifier),
+		handlers.GetInviteLinks(app.InvitationService()),
+	)
+
+	router.Post("/:boardID/invite-links",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.Auth(verifier),
+		handlers.CreateInviteLink(app.InvitationServiceFromCtx),
+	)
+
+	router.Delete("/:boardID/invite-links/:linkID",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.Auth(verifier),
+		handlers.RevokeInviteLink(app.InvitationServiceFromCtx),
+	)
+
 	router.Post("/invite", middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
-		middlewares.Auth(secret),
-		handlers.InviteToBoard(app.BoardServiceFromCtx))
+		middlewares.Auth(verifier),
+		handlers.InviteToBoard(app.InvitationServiceFromCtx))
+}
+
+func registerInvitationRoutes(router fiber.Router, app *service.AppContainer, verifier middlewares.TokenVerifier, loggerMiddleWare fiber.Handler) {
+	invitations := router.Group("/invitations")
+	invitations.Use(loggerMiddleWare)
+
+	invitations.Get("",
+		middlewares.AccountAuth(verifier),
+		handlers.GetUserInvitations(app.InvitationService()),
+	)
+
+	invitations.Post("/:invitationID/accept",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.AccountAuth(verifier),
+		handlers.AcceptInvitation(app.InvitationServiceFromCtx),
+	)
+
+	invitations.Post("/:invitationID/decline",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.AccountAuth(verifier),
+		handlers.DeclineInvitation(app.InvitationServiceFromCtx),
+	)
+
+	links := router.Group("/invite-links")
+	links.Use(loggerMiddleWare)
+
+	links.Post("/:token/join",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.AccountAuth(verifier),
+		handlers.JoinByInviteLink(app.InvitationServiceFromCtx),
+	)
 }
 
-func registerTaskRoutes(router fiber.Router, app *service.AppContainer, secret []byte, loggerMiddleWare fiber.Handler) {
+func registerTemplateRoutes(router fiber.Router, app *service.AppContainer, verifier middlewares.TokenVerifier, loggerMiddleWare fiber.Handler) {
+	router = router.Group("/templates")
+	router.Use(loggerMiddleWare)
+
+	router.Get("",
+		middlewares.AccountAuth(verifier),
+		handlers.GetUserTemplates(app.TemplateService()),
+	)
+
+	router.Get("/:templateID",
+		middlewares.AccountAuth(verifier),
+		handlers.GetTemplate(app.TemplateService()),
+	)
+
+	router.Delete("/:templateID",
+		middlewares.AccountAuth(verifier),
+		handlers.DeleteTemplate(app.TemplateService()),
+	)
+
+	router.Post("/:templateID/boards",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.AccountAuth(verifier),
+		middlewares.InvalidateCache(middlewares.PublicBoardsCache),
+		handlers.CreateBoardFromTemplate(app.TemplateServiceFromCtx),
+	)
+}
+
+func registerWorkspaceRoutes(router fiber.Router, app *service.AppContainer, verifier middlewares.TokenVerifier, loggerMiddleWare fiber.Handler) {
+	router = router.Group("/workspaces")
+	router.Use(loggerMiddleWare)
+
+	router.Post("",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.AccountAuth(verifier),
+		handlers.CreateWorkspace(app.WorkspaceServiceFromCtx),
+	)
+
+	router.Get("",
+		middlewares.AccountAuth(verifier),
+		handlers.GetUserWorkspaces(app.WorkspaceService()),
+	)
+
+	router.Get("/:workspaceID",
+		middlewares.AccountAuth(verifier),
+		handlers.GetWorkspace(app.WorkspaceService()),
+	)
+
+	router.Get("/:workspaceID/boards",
+		middlewares.AccountAuth(verifier),
+		handlers.GetWorkspaceBoards(app.WorkspaceService()),
+	)
+
+	router.Get("/:workspaceID/members",
+		middlewares.AccountAuth(verifier),
+		handlers.GetWorkspaceMembers(app.WorkspaceService()),
+	)
+
+	router.Post("/:workspaceID/members",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.AccountAuth(verifier),
+		handlers.AddWorkspaceMember(app.WorkspaceServiceFromCtx),
+	)
+
+	router.Patch("/:workspaceID/members/:userID",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.AccountAuth(verifier),
+		handlers.ChangeWorkspaceMemberRole(app.WorkspaceServiceFromCtx),
+	)
+
+	router.Delete("/:workspaceID/members/:userID",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.AccountAuth(verifier),
+		handlers.RemoveWorkspaceMember(app.WorkspaceServiceFromCtx),
+	)
+
+	router.Get("/:workspaceID/roles",
+		middlewares.AccountAuth(verifier),
+		handlers.GetWorkspaceRoles(app.RoleService()),
+	)
+
+	router.Post("/:workspaceID/roles",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.AccountAuth(verifier),
+		handlers.CreateWorkspaceRole(app.RoleServiceFromCtx),
+	)
+
+	router.Patch("/:workspaceID/roles/:roleID",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.AccountAuth(verifier),
+		handlers.UpdateWorkspaceRole(app.RoleServiceFromCtx),
+	)
+
+	router.Delete("/:workspaceID/roles/:roleID",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.AccountAuth(verifier),
+		handlers.DeleteWorkspaceRole(app.RoleServiceFromCtx),
+	)
+}
+
+func registerUserRoutes(router fiber.Router, app *service.AppContainer, verifier middlewares.TokenVerifier, loggerMiddleWare fiber.Handler) {
+	router = router.Group("/me")
+	router.Use(loggerMiddleWare)
+
+	router.Get("",
+		middlewares.SessionAuth(verifier),
+		handlers.GetProfile(app.UserService()),
+	)
+
+	router.Patch("",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.SessionAuth(verifier),
+		handlers.UpdateProfile(app.UserServiceFromCtx),
+	)
+
+	router.Post("/verification",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.SessionAuth(verifier),
+		handlers.ResendVerification(app.AuthServiceFromCtx),
+	)
+
+	router.Get("/2fa",
+		middlewares.SessionAuth(verifier),
+		handlers.GetTwoFactorStatus(app.AuthService()),
+	)
+
+	router.Post("/2fa",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.SessionAuth(verifier),
+		handlers.EnrollTwoFactor(app.AuthServiceFromCtx),
+	)
+
+	router.Post("/2fa/enable",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.SessionAuth(verifier),
+		handlers.EnableTwoFactor(app.AuthServiceFromCtx),
+	)
+
+	router.Delete("/2fa",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.SessionAuth(verifier),
+		handlers.DisableTwoFactor(app.AuthServiceFromCtx),
+	)
+
+	router.Post("/2fa/recovery-codes",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.SessionAuth(verifier),
+		handlers.RegenerateRecoveryCodes(app.AuthServiceFromCtx),
+	)
+
+	router.Put("/avatar",
+		middlewares.SessionAuth(verifier),
+		handlers.UploadAvatar(app.UserService()),
+	)
+
+	router.Post("/password",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.SessionAuth(verifier),
+		handlers.ChangePassword(app.UserServiceFromCtx),
+	)
+
+	router.Delete("",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.SessionAuth(verifier),
+		middlewares.InvalidateCache(middlewares.PublicBoardsCache),
+		handlers.DeleteAccount(app.UserServiceFromCtx),
+	)
+}
+
+func registerSessionRoutes(router fiber.Router, app *service.AppContainer, verifier middlewares.TokenVerifier, loggerMiddleWare fiber.Handler) {
+	router.Post("/logout", loggerMiddleWare, middlewares.SessionAuth(verifier), handlers.Logout(app.AuthService()))
+	router.Post("/logout-all", loggerMiddleWare, middlewares.SessionAuth(verifier), handlers.LogoutAll(app.AuthService()))
+
+	sessions := router.Group("/sessions")
+	sessions.Use(loggerMiddleWare, middlewares.SessionAuth(verifier))
+	sessions.Get("", handlers.GetSessions(app.AuthService()))
+	sessions.Delete("/:sessionID", handlers.RevokeSession(app.AuthService()))
+}
+
+func registerAccessTokenRoutes(router fiber.Router, app *service.AppContainer, verifier middlewares.TokenVerifier, loggerMiddleWare fiber.Handler) {
+	router = router.Group("/access-tokens")
+	router.Use(loggerMiddleWare, middlewares.SessionAuth(verifier))
+
+	router.Post("",
+		handlers.CreateAccessToken(app.AccessTokenService()),
+	)
+
+	router.Get("",
+		handlers.GetAccessTokens(app.AccessTokenService()),
+	)
+
+	router.Delete("/:tokenID",
+		handlers.RevokeAccessToken(app.AccessTokenService()),
+	)
+}
+
+func registerAdminRoutes(router fiber.Router, app *service.AppContainer, verifier middlewares.TokenVerifier, loggerMiddleWare fiber.Handler) {
+	router = router.Group("/admin")
+	router.Use(loggerMiddleWare, middlewares.SessionAuth(verifier), middlewares.RoleChecker(user.RoleAdmin.String()))
+
+	router.Get("/users",
+		handlers.GetAllUsers(app.AdminService()),
+	)
+
+	router.Post("/users/:userID/suspend",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		handlers.SuspendUser(app.AdminServiceFromCtx),
+	)
+
+	router.Post("/users/:userID/unsuspend",
+		handlers.UnsuspendUser(app.AdminServiceFromCtx),
+	)
+
+	router.Get("/boards",
+		handlers.GetAllBoards(app.AdminService()),
+	)
+
+	router.Post("/boards/:boardID/ownership",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		handlers.ForceTransferOwnership(app.AdminServiceFromCtx),
+	)
+
+	router.Get("/stats",
+		handlers.GetSystemStats(app.AdminService()),
+	)
+}
+
+func registerTaskRoutes(router fiber.Router, app *service.AppContainer, verifier middlewares.TokenVerifier, loggerMiddleWare fiber.Handler) {
 	router = router.Group("/tasks")
 	router.Use(loggerMiddleWare)
 
 	router.Post("",
 		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
-		middlewares.Auth(secret),
+		middlewares.Auth(verifier),
 		handlers.CreateTask(app.TaskServiceFromCtx),
 	)
 
 	router.Get("/:taskID",
-		middlewares.Auth(secret),
+		middlewares.Auth(verifier),
 		handlers.GetFullTaskByID(app.TaskService()),
 	)
 
+	router.Get("/:taskID/tree",
+		middlewares.Auth(verifier),
+		handlers.GetTaskTree(app.TaskService()),
+	)
+
+	router.Get("/:taskID/dependencies",
+		middlewares.Auth(verifier),
+		handlers.GetTaskDependencies(app.TaskService()),
+	)
+
 	router.Patch("/reorder",
 		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
-		middlewares.Auth(secret),
+		middlewares.Auth(verifier),
 		handlers.ReorderTasks(app.TaskServiceFromCtx),
 	)
 
 	router.Patch("/:taskID",
 		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
-		middlewares.Auth(secret),
+		middlewares.Auth(verifier),
+		handlers.UpdateTask(app.TaskServiceFromCtx),
+	)
+
+	router.Put("/:taskID/column",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.Auth(verifier),
 		handlers.UpdateTaskColumnByID(app.TaskServiceFromCtx),
 	)
 
+	// must stay above "/:taskID" so it is not taken for a task id
+	router.Delete("/dependency",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.Auth(verifier),
+		handlers.RemoveDependency(app.TaskServiceFromCtx),
+	)
+
+	router.Delete("/:taskID",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.Auth(verifier),
+		handlers.DeleteTask(app.TaskServiceFromCtx),
+	)
+
+	router.Post("/:taskID/move",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.Auth(verifier),
+		handlers.MoveTask(app.TaskServiceFromCtx),
+	)
+
+	router.Post("/:taskID/restore",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.Auth(verifier),
+		handlers.RestoreTask(app.TaskServiceFromCtx),
+	)
+
 	router.Post("/dependency",
 		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
-		middlewares.Auth(secret),
+		middlewares.Auth(verifier),
 		handlers.AddDependency(app.TaskServiceFromCtx),
 	)
 }
 
-func registerColumnRoutes(router fiber.Router, app *service.AppContainer, secret []byte, loggerMiddleWare fiber.Handler) {
+func registerColumnRoutes(router fiber.Router, app *service.AppContainer, verifier middlewares.TokenVerifier, loggerMiddleWare fiber.Handler) {
 	router = router.Group("/columns")
 	router.Use(loggerMiddleWare)
 	router.Post("",
-		middlewares.Auth(secret),
+		middlewares.Auth(verifier),
 		handlers.CreateColumns(app.ColumnService()),
 	)
+	router.Patch("/:columnID",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.Auth(verifier),
+		handlers.UpdateColumn(app.ColumnServiceFromCtx),
+	)
+
+	router.Post("/:columnID/move",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.Auth(verifier),
+		handlers.MoveColumn(app.ColumnServiceFromCtx),
+	)
+
+	router.Put("/:columnID/kind",
+		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
+		middlewares.Auth(verifier),
+		handlers.UpdateColumnKind(app.ColumnServiceFromCtx),
+	)
+
 	router.Delete("/:columnID",
-		middlewares.Auth(secret),
+		middlewares.Auth(verifier),
 		handlers.DeleteColumn(app.ColumnService()),
 	)
 
 	router.Put("",
 		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
-		middlewares.Auth(secret),
+		middlewares.Auth(verifier),
 		handlers.ReorderColumns(app.ColumnServiceFromCtx),
 	)
 }
@@ -187,20 +692,20 @@ func loggerSetup(app *fiber.App) func(groupName string) fiber.Handler {
 	}
 	return createGroupLogger
 }
-func registerNotificationRoutes(router fiber.Router, app *service.AppContainer, secret []byte, loggerMiddleWare fiber.Handler) {
+func registerNotificationRoutes(router fiber.Router, app *service.AppContainer, verifier middlewares.TokenVerifier, loggerMiddleWare fiber.Handler) {
 	router = router.Group("/notifications")
 	router.Use(loggerMiddleWare)
-	router.Get("", middlewares.Auth(secret), handlers.GetNotifications(app.NotificationService()))
-	router.Patch("/read/:notifID", middlewares.Auth(secret), handlers.UpdateNotifications(app.NotificationService()))
+	router.Get("", middlewares.AccountAuth(verifier), handlers.GetNotifications(app.NotificationService()))
+	router.Patch("/read/:notifID", middlewares.AccountAuth(verifier), handlers.UpdateNotifications(app.NotificationService()))
 }
 
-func registerCommentRoutes(router fiber.Router, app *service.AppContainer, secret []byte, loggerMiddleWare fiber.Handler) {
+func registerCommentRoutes(router fiber.Router, app *service.AppContainer, verifier middlewares.TokenVerifier, loggerMiddleWare fiber.Handler) {
 	router = router.Group("/comments")
 	router.Use(loggerMiddleWare)
 
 	router.Post("",
 		middlewares.SetTransaction(adapters.NewGormCommitter(app.RawDBConnection())),
-		middlewares.Auth(secret),
+		middlewares.Auth(verifier),
 		handlers.CreateUserComment(app.CommentServiceFromCtx),
 	)
 }
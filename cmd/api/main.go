@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"log"
 	"os"
@@ -10,7 +11,10 @@ import (
 	http_server "server/api/http"
 )
 
-var configPath = flag.String("config", "", "configuration path")
+var (
+	configPath = flag.String("config", "", "configuration path")
+	makeAdmin  = flag.String("make-admin", "", "email of a registered user to make a global admin, the server is not started")
+)
 
 //	@Title			heisenflow-System
 //	@version		1.0
@@ -33,6 +37,14 @@ func main() {
 		log.Fatal(err)
 	}
 
+	if len(*makeAdmin) > 0 {
+		if _, err := app.AdminService().MakeAdmin(context.Background(), *makeAdmin); err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("%s is now an admin", *makeAdmin)
+		return
+	}
+
 	http_server.Run(cfg, app)
 }
 
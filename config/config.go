@@ -1,9 +1,13 @@
 package config
 
 type Config struct {
-	Server Server `mapstructure:"server"`
-	DB     DB     `mapstructure:"db"`
-	Redis  Redis  `mapstructure:"redis"`
+	Server     Server     `mapstructure:"server"`
+	DB         DB         `mapstructure:"db"`
+	Redis      Redis      `mapstructure:"redis"`
+	Trash      Trash      `mapstructure:"trash"`
+	Invitation Invitation `mapstructure:"invitation"`
+	Admin      Admin      `mapstructure:"admin"`
+	Mail       Mail       `mapstructure:"mail"`
 }
 
 type Server struct {
@@ -12,6 +16,23 @@ type Server struct {
 	TokenExpMinutes        uint   `mapstructure:"token_exp_minutes"`
 	RefreshTokenExpMinutes uint   `mapstructure:"refresh_token_exp_minutes"`
 	TokenSecret            string `mapstructure:"token_secret"`
+	// SigningKeyID names the key of SigningKeys new tokens are signed with, empty signs them with TokenSecret
+	SigningKeyID string       `mapstructure:"signing_key_id"`
+	SigningKeys  []SigningKey `mapstructure:"signing_keys"`
+	// TokenSecretRetireAt keeps the tokens signed with TokenSecret valid until then (RFC 3339) once
+	// SigningKeyID is set, empty rejects them right away
+	TokenSecretRetireAt string `mapstructure:"token_secret_retire_at"`
+	// TwoFactorIssuer names the service in authenticator apps
+	TwoFactorIssuer string `mapstructure:"two_factor_issuer"`
+	// ChallengeExpMinutes is how long a user with two-factor authentication has to send a code after their password
+	ChallengeExpMinutes uint `mapstructure:"challenge_exp_minutes"`
+}
+
+type SigningKey struct {
+	ID             string `mapstructure:"id"`
+	Algorithm      string `mapstructure:"algorithm"`        // "RS256" or "EdDSA"
+	PrivateKeyFile string `mapstructure:"private_key_file"` // PEM, PKCS #8 or PKCS #1
+	RetireAt       string `mapstructure:"retire_at"`        // RFC 3339, the end of the overlap window of a replaced key
 }
 
 type DB struct {
@@ -27,3 +48,29 @@ type Redis struct {
 	Host string `mapstructure:"host"`
 	Port int    `mapstructure:"port"`
 }
+
+type Trash struct {
+	RetentionDays        uint `mapstructure:"retention_days"`
+	PurgeIntervalMinutes uint `mapstructure:"purge_interval_minutes"`
+}
+
+type Invitation struct {
+	ExpireHours uint `mapstructure:"expire_hours"` // 0 keeps invitations open until they are answered
+}
+
+type Admin struct {
+	Emails []string `mapstructure:"emails"` // registered users made global admins at startup
+}
+
+type Mail struct {
+	Driver                     string `mapstructure:"driver"` // "smtp" or "log"
+	Host                       string `mapstructure:"host"`
+	Port                       int    `mapstructure:"port"`
+	User                       string `mapstructure:"user"`
+	Pass                       string `mapstructure:"pass"`
+	From                       string `mapstructure:"from"`
+	LogFile                    string `mapstructure:"log_file"` // the log driver appends the mails here, empty writes them to the standard logger
+	BaseURL                    string `mapstructure:"base_url"` // the frontend address the links in the mails point to
+	VerificationExpireHours    uint   `mapstructure:"verification_expire_hours"`
+	ResetPasswordExpireMinutes uint   `mapstructure:"reset_password_expire_minutes"`
+}
@@ -0,0 +1,85 @@
+package accesstoken
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxLifetimeDays = 365
+	// lastUsedPrecision keeps a busy token from writing on every request
+	lastUsedPrecision = time.Minute
+)
+
+type Ops struct {
+	repo Repo
+}
+
+func NewOps(repo Repo) *Ops {
+	return &Ops{repo}
+}
+
+// Create stores a token that expires in lifetimeDays and returns its secret, which can not be recovered later.
+func (o *Ops) Create(ctx context.Context, t *AccessToken, lifetimeDays uint) (string, error) {
+	t.Name = strings.TrimSpace(t.Name)
+	if t.Name == "" {
+		return "", ErrInvalidName
+	}
+	if err := validateScopes(t.Scopes); err != nil {
+		return "", err
+	}
+	if lifetimeDays == 0 || lifetimeDays > maxLifetimeDays {
+		return "", ErrInvalidExpiry
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		return "", err
+	}
+	t.Hash = hash(secret)
+	t.Prefix = secret[:len(SecretPrefix)+8]
+	t.ExpiresAt = time.Now().AddDate(0, 0, int(lifetimeDays))
+	if err := o.repo.Insert(ctx, t); err != nil {
+		return "", err
+	}
+	return secret, nil
+}
+
+// Authenticate returns the active token of secret and records that it was used.
+func (o *Ops) Authenticate(ctx context.Context, secret string) (*AccessToken, error) {
+	t, err := o.repo.GetByHash(ctx, hash(secret))
+	if err != nil {
+		return nil, err
+	}
+	if t == nil || !t.IsActive() {
+		return nil, ErrInvalidToken
+	}
+
+	now := time.Now()
+	if t.LastUsedAt == nil || now.Sub(*t.LastUsedAt) >= lastUsedPrecision {
+		if err := o.repo.SetLastUsedAt(ctx, t.ID, now); err != nil {
+			return nil, err
+		}
+		t.LastUsedAt = &now
+	}
+	return t, nil
+}
+
+func (o *Ops) GetUserTokens(ctx context.Context, userID uuid.UUID) ([]AccessToken, error) {
+	return o.repo.GetByUserID(ctx, userID)
+}
+
+// Revoke ends a token of the user userID.
+func (o *Ops) Revoke(ctx context.Context, userID, id uuid.UUID) error {
+	t, err := o.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if t == nil || t.UserID != userID || t.RevokedAt != nil {
+		return ErrTokenNotFound
+	}
+	return o.repo.Revoke(ctx, id)
+}
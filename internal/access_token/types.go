@@ -0,0 +1,108 @@
+/*
+A personal access token lets scripts call the api as its user without their password. It has a name,
+scopes, an expiry and optionally a board it is limited to. The secret is shown once at creation, only its
+hash and a short prefix to recognize it by are stored.
+*/
+
+package accesstoken
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SecretPrefix starts every token, it tells them apart from JWTs.
+const SecretPrefix = "hfp_"
+
+type Scope string
+
+const (
+	// ScopeRead allows the requests that only read.
+	ScopeRead Scope = "read"
+	// ScopeWrite allows every request, reading included.
+	ScopeWrite Scope = "write"
+)
+
+var (
+	ErrTokenNotFound  = errors.New("access token not found")
+	ErrInvalidToken   = errors.New("the access token is invalid, expired or revoked")
+	ErrInvalidName    = errors.New("the name of an access token can not be empty")
+	ErrInvalidScopes  = errors.New("scopes should be one or more of the following values: read, write")
+	ErrInvalidExpiry  = errors.New("an access token has to expire in 1 to 365 days")
+	ErrNotBoardMember = errors.New("an access token can only be limited to a board you are a member of")
+)
+
+type Repo interface {
+	Insert(ctx context.Context, t *AccessToken) error
+	GetByID(ctx context.Context, id uuid.UUID) (*AccessToken, error)
+	GetByHash(ctx context.Context, hash string) (*AccessToken, error)
+	// GetByUserID returns the tokens of a user that are not revoked, the newest first.
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]AccessToken, error)
+	Revoke(ctx context.Context, id uuid.UUID) error
+	SetLastUsedAt(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+type AccessToken struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Name       string
+	Scopes     []Scope
+	BoardID    *uuid.UUID // the only board the token can act on, nil for all boards of the user
+	Prefix     string     // the start of the secret, to recognize the token by
+	Hash       string
+	ExpiresAt  time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+func (t *AccessToken) IsActive() bool {
+	return t.RevokedAt == nil && time.Now().Before(t.ExpiresAt)
+}
+
+func (t *AccessToken) CanWrite() bool {
+	for _, s := range t.Scopes {
+		if s == ScopeWrite {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSecret tells whether a bearer token is an access token rather than a JWT.
+func IsSecret(token string) bool {
+	return strings.HasPrefix(token, SecretPrefix)
+}
+
+func validateScopes(scopes []Scope) error {
+	if len(scopes) == 0 {
+		return ErrInvalidScopes
+	}
+	for _, s := range scopes {
+		if s != ScopeRead && s != ScopeWrite {
+			return ErrInvalidScopes
+		}
+	}
+	return nil
+}
+
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return SecretPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
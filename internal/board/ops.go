@@ -37,18 +37,25 @@ func (o *Ops) GetFullBoardByID(ctx context.Context, id uuid.UUID) (*Board, error
 	return board, nil
 }
 
-func (o *Ops) GetUserBoards(ctx context.Context, userID uuid.UUID, page, pageSize uint) ([]Board, uint, error) {
+func (o *Ops) GetUserBoards(ctx context.Context, userID uuid.UUID, page, pageSize uint, withArchived bool) ([]Board, uint, error) {
 	limit := pageSize
 	offset := (page - 1) * pageSize
 
-	return o.repo.GetUserBoards(ctx, userID, limit, offset)
+	return o.repo.GetUserBoards(ctx, userID, limit, offset, withArchived)
 }
 
-func (o *Ops) GetPublicBoards(ctx context.Context, userID uuid.UUID, page, pageSize uint) ([]Board, uint, error) {
+func (o *Ops) GetPublicBoards(ctx context.Context, userID uuid.UUID, page, pageSize uint, withArchived bool) ([]Board, uint, error) {
 	limit := pageSize
 	offset := (page - 1) * pageSize
 
-	return o.repo.GetPublicBoards(ctx, userID, limit, offset)
+	return o.repo.GetPublicBoards(ctx, userID, limit, offset, withArchived)
+}
+
+func (o *Ops) GetAllBoards(ctx context.Context, page, pageSize uint) ([]Board, uint, error) {
+	limit := pageSize
+	offset := (page - 1) * pageSize
+
+	return o.repo.GetAll(ctx, limit, offset)
 }
 
 func (o *Ops) Create(ctx context.Context, board *Board) error {
@@ -56,8 +63,20 @@ func (o *Ops) Create(ctx context.Context, board *Board) error {
 		return ErrInvalidName
 	}
 
-	if board.Type != string(Private) && board.Type != string(Public) {
-		return ErrWrongType
+	if err := validateDescription(board.Description); err != nil {
+		return err
+	}
+	if err := validateType(board.Type); err != nil {
+		return err
+	}
+	if board.Type == string(Workspace) && board.WorkspaceID == nil {
+		return ErrNoWorkspace
+	}
+	if board.WIPPolicy == "" {
+		board.WIPPolicy = WIPPolicyEnforce
+	}
+	if err := validateWIPPolicy(board.WIPPolicy); err != nil {
+		return err
 	}
 	if board.CreatedAt.After(time.Now()) {
 		return ErrWrongBoardTime
@@ -69,3 +88,74 @@ func (o *Ops) Create(ctx context.Context, board *Board) error {
 func (o *Ops) Delete(ctx context.Context, boardID uuid.UUID) error {
 	return o.repo.DeleteByID(ctx, boardID)
 }
+
+func (o *Ops) Update(ctx context.Context, boardID uuid.UUID, fields *UpdateFields) (*Board, error) {
+	if fields.IsEmpty() {
+		return nil, ErrNothingToUpdate
+	}
+	if fields.Name != nil {
+		if err := ValidateBoardName(*fields.Name); err != nil {
+			return nil, err
+		}
+	}
+	if fields.Description != nil {
+		if err := validateDescription(*fields.Description); err != nil {
+			return nil, err
+		}
+	}
+	if fields.Type != nil {
+		if err := validateType(*fields.Type); err != nil {
+			return nil, err
+		}
+		if *fields.Type == string(Workspace) {
+			b, err := o.GetBoardByID(ctx, boardID)
+			if err != nil {
+				return nil, err
+			}
+			if b.WorkspaceID == nil {
+				return nil, ErrNoWorkspace
+			}
+		}
+	}
+	if fields.WIPPolicy != nil {
+		if err := validateWIPPolicy(*fields.WIPPolicy); err != nil {
+			return nil, err
+		}
+	}
+	return o.repo.Update(ctx, boardID, fields)
+}
+
+// CheckWritable returns ErrBoardArchived for an archived board, which only allows reading.
+func (o *Ops) CheckWritable(ctx context.Context, boardID uuid.UUID) error {
+	b, err := o.GetBoardByID(ctx, boardID)
+	if err != nil {
+		return err
+	}
+	if b.IsArchived() {
+		return ErrBoardArchived
+	}
+	return nil
+}
+
+func (o *Ops) Archive(ctx context.Context, b *Board) error {
+	if b.IsArchived() {
+		return ErrBoardArchived
+	}
+	now := time.Now()
+	if err := o.repo.SetArchivedAt(ctx, b.ID, &now); err != nil {
+		return err
+	}
+	b.ArchivedAt = &now
+	return nil
+}
+
+func (o *Ops) Unarchive(ctx context.Context, b *Board) error {
+	if !b.IsArchived() {
+		return ErrBoardNotArchived
+	}
+	if err := o.repo.SetArchivedAt(ctx, b.ID, nil); err != nil {
+		return err
+	}
+	b.ArchivedAt = nil
+	return nil
+}
@@ -14,36 +14,80 @@ import (
 type BoardType string
 
 const (
-	Private BoardType = "private"
-	Public  BoardType = "public"
+	Private   BoardType = "private"
+	Public    BoardType = "public"
+	Workspace BoardType = "workspace" // visible to the members of the workspace owning the board
+)
+
+// WIPPolicy decides what happens when a task is put in a column that is at its WIP limit.
+type WIPPolicy string
+
+const (
+	WIPPolicyEnforce WIPPolicy = "enforce" // refuse the task
+	WIPPolicyWarn    WIPPolicy = "warn"    // accept the task and warn about the limit
 )
 
 var (
 	ErrWrongType                      = errors.New("wrong type for board")
+	ErrWrongWIPPolicy                 = errors.New("wrong wip policy for board: must be enforce or warn")
 	ErrInvalidName                    = errors.New("invalid board name: must be 1-100 characters long and can only contain alphanumeric characters, spaces, hyphens, underscores, and periods")
 	ErrWrongBoardTime                 = errors.New("wrong board time")
 	ErrBoardNotFound                  = errors.New("board not found")
 	ErrFailedToDeleteBoard            = errors.New("failed to delete board")
 	ErrFailedToFetchTasks             = errors.New("failed to fetch all tasks")
 	ErrFailedToDeleteTaskDependencies = errors.New("failed to delete dependencies")
+	ErrLongDescription                = errors.New("description cannot be longer than 1000 characters")
+	ErrNothingToUpdate                = errors.New("no field given to update")
+	ErrBoardArchived                  = errors.New("board is archived, unarchive it to make changes")
+	ErrBoardNotArchived               = errors.New("board is not archived")
+	ErrNoWorkspace                    = errors.New("only a board of a workspace can have the workspace type")
 )
 
 type Repo interface {
 	Insert(ctx context.Context, board *Board) error
 	GetByID(ctx context.Context, id uuid.UUID) (*Board, error)
 	GetFullByID(ctx context.Context, id uuid.UUID) (*Board, error)
-	GetUserBoards(ctx context.Context, userID uuid.UUID, limit, offset uint) (userBoards []Board, total uint, err error)
-	GetPublicBoards(ctx context.Context, userID uuid.UUID, limit, offset uint) (publicBoards []Board, total uint, err error)
+	GetUserBoards(ctx context.Context, userID uuid.UUID, limit, offset uint, withArchived bool) (userBoards []Board, total uint, err error)
+	GetPublicBoards(ctx context.Context, userID uuid.UUID, limit, offset uint, withArchived bool) (publicBoards []Board, total uint, err error)
+	// GetAll lists every board, archived ones included.
+	GetAll(ctx context.Context, limit, offset uint) (boards []Board, total uint, err error)
 	DeleteByID(ctx context.Context, boardID uuid.UUID) error
+	Update(ctx context.Context, boardID uuid.UUID, fields *UpdateFields) (*Board, error)
+	SetArchivedAt(ctx context.Context, boardID uuid.UUID, archivedAt *time.Time) error
 }
 
 type Board struct {
-	ID        uuid.UUID
-	CreatedAt time.Time
-	Name      string
-	Type      string
-	Users     []user.User
-	Columns   []column.Column
+	ID          uuid.UUID
+	CreatedAt   time.Time
+	Name        string
+	Description string
+	Type        string
+	WIPPolicy   WIPPolicy
+	ArchivedAt  *time.Time // archived boards are read-only
+	WorkspaceID *uuid.UUID // nil for a board outside of any workspace
+	Users       []user.User
+	Columns     []column.Column
+}
+
+// IsPublic tells whether users without a role on the board can see it.
+func (b *Board) IsPublic() bool {
+	return b.Type == string(Public)
+}
+
+func (b *Board) IsArchived() bool {
+	return b.ArchivedAt != nil
+}
+
+// UpdateFields holds the fields of a partial board update, nil fields are left untouched.
+type UpdateFields struct {
+	Name        *string
+	Description *string
+	Type        *string
+	WIPPolicy   *WIPPolicy
+}
+
+func (f *UpdateFields) IsEmpty() bool {
+	return f.Name == nil && f.Description == nil && f.Type == nil && f.WIPPolicy == nil
 }
 
 func ValidateBoardName(name string) error {
@@ -53,3 +97,24 @@ func ValidateBoardName(name string) error {
 	}
 	return nil
 }
+
+func validateDescription(description string) error {
+	if len(description) > 1000 {
+		return ErrLongDescription
+	}
+	return nil
+}
+
+func validateType(boardType string) error {
+	if boardType != string(Private) && boardType != string(Public) && boardType != string(Workspace) {
+		return ErrWrongType
+	}
+	return nil
+}
+
+func validateWIPPolicy(policy WIPPolicy) error {
+	if policy != WIPPolicyEnforce && policy != WIPPolicyWarn {
+		return ErrWrongWIPPolicy
+	}
+	return nil
+}
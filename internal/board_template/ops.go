@@ -0,0 +1,53 @@
+package boardtemplate
+
+import (
+	"context"
+	"server/internal/board"
+	"server/internal/column"
+
+	"github.com/google/uuid"
+)
+
+type Ops struct {
+	repo Repo
+}
+
+func NewOps(repo Repo) *Ops {
+	return &Ops{repo}
+}
+
+func (o *Ops) Create(ctx context.Context, t *Template) error {
+	if err := board.ValidateBoardName(t.Name); err != nil {
+		return ErrInvalidName
+	}
+	if len(t.Columns) == 0 {
+		return ErrNoColumns
+	}
+	for i := range t.Columns {
+		if err := column.ValidateColumnName(t.Columns[i].Name); err != nil {
+			return err
+		}
+		if !t.Columns[i].Kind.IsValid() {
+			return column.ErrInvalidKind
+		}
+		t.Columns[i].Position = uint(i)
+	}
+	for _, task := range t.Tasks {
+		if task.Title == "" || task.ColumnPosition >= uint(len(t.Columns)) {
+			return ErrInvalidTask
+		}
+	}
+	return o.repo.Insert(ctx, t)
+}
+
+func (o *Ops) GetByID(ctx context.Context, id uuid.UUID) (*Template, error) {
+	return o.repo.GetByID(ctx, id)
+}
+
+func (o *Ops) GetUserTemplates(ctx context.Context, userID uuid.UUID) ([]Template, error) {
+	return o.repo.GetUserTemplates(ctx, userID)
+}
+
+func (o *Ops) Delete(ctx context.Context, id uuid.UUID) error {
+	return o.repo.Delete(ctx, id)
+}
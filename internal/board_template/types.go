@@ -0,0 +1,55 @@
+package boardtemplate
+
+import (
+	"context"
+	"errors"
+	"server/internal/board"
+	"server/internal/column"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrTemplateNotFound = errors.New("template not found")
+	ErrInvalidName      = errors.New("invalid template name: must be 1-100 characters long and can only contain alphanumeric characters, spaces, hyphens, underscores, and periods")
+	ErrNoColumns        = errors.New("a template needs at least one column")
+	ErrInvalidTask      = errors.New("a template task must have a title and belong to one of the template columns")
+)
+
+type Repo interface {
+	Insert(ctx context.Context, t *Template) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Template, error)
+	GetUserTemplates(ctx context.Context, userID uuid.UUID) ([]Template, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// Template is a reusable board layout: the board settings, its columns in order
+// and optionally some sample tasks.
+type Template struct {
+	ID              uuid.UUID
+	Name            string
+	Description     string // copied to the boards created from the template
+	Type            string
+	WIPPolicy       board.WIPPolicy
+	CreatedByUserID uuid.UUID
+	CreatedAt       time.Time
+	Columns         []Column
+	Tasks           []Task
+}
+
+type Column struct {
+	Position uint // 0 based, the order of the columns on the board
+	Name     string
+	Kind     column.Kind
+	WIPLimit *uint
+}
+
+// Task is a sample top-level task, placed in the column at ColumnPosition.
+type Task struct {
+	Position       uint // order of the task in its column
+	ColumnPosition uint
+	Title          string
+	Description    string
+	StoryPoint     uint
+}
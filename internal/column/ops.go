@@ -24,6 +24,12 @@ func (o *Ops) Create(ctx context.Context, column *Column) error {
 	if err := ValidateColumnName(column.Name); err != nil {
 		return err
 	}
+	if column.Kind == "" {
+		column.Kind = KindInProgress
+	}
+	if !column.Kind.IsValid() {
+		return ErrInvalidKind
+	}
 	_, err := o.repo.Create(ctx, column)
 	return err
 }
@@ -36,10 +42,16 @@ func (o *Ops) GetMinOrderColumn(ctx context.Context, boardID uuid.UUID) (*Column
 }
 
 func (o *Ops) CreateColumns(ctx context.Context, columns []Column) ([]Column, error) {
-	for _, column := range columns {
-		if err := ValidateColumnName(column.Name); err != nil {
+	for i := range columns {
+		if err := ValidateColumnName(columns[i].Name); err != nil {
 			return nil, err
 		}
+		if columns[i].Kind == "" {
+			columns[i].Kind = KindInProgress
+		}
+		if !columns[i].Kind.IsValid() {
+			return nil, ErrInvalidKind
+		}
 	}
 	return o.repo.CreateBatch(ctx, columns)
 }
@@ -54,6 +66,7 @@ func (o *Ops) GetColumnsByBoardID(ctx context.Context, boardID uuid.UUID) ([]Col
 
 func (o *Ops) SetDoneAsDefault(ctx context.Context, boardID uuid.UUID) (*Column, error) {
 	col := NewColumn(DoneDefaultColumn, boardID, uint(1), time.Now())
+	col.Kind = KindDone
 	err := o.repo.SetDoneAsDefault(ctx, col)
 	if err != nil {
 		return nil, err
@@ -62,9 +75,112 @@ func (o *Ops) SetDoneAsDefault(ctx context.Context, boardID uuid.UUID) (*Column,
 }
 
 func (o *Ops) ReorderColumns(ctx context.Context, boardID uuid.UUID, newOrder map[uuid.UUID]uint) error {
+	if !isPermutation(newOrder) {
+		return ErrInvalidOrder
+	}
 	return o.repo.ReorderColumns(ctx, boardID, newOrder)
 }
 
 func (o *Ops) GetColumns(ctx context.Context, boardID uuid.UUID) ([]Column, error) {
 	return o.repo.GetColumns(ctx, boardID)
 }
+
+func (o *Ops) UpdateKind(ctx context.Context, id uuid.UUID, kind Kind) error {
+	if !kind.IsValid() {
+		return ErrInvalidKind
+	}
+	return o.repo.UpdateKind(ctx, id, kind)
+}
+
+func (o *Ops) Update(ctx context.Context, id uuid.UUID, fields *UpdateFields) (*Column, error) {
+	if fields.IsEmpty() {
+		return nil, ErrNothingToUpdate
+	}
+	if fields.Name != nil {
+		if err := ValidateColumnName(*fields.Name); err != nil {
+			return nil, err
+		}
+	}
+	return o.repo.Update(ctx, id, fields)
+}
+
+func (o *Ops) GetTransitions(ctx context.Context, boardID uuid.UUID) ([]Transition, error) {
+	return o.repo.GetTransitions(ctx, boardID)
+}
+
+// SetTransitions replaces the whole workflow of a board, an empty list lets tasks move freely again.
+func (o *Ops) SetTransitions(ctx context.Context, boardID uuid.UUID, transitions []Transition) ([]Transition, error) {
+	columns, err := o.repo.GetByBoardID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	onBoard := make(map[uuid.UUID]bool, len(columns))
+	for _, c := range columns {
+		onBoard[c.ID] = true
+	}
+
+	seen := make(map[[2]uuid.UUID]bool, len(transitions))
+	for i := range transitions {
+		t := &transitions[i]
+		key := [2]uuid.UUID{t.FromColumnID, t.ToColumnID}
+		if t.FromColumnID == t.ToColumnID || !onBoard[t.FromColumnID] || !onBoard[t.ToColumnID] || seen[key] {
+			return nil, ErrInvalidTransition
+		}
+		seen[key] = true
+		t.BoardID = boardID
+	}
+
+	if err := o.repo.ReplaceTransitions(ctx, boardID, transitions); err != nil {
+		return nil, err
+	}
+	return o.repo.GetTransitions(ctx, boardID)
+}
+
+// CheckTransition tells whether a user with the given role may move a task between two columns of a board.
+func (o *Ops) CheckTransition(ctx context.Context, boardID, fromColumnID, toColumnID uuid.UUID, role string) error {
+	transitions, err := o.repo.GetTransitions(ctx, boardID)
+	if err != nil {
+		return err
+	}
+
+	restricted := false
+	var allowed []uuid.UUID
+	for _, t := range transitions {
+		if t.FromColumnID != fromColumnID {
+			continue
+		}
+		restricted = true
+		if !t.AllowsRole(role) {
+			continue
+		}
+		if t.ToColumnID == toColumnID {
+			return nil
+		}
+		allowed = append(allowed, t.ToColumnID)
+	}
+	if !restricted {
+		return nil
+	}
+
+	columns, err := o.repo.GetByBoardID(ctx, boardID)
+	if err != nil {
+		return err
+	}
+	names := make(map[uuid.UUID]string, len(columns))
+	for _, c := range columns {
+		names[c.ID] = c.Name
+	}
+	notAllowed := &TransitionNotAllowedError{From: names[fromColumnID], To: names[toColumnID]}
+	for _, id := range allowed {
+		notAllowed.Allowed = append(notAllowed.Allowed, names[id])
+	}
+	return notAllowed
+}
+
+// Move ranks a column right after afterID and/or right before beforeID, last when neither is given.
+func (o *Ops) Move(ctx context.Context, id uuid.UUID, afterID, beforeID *uuid.UUID) (*Column, error) {
+	if (afterID != nil && *afterID == id) || (beforeID != nil && *beforeID == id) {
+		return nil, ErrInvalidPosition
+	}
+	return o.repo.Move(ctx, id, afterID, beforeID)
+}
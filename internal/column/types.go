@@ -3,8 +3,10 @@ package column
 import (
 	"context"
 	"errors"
+	"fmt"
 	"regexp"
 	"server/internal/task"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -18,12 +20,38 @@ var (
 	ErrInvalidColumnID      = errors.New("errInvalidColumnID")
 	ErrFailedToUpdateColumn = errors.New("failed to update column")
 	ErrLengthMismatch       = errors.New("length mismatch")
+	ErrInvalidOrder         = errors.New("invalid order: the orders must be 1 to the number of columns, each used once")
+	ErrInvalidKind          = errors.New("invalid column kind: must be one of backlog, in_progress or done")
+	ErrDuplicateName        = errors.New("a column with this name already exists on the board")
+	ErrNothingToUpdate      = errors.New("nothing to update")
+	ErrWIPLimitExceeded     = errors.New("column wip limit exceeded")
+	ErrInvalidTransition    = errors.New("invalid transition: must link two different columns of the board and appear once")
+	ErrTransitionNotAllowed = errors.New("column transition not allowed")
+	ErrInvalidPosition      = errors.New("invalid position: after_id and before_id must be other columns of the board, after_id ranked before before_id")
 )
 
 const (
 	DoneDefaultColumn = "done"
 )
 
+// Kind tells what stage of the workflow a column stands for. Tasks in a KindDone column
+// are finished, whatever the column is called.
+type Kind string
+
+const (
+	KindBacklog    Kind = "backlog"
+	KindInProgress Kind = "in_progress"
+	KindDone       Kind = "done"
+)
+
+func (k Kind) IsValid() bool {
+	switch k {
+	case KindBacklog, KindInProgress, KindDone:
+		return true
+	}
+	return false
+}
+
 type Repo interface {
 	Create(ctx context.Context, column *Column) (*Column, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*Column, error)
@@ -33,6 +61,11 @@ type Repo interface {
 	Delete(ctx context.Context, id uuid.UUID) error
 	GetByBoardID(ctx context.Context, boardID uuid.UUID) ([]Column, error)
 	SetDoneAsDefault(ctx context.Context, column *Column) error
+	UpdateKind(ctx context.Context, id uuid.UUID, kind Kind) error
+	Update(ctx context.Context, id uuid.UUID, fields *UpdateFields) (*Column, error)
+	GetTransitions(ctx context.Context, boardID uuid.UUID) ([]Transition, error)
+	ReplaceTransitions(ctx context.Context, boardID uuid.UUID, transitions []Transition) error
+	Move(ctx context.Context, id uuid.UUID, afterID, beforeID *uuid.UUID) (*Column, error)
 	ReorderColumns(ctx context.Context, boardID uuid.UUID, newOrder map[uuid.UUID]uint) error
 	GetColumns(ctx context.Context, boardID uuid.UUID) ([]Column, error)
 }
@@ -42,6 +75,9 @@ type Column struct {
 	Name      string
 	BoardID   uuid.UUID
 	OrderNum  uint
+	Rank      string // position on the board, see pkg/rank
+	Kind      Kind
+	WIPLimit  *uint // nil when the column has no work in progress limit
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	Tasks     []task.Task
@@ -52,10 +88,95 @@ func NewColumn(name string, boardID uuid.UUID, orderNum uint, createdAt time.Tim
 		Name:      name,
 		BoardID:   boardID,
 		OrderNum:  orderNum,
+		Kind:      KindInProgress,
 		CreatedAt: createdAt,
 	}
 }
 
+func (c *Column) IsDone() bool {
+	return c.Kind == KindDone
+}
+
+// UpdateFields holds the column fields to change; nil fields are left untouched.
+// A WIPLimit of 0 removes the limit.
+type UpdateFields struct {
+	Name     *string
+	WIPLimit *uint
+}
+
+func (f *UpdateFields) IsEmpty() bool {
+	return f.Name == nil && f.WIPLimit == nil
+}
+
+// WIPLimitExceededError is returned, or given as a warning, when a task is put in a full column.
+type WIPLimitExceededError struct {
+	Column string
+	Limit  uint
+	Count  int64 // tasks in the column with the new one
+}
+
+func (e *WIPLimitExceededError) Error() string {
+	return fmt.Sprintf("%v: column %s would hold %d tasks, its limit is %d", ErrWIPLimitExceeded, e.Column, e.Count, e.Limit)
+}
+
+func (e *WIPLimitExceededError) Unwrap() error {
+	return ErrWIPLimitExceeded
+}
+
+// Transition allows tasks to move from one column to another. Once a column has an outgoing
+// transition, tasks may only leave it through its transitions; columns without any are free.
+// Roles limits who may use a transition, an empty list lets anyone who can move the task.
+type Transition struct {
+	ID           uuid.UUID
+	BoardID      uuid.UUID
+	FromColumnID uuid.UUID
+	ToColumnID   uuid.UUID
+	Roles        []string
+}
+
+func (t *Transition) AllowsRole(role string) bool {
+	if len(t.Roles) == 0 {
+		return true
+	}
+	for _, r := range t.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionNotAllowedError lists the columns a task could have been moved to instead.
+type TransitionNotAllowedError struct {
+	From    string
+	To      string
+	Allowed []string
+}
+
+func (e *TransitionNotAllowedError) Error() string {
+	allowed := "none"
+	if len(e.Allowed) > 0 {
+		allowed = strings.Join(e.Allowed, ", ")
+	}
+	return fmt.Sprintf("%v: a task in %s can not be moved to %s, allowed columns: %s", ErrTransitionNotAllowed, e.From, e.To, allowed)
+}
+
+func (e *TransitionNotAllowedError) Unwrap() error {
+	return ErrTransitionNotAllowed
+}
+
+// isPermutation tells whether the orders are exactly 1 to len(newOrder).
+func isPermutation(newOrder map[uuid.UUID]uint) bool {
+	seen := make([]bool, len(newOrder))
+	for _, order := range newOrder {
+		if order < 1 || order > uint(len(newOrder)) || seen[order-1] {
+			return false
+		}
+		seen[order-1] = true
+	}
+	return true
+}
+
 func ValidateColumnName(name string) error {
 	var validBoardName = regexp.MustCompile(`^[a-zA-Z0-9 ._-]{1,100}$`)
 	if !validBoardName.MatchString(name) {
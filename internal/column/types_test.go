@@ -0,0 +1,30 @@
+package column
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPermutation(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+
+	scenarios := []struct {
+		name     string
+		newOrder map[uuid.UUID]uint
+		expected bool
+	}{
+		{name: "Empty", newOrder: map[uuid.UUID]uint{}, expected: true},
+		{name: "Permutation", newOrder: map[uuid.UUID]uint{a: 3, b: 1, c: 2}, expected: true},
+		{name: "ZeroOrder", newOrder: map[uuid.UUID]uint{a: 0, b: 1, c: 2}, expected: false},
+		{name: "OrderOutOfRange", newOrder: map[uuid.UUID]uint{a: 1, b: 2, c: 7}, expected: false},
+		{name: "DuplicateOrder", newOrder: map[uuid.UUID]uint{a: 3, b: 3, c: 1}, expected: false},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			assert.Equal(t, scenario.expected, isPermutation(scenario.newOrder))
+		})
+	}
+}
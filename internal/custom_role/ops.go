@@ -0,0 +1,87 @@
+package customrole
+
+import (
+	"context"
+	"server/internal/board"
+	"server/pkg/rbac"
+
+	"github.com/google/uuid"
+)
+
+type Ops struct {
+	repo Repo
+}
+
+func NewOps(repo Repo) *Ops {
+	return &Ops{repo}
+}
+
+func (o *Ops) Create(ctx context.Context, r *CustomRole) error {
+	if err := validateName(r.Name); err != nil {
+		return err
+	}
+	if err := validatePermissions(r.Permissions); err != nil {
+		return err
+	}
+	return o.repo.Insert(ctx, r)
+}
+
+func (o *Ops) GetByID(ctx context.Context, id uuid.UUID) (*CustomRole, error) {
+	return o.repo.GetByID(ctx, id)
+}
+
+func (o *Ops) GetBoardRoles(ctx context.Context, b *board.Board) ([]CustomRole, error) {
+	return o.repo.GetBoardRoles(ctx, b.ID, b.WorkspaceID)
+}
+
+func (o *Ops) GetWorkspaceRoles(ctx context.Context, workspaceID uuid.UUID) ([]CustomRole, error) {
+	return o.repo.GetWorkspaceRoles(ctx, workspaceID)
+}
+
+func (o *Ops) Update(ctx context.Context, id uuid.UUID, fields *UpdateFields) (*CustomRole, error) {
+	if fields.Name == nil && fields.Permissions == nil {
+		return nil, ErrNothingToUpdate
+	}
+	if fields.Name != nil {
+		if err := validateName(*fields.Name); err != nil {
+			return nil, err
+		}
+	}
+	if fields.Permissions != nil {
+		if err := validatePermissions(fields.Permissions); err != nil {
+			return nil, err
+		}
+	}
+	return o.repo.Update(ctx, id, fields)
+}
+
+// Delete removes a role nobody holds anymore.
+func (o *Ops) Delete(ctx context.Context, r *CustomRole) error {
+	holders, err := o.repo.CountHolders(ctx, r.Role())
+	if err != nil {
+		return err
+	}
+	if holders > 0 {
+		return ErrRoleInUse
+	}
+	return o.repo.Delete(ctx, r.ID)
+}
+
+// ValidateForBoard checks that role is a built-in role or a custom role that can be given on b.
+func (o *Ops) ValidateForBoard(ctx context.Context, role string, b *board.Board) error {
+	if rbac.IsBuiltInRole(role) {
+		return nil
+	}
+	id, ok := ParseRole(role)
+	if !ok {
+		return ErrRoleNotFound
+	}
+	r, err := o.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !r.AppliesTo(b) {
+		return ErrRoleNotFound
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+/*
+A custom role is a named set of permissions defined for one board or for every board of a workspace.
+Members hold it like a built-in role, under the key returned by CustomRole.Role.
+*/
+
+package customrole
+
+import (
+	"context"
+	"errors"
+	"server/internal/board"
+	"server/pkg/rbac"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// keyPrefix tells custom role keys apart from the built-in roles.
+const keyPrefix = "custom:"
+
+var (
+	ErrRoleNotFound      = errors.New("role not found")
+	ErrInvalidName       = errors.New("invalid role name: must be 1-50 characters long")
+	ErrUnknownPermission = errors.New("unknown permission")
+	ErrNoPermissions     = errors.New("a role needs at least one permission")
+	ErrNothingToUpdate   = errors.New("no field given to update")
+	ErrRoleInUse         = errors.New("role is held by members, give them another role first")
+)
+
+type Repo interface {
+	Insert(ctx context.Context, r *CustomRole) error
+	GetByID(ctx context.Context, id uuid.UUID) (*CustomRole, error)
+	// GetBoardRoles returns the roles of the board and the ones of its workspace when it has one.
+	GetBoardRoles(ctx context.Context, boardID uuid.UUID, workspaceID *uuid.UUID) ([]CustomRole, error)
+	GetWorkspaceRoles(ctx context.Context, workspaceID uuid.UUID) ([]CustomRole, error)
+	Update(ctx context.Context, id uuid.UUID, fields *UpdateFields) (*CustomRole, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	// CountHolders counts the members holding role on any board.
+	CountHolders(ctx context.Context, role rbac.Role) (int64, error)
+}
+
+type CustomRole struct {
+	ID          uuid.UUID
+	Name        string
+	BoardID     *uuid.UUID // set for the roles of a board
+	WorkspaceID *uuid.UUID // set for the roles of a workspace
+	Permissions []rbac.Permission
+	CreatedAt   time.Time
+}
+
+// UpdateFields holds the fields of a partial role update, nil fields are left untouched.
+type UpdateFields struct {
+	Name        *string
+	Permissions []rbac.Permission
+}
+
+// Role is the key members hold the custom role with.
+func (r *CustomRole) Role() rbac.Role {
+	return rbac.Role(keyPrefix + r.ID.String())
+}
+
+// AppliesTo tells whether the role can be given on b.
+func (r *CustomRole) AppliesTo(b *board.Board) bool {
+	if r.BoardID != nil {
+		return *r.BoardID == b.ID
+	}
+	return r.WorkspaceID != nil && b.WorkspaceID != nil && *r.WorkspaceID == *b.WorkspaceID
+}
+
+// ParseRole returns the id of the custom role held under role, false for a built-in or malformed role.
+func ParseRole(role string) (uuid.UUID, bool) {
+	if !strings.HasPrefix(role, keyPrefix) {
+		return uuid.Nil, false
+	}
+	id, err := uuid.Parse(strings.TrimPrefix(role, keyPrefix))
+	return id, err == nil
+}
+
+func validateName(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 50 {
+		return ErrInvalidName
+	}
+	return nil
+}
+
+func validatePermissions(permissions []rbac.Permission) error {
+	if len(permissions) == 0 {
+		return ErrNoPermissions
+	}
+	for _, p := range permissions {
+		if !rbac.IsAPermission(string(p)) {
+			return ErrUnknownPermission
+		}
+	}
+	return nil
+}
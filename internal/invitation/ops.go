@@ -0,0 +1,84 @@
+package invitation
+
+import (
+	"context"
+	"server/internal/user"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Ops struct {
+	repo Repo
+}
+
+func NewOps(repo Repo) *Ops {
+	return &Ops{repo}
+}
+
+func (o *Ops) Create(ctx context.Context, inv *Invitation) error {
+	inv.Email = user.LowerCaseEmail(inv.Email)
+	pending, err := o.repo.GetPending(ctx, inv.BoardID, inv.Email)
+	if err != nil {
+		return err
+	}
+	if pending != nil && !pending.IsExpired(time.Now()) {
+		return ErrPendingInvitationExists
+	}
+	if pending != nil {
+		// an expired invitation does not block a new one
+		if err := o.repo.UpdateStatus(ctx, pending.ID, StatusRevoked); err != nil {
+			return err
+		}
+	}
+	inv.Status = StatusPending
+	return o.repo.Insert(ctx, inv)
+}
+
+func (o *Ops) GetByID(ctx context.Context, id uuid.UUID) (*Invitation, error) {
+	return o.repo.GetByID(ctx, id)
+}
+
+func (o *Ops) GetPendingByBoardID(ctx context.Context, boardID uuid.UUID) ([]Invitation, error) {
+	return o.repo.GetPendingByBoardID(ctx, boardID)
+}
+
+func (o *Ops) GetPendingByUserID(ctx context.Context, userID uuid.UUID) ([]Invitation, error) {
+	return o.repo.GetPendingByUserID(ctx, userID)
+}
+
+func (o *Ops) UpdateStatus(ctx context.Context, id uuid.UUID, status Status) error {
+	return o.repo.UpdateStatus(ctx, id, status)
+}
+
+func (o *Ops) ClaimByEmail(ctx context.Context, email string, userID uuid.UUID) error {
+	return o.repo.ClaimByEmail(ctx, user.LowerCaseEmail(email), userID)
+}
+
+func (o *Ops) CreateLink(ctx context.Context, link *Link) error {
+	return o.repo.InsertLink(ctx, link)
+}
+
+func (o *Ops) GetLinkByID(ctx context.Context, id uuid.UUID) (*Link, error) {
+	return o.repo.GetLinkByID(ctx, id)
+}
+
+func (o *Ops) GetLinksByBoardID(ctx context.Context, boardID uuid.UUID) ([]Link, error) {
+	return o.repo.GetLinksByBoardID(ctx, boardID)
+}
+
+func (o *Ops) RevokeLink(ctx context.Context, id uuid.UUID) error {
+	return o.repo.RevokeLink(ctx, id)
+}
+
+// UseLink checks that the link can still be used and counts the use.
+func (o *Ops) UseLink(ctx context.Context, link *Link) error {
+	if err := link.CheckUsable(time.Now()); err != nil {
+		return err
+	}
+	if err := o.repo.UseLink(ctx, link.ID); err != nil {
+		return err
+	}
+	link.Uses++
+	return nil
+}
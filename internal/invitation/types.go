@@ -0,0 +1,112 @@
+/*
+An invitation asks one person, known by email, to join a board with a role. It stays pending until the
+person accepts or declines it, it expires or an inviter revokes it. Invitations to emails without an
+account are claimed when that email registers.
+
+A link lets anyone holding it join a board with a fixed role until it is revoked, expires or reaches
+its usage limit.
+*/
+
+package invitation
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusAccepted Status = "accepted"
+	StatusDeclined Status = "declined"
+	StatusRevoked  Status = "revoked"
+)
+
+var (
+	ErrInvitationNotFound      = errors.New("invitation not found")
+	ErrInvitationNotPending    = errors.New("invitation is not pending anymore")
+	ErrInvitationExpired       = errors.New("invitation has expired")
+	ErrPendingInvitationExists = errors.New("a pending invitation for this email already exists")
+	ErrLinkNotFound            = errors.New("invite link not found")
+	ErrLinkRevoked             = errors.New("invite link has been revoked")
+	ErrLinkExpired             = errors.New("invite link has expired")
+	ErrLinkExhausted           = errors.New("invite link has reached its usage limit")
+)
+
+type Repo interface {
+	Insert(ctx context.Context, inv *Invitation) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Invitation, error)
+	// GetPending returns the open invitation of email to the board, nil when there is none.
+	GetPending(ctx context.Context, boardID uuid.UUID, email string) (*Invitation, error)
+	GetPendingByBoardID(ctx context.Context, boardID uuid.UUID) ([]Invitation, error)
+	GetPendingByUserID(ctx context.Context, userID uuid.UUID) ([]Invitation, error)
+	UpdateStatus(ctx context.Context, id uuid.UUID, status Status) error
+	// ClaimByEmail hands the pending invitations sent to email over to the account userID.
+	ClaimByEmail(ctx context.Context, email string, userID uuid.UUID) error
+
+	InsertLink(ctx context.Context, link *Link) error
+	GetLinkByID(ctx context.Context, id uuid.UUID) (*Link, error)
+	GetLinksByBoardID(ctx context.Context, boardID uuid.UUID) ([]Link, error)
+	RevokeLink(ctx context.Context, id uuid.UUID) error
+	// UseLink counts one more use of the link unless it is used up.
+	UseLink(ctx context.Context, id uuid.UUID) error
+}
+
+type Invitation struct {
+	ID            uuid.UUID
+	BoardID       uuid.UUID
+	BoardName     string
+	Email         string
+	InviteeUserID *uuid.UUID // nil until the email belongs to an account
+	InviterUserID uuid.UUID
+	Role          string
+	Status        Status
+	ExpiresAt     *time.Time // nil for invitations that never expire
+	CreatedAt     time.Time
+}
+
+func (i *Invitation) IsExpired(now time.Time) bool {
+	return i.ExpiresAt != nil && !now.Before(*i.ExpiresAt)
+}
+
+// CheckOpen reports why the invitation can not be answered anymore, nil if it can.
+func (i *Invitation) CheckOpen(now time.Time) error {
+	if i.Status != StatusPending {
+		return ErrInvitationNotPending
+	}
+	if i.IsExpired(now) {
+		return ErrInvitationExpired
+	}
+	return nil
+}
+
+type Link struct {
+	ID              uuid.UUID
+	BoardID         uuid.UUID
+	Role            string
+	CreatedByUserID uuid.UUID
+	MaxUses         uint // 0 for no limit
+	Uses            uint
+	ExpiresAt       *time.Time
+	RevokedAt       *time.Time
+	CreatedAt       time.Time
+	Token           string // signed form of ID handed out to users, never stored
+}
+
+// CheckUsable reports why the link can not be used to join anymore, nil if it can.
+func (l *Link) CheckUsable(now time.Time) error {
+	if l.RevokedAt != nil {
+		return ErrLinkRevoked
+	}
+	if l.ExpiresAt != nil && !now.Before(*l.ExpiresAt) {
+		return ErrLinkExpired
+	}
+	if l.MaxUses > 0 && l.Uses >= l.MaxUses {
+		return ErrLinkExhausted
+	}
+	return nil
+}
@@ -44,3 +44,12 @@ func (o *Ops) NotifBroadCasting(ctx context.Context, notif *Notification, boardI
 	return o.repo.NotifBroadCasting(ctx, notif, boardID, userID, task)
 }
 
+// NotifBoardManagers sends the notification to every maintainer and owner of the board but userID.
+func (o *Ops) NotifBoardManagers(ctx context.Context, notif *Notification, boardID, userID uuid.UUID) error {
+	return o.repo.NotifBoardManagers(ctx, notif, boardID, userID)
+}
+
+// NotifBoardMembers sends the notification to every member of the board but userID.
+func (o *Ops) NotifBoardMembers(ctx context.Context, notif *Notification, boardID, userID uuid.UUID) error {
+	return o.repo.NotifBoardMembers(ctx, notif, boardID, userID)
+}
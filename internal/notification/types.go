@@ -17,6 +17,12 @@ const (
 	TaskMoved       = NotificationType("Move Task")
 	CommentedNotif  = NotificationType("Comment")
 	TaskUpdateNotif = NotificationType("Update Task")
+	RoleChanged     = NotificationType("Change Role")
+	MemberRemoved   = NotificationType("Remove Member")
+	MemberLeft      = NotificationType("Leave Board")
+	OwnershipNotif  = NotificationType("Ownership Transfer")
+	BoardUpdated    = NotificationType("Update Board")
+	BoardArchived   = NotificationType("Archive Board")
 )
 
 var (
@@ -34,6 +40,8 @@ type Repo interface {
 	MarkNotificationAsSeen(ctx context.Context, notificationID uuid.UUID) (*Notification, error)
 	GetNotificationByID(ctx context.Context, notificationID uuid.UUID) (*Notification, error)
 	NotifBroadCasting(ctx context.Context, notif *Notification, boardID, userID uuid.UUID, task *task.Task)error
+	NotifBoardManagers(ctx context.Context, notif *Notification, boardID, userID uuid.UUID) error
+	NotifBoardMembers(ctx context.Context, notif *Notification, boardID, userID uuid.UUID) error
 }
 
 type Notification struct {
@@ -0,0 +1,106 @@
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Ops struct {
+	repo Repo
+}
+
+func NewOps(repo Repo) *Ops {
+	return &Ops{repo}
+}
+
+// Start opens a session of a user that lasts ttl unless it is refreshed.
+func (o *Ops) Start(ctx context.Context, userID uuid.UUID, device, ip string, ttl time.Duration) (*Session, error) {
+	now := time.Now()
+	s := &Session{
+		UserID:     userID,
+		TokenID:    uuid.New(),
+		Device:     device,
+		IP:         ip,
+		ExpiresAt:  now.Add(ttl),
+		LastUsedAt: now,
+	}
+	if err := o.repo.Insert(ctx, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Rotate exchanges the refresh token tokenID of a session for a new one and extends the session by ttl.
+// A token that is not the current one of the session revokes it.
+func (o *Ops) Rotate(ctx context.Context, id, tokenID uuid.UUID, ip string, ttl time.Duration) (*Session, error) {
+	s, err := o.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil || !s.IsActive() {
+		return nil, ErrSessionExpired
+	}
+	if s.TokenID != tokenID {
+		return nil, o.revokeReused(ctx, id)
+	}
+
+	now := time.Now()
+	s.TokenID = uuid.New()
+	s.IP = ip
+	s.ExpiresAt = now.Add(ttl)
+	s.LastUsedAt = now
+	rotated, err := o.repo.Rotate(ctx, s, tokenID)
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		// the token was rotated by a concurrent refresh
+		return nil, o.revokeReused(ctx, id)
+	}
+	return s, nil
+}
+
+// CheckActive tells whether the session id is still on, the access tokens of a session only work while it is.
+func (o *Ops) CheckActive(ctx context.Context, id uuid.UUID) error {
+	s, err := o.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if s == nil || !s.IsActive() {
+		return ErrSessionExpired
+	}
+	return nil
+}
+
+func (o *Ops) revokeReused(ctx context.Context, id uuid.UUID) error {
+	if err := o.repo.Revoke(ctx, id); err != nil {
+		return err
+	}
+	return ErrTokenReused
+}
+
+func (o *Ops) GetActive(ctx context.Context, userID uuid.UUID) ([]Session, error) {
+	return o.repo.GetActive(ctx, userID)
+}
+
+// Revoke ends a session of the user userID.
+func (o *Ops) Revoke(ctx context.Context, userID, id uuid.UUID) error {
+	s, err := o.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if s == nil || s.UserID != userID {
+		return ErrSessionNotFound
+	}
+	if s.RevokedAt != nil {
+		return nil
+	}
+	return o.repo.Revoke(ctx, id)
+}
+
+// RevokeAll ends the sessions of a user, but the one with id except when it is given.
+func (o *Ops) RevokeAll(ctx context.Context, userID uuid.UUID, except *uuid.UUID) error {
+	return o.repo.RevokeAll(ctx, userID, except)
+}
@@ -0,0 +1,49 @@
+/*
+A session is one login of a user on a device. It holds the id of the only refresh token that is valid
+for it; refreshing rotates that id, so a refresh token works once. A refresh token presented again after
+it was rotated means it leaked, the session is revoked then.
+*/
+
+package session
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrSessionNotFound = errors.New("session not found")
+	ErrSessionExpired  = errors.New("the session is revoked or expired, log in again")
+	ErrTokenReused     = errors.New("the refresh token was already used, the session is revoked")
+)
+
+type Repo interface {
+	Insert(ctx context.Context, s *Session) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Session, error)
+	// GetActive returns the sessions of a user that are neither revoked nor expired, the last used first.
+	GetActive(ctx context.Context, userID uuid.UUID) ([]Session, error)
+	// Rotate replaces the token of an active session as long as it still is oldTokenID, it reports whether it did.
+	Rotate(ctx context.Context, s *Session, oldTokenID uuid.UUID) (bool, error)
+	Revoke(ctx context.Context, id uuid.UUID) error
+	// RevokeAll revokes the sessions of a user but except, when given.
+	RevokeAll(ctx context.Context, userID uuid.UUID, except *uuid.UUID) error
+}
+
+type Session struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	TokenID    uuid.UUID // the id of the refresh token that is valid now
+	Device     string    // the user agent of the client
+	IP         string
+	ExpiresAt  time.Time
+	RevokedAt  *time.Time
+	LastUsedAt time.Time
+	CreatedAt  time.Time
+}
+
+func (s *Session) IsActive() bool {
+	return s.RevokedAt == nil && time.Now().Before(s.ExpiresAt)
+}
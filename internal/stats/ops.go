@@ -0,0 +1,15 @@
+package stats
+
+import "context"
+
+type Ops struct {
+	repo Repo
+}
+
+func NewOps(repo Repo) *Ops {
+	return &Ops{repo}
+}
+
+func (o *Ops) Get(ctx context.Context) (*Stats, error) {
+	return o.repo.Get(ctx)
+}
@@ -0,0 +1,18 @@
+package stats
+
+import "context"
+
+type Repo interface {
+	Get(ctx context.Context) (*Stats, error)
+}
+
+// Stats are the system wide counters shown to global admins.
+type Stats struct {
+	Users          int64
+	SuspendedUsers int64
+	Boards         int64
+	ArchivedBoards int64
+	Workspaces     int64
+	Tasks          int64
+	Comments       int64
+}
@@ -2,6 +2,7 @@ package task
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -53,13 +54,163 @@ func (o *Ops) Create(ctx context.Context, task *Task) error {
 			return err
 		}
 	}
+	if err := validateTimeRange(task.StartAt, task.EndAt); err != nil {
+		return err
+	}
 	return o.repo.Insert(ctx, task)
 }
 
+// CreateBatch inserts copied tasks as they are, keeping their ids so subtasks and
+// dependencies can point to each other. Tasks without a rank are put last in their column.
+func (o *Ops) CreateBatch(ctx context.Context, tasks []Task) error {
+	for _, t := range tasks {
+		if err := validateTitleAndDescription(t.Title, t.Description); err != nil {
+			return err
+		}
+	}
+	return o.repo.InsertBatch(ctx, tasks)
+}
+
+// CopyDependencies inserts dependencies between copied tasks, which can not form a cycle.
+func (o *Ops) CopyDependencies(ctx context.Context, dependencies []TaskDependency) error {
+	return o.repo.InsertDependencies(ctx, dependencies)
+}
+
 func (o *Ops) AddDependency(ctx context.Context, t *Task) error {
 	return o.repo.AddDependency(ctx, t)
 }
 
 func (o *Ops) ReorderTasks(ctx context.Context, colID uuid.UUID, newOrder map[uuid.UUID]uint) ([]Task, error) {
+	if !isPermutation(newOrder) {
+		return nil, ErrInvalidOrder
+	}
 	return o.repo.ReorderTasks(ctx, colID, newOrder)
 }
+
+func (o *Ops) Update(ctx context.Context, taskID uuid.UUID, fields *UpdateFields) (*Task, error) {
+	if fields.IsEmpty() {
+		return nil, ErrNothingToUpdate
+	}
+
+	current, err := o.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	title, description := current.Title, current.Description
+	if fields.Title != nil {
+		title = *fields.Title
+	}
+	if fields.Description != nil {
+		description = *fields.Description
+	}
+	if err := validateTitleAndDescription(title, description); err != nil {
+		return nil, err
+	}
+
+	if fields.StoryPoint != nil {
+		if err := validateStoryPoint(*fields.StoryPoint); err != nil {
+			return nil, err
+		}
+	}
+
+	startAt, endAt := current.StartAt, current.EndAt
+	if fields.StartAt != nil {
+		startAt = fields.StartAt
+	}
+	if fields.EndAt != nil {
+		endAt = fields.EndAt
+	}
+	if err := validateTimeRange(startAt, endAt); err != nil {
+		return nil, err
+	}
+
+	return o.repo.Update(ctx, taskID, fields)
+}
+
+func (o *Ops) Delete(ctx context.Context, taskID uuid.UUID) error {
+	return o.repo.Delete(ctx, taskID)
+}
+
+func (o *Ops) GetDeletedTaskByID(ctx context.Context, id uuid.UUID) (*Task, error) {
+	task, err := o.repo.GetDeletedByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil {
+		return nil, ErrTaskNotFound
+	}
+	if task.DeletedAt == nil {
+		return nil, ErrTaskNotDeleted
+	}
+	return task, nil
+}
+
+func (o *Ops) GetDeletedTasks(ctx context.Context, boardID uuid.UUID, page, pageSize uint) ([]Task, uint, error) {
+	limit := pageSize
+	offset := (page - 1) * pageSize
+
+	return o.repo.GetDeletedByBoardID(ctx, boardID, limit, offset)
+}
+
+func (o *Ops) Restore(ctx context.Context, task *Task) error {
+	return o.repo.Restore(ctx, task)
+}
+
+// PurgeDeleted permanently removes the tasks that have been in trash for longer than retention.
+func (o *Ops) PurgeDeleted(ctx context.Context, retention time.Duration) (int64, error) {
+	return o.repo.PurgeDeleted(ctx, time.Now().Add(-retention))
+}
+
+func (o *Ops) GetTaskTree(ctx context.Context, rootID uuid.UUID) (*TreeNode, error) {
+	tasks, err := o.repo.GetSubtree(ctx, rootID)
+	if err != nil {
+		return nil, err
+	}
+	return buildTree(rootID, tasks)
+}
+
+func (o *Ops) RemoveDependency(ctx context.Context, t *Task) error {
+	return o.repo.RemoveDependency(ctx, t)
+}
+
+// GetDependencies returns the task with both DependsOn and DependentBy filled,
+// following the whole chain in each direction when transitive is set.
+func (o *Ops) GetDependencies(ctx context.Context, taskID uuid.UUID, transitive bool) (*Task, error) {
+	t, err := o.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	t.DependsOn, t.DependentBy, err = o.repo.GetDependencies(ctx, taskID, transitive)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (o *Ops) GetBoardDependencyGraph(ctx context.Context, boardID uuid.UUID) (*DependencyGraph, error) {
+	return o.repo.GetBoardDependencyGraph(ctx, boardID)
+}
+
+func (o *Ops) GetBoardSchedule(ctx context.Context, boardID uuid.UUID) (*Schedule, error) {
+	graph, err := o.repo.GetBoardDependencyGraph(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	return ComputeSchedule(graph)
+}
+
+// CountColumnTasks counts the top level tasks of a column, subtasks move along with their parent.
+// Inside a transaction the column stays locked until it ends, so the count holds until the task is written.
+func (o *Ops) CountColumnTasks(ctx context.Context, colID uuid.UUID) (int64, error) {
+	return o.repo.CountColumnTasks(ctx, colID)
+}
+
+// Move ranks a task of colID right after afterID and/or right before beforeID, at the end of the
+// column when neither is given. Only the moved task is written unless the column needs a rebalance.
+func (o *Ops) Move(ctx context.Context, taskID, colID uuid.UUID, afterID, beforeID *uuid.UUID) (*Task, error) {
+	if (afterID != nil && *afterID == taskID) || (beforeID != nil && *beforeID == taskID) {
+		return nil, ErrInvalidPosition
+	}
+	return o.repo.Move(ctx, taskID, colID, afterID, beforeID)
+}
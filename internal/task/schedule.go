@@ -0,0 +1,151 @@
+package task
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// storyPointDuration is how long a task without both dates is assumed to take per story point.
+const storyPointDuration = 24 * time.Hour
+
+// ScheduleItem is the critical path method result of one task. All starts and finishes are
+// offsets from the start of the board, since a task may not have dates at all.
+type ScheduleItem struct {
+	Task           Task
+	Duration       time.Duration
+	EarliestStart  time.Duration
+	EarliestFinish time.Duration
+	LatestStart    time.Duration
+	LatestFinish   time.Duration
+	Slack          time.Duration
+	Critical       bool
+}
+
+// ScheduleConflict is a task planned to end before one of the tasks it depends on.
+type ScheduleConflict struct {
+	TaskID           uuid.UUID
+	DependencyTaskID uuid.UUID
+	EndAt            time.Time
+	DependencyEndAt  time.Time
+}
+
+type Schedule struct {
+	Items         []ScheduleItem // in topological order, dependencies first
+	CriticalPath  []uuid.UUID
+	TotalDuration time.Duration
+	Conflicts     []ScheduleConflict
+}
+
+func taskDuration(t Task) time.Duration {
+	if t.StartAt != nil && t.EndAt != nil && t.EndAt.After(*t.StartAt) {
+		return t.EndAt.Sub(*t.StartAt)
+	}
+	return time.Duration(t.StoryPoint) * storyPointDuration
+}
+
+// ComputeSchedule runs the critical path method over the dependency DAG of a board.
+func ComputeSchedule(g *DependencyGraph) (*Schedule, error) {
+	index := make(map[uuid.UUID]int, len(g.Nodes))
+	for i, n := range g.Nodes {
+		index[n.ID] = i
+	}
+
+	// successors run after the task they depend on
+	successors := make([][]int, len(g.Nodes))
+	predecessors := make([][]int, len(g.Nodes))
+	inDegree := make([]int, len(g.Nodes))
+	for _, e := range g.Edges {
+		dependent, ok1 := index[e.DependentTaskID]
+		dependency, ok2 := index[e.DependencyTaskID]
+		if !ok1 || !ok2 {
+			continue
+		}
+		successors[dependency] = append(successors[dependency], dependent)
+		predecessors[dependent] = append(predecessors[dependent], dependency)
+		inDegree[dependent]++
+	}
+
+	// Kahn's algorithm
+	order := make([]int, 0, len(g.Nodes))
+	queue := make([]int, 0, len(g.Nodes))
+	for i := range g.Nodes {
+		if inDegree[i] == 0 {
+			queue = append(queue, i)
+		}
+	}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		order = append(order, current)
+		for _, next := range successors[current] {
+			inDegree[next]--
+			if inDegree[next] == 0 {
+				queue = append(queue, next)
+			}
+		}
+	}
+	if len(order) != len(g.Nodes) {
+		return nil, ErrCircularDependency
+	}
+
+	items := make([]ScheduleItem, len(g.Nodes))
+	var total time.Duration
+
+	// forward pass
+	for _, i := range order {
+		item := &items[i]
+		item.Task = g.Nodes[i]
+		item.Duration = taskDuration(g.Nodes[i])
+		for _, p := range predecessors[i] {
+			if items[p].EarliestFinish > item.EarliestStart {
+				item.EarliestStart = items[p].EarliestFinish
+			}
+		}
+		item.EarliestFinish = item.EarliestStart + item.Duration
+		if item.EarliestFinish > total {
+			total = item.EarliestFinish
+		}
+	}
+
+	// backward pass
+	for k := len(order) - 1; k >= 0; k-- {
+		i := order[k]
+		item := &items[i]
+		item.LatestFinish = total
+		for _, s := range successors[i] {
+			if items[s].LatestStart < item.LatestFinish {
+				item.LatestFinish = items[s].LatestStart
+			}
+		}
+		item.LatestStart = item.LatestFinish - item.Duration
+		item.Slack = item.LatestStart - item.EarliestStart
+		item.Critical = item.Slack == 0
+	}
+
+	schedule := &Schedule{TotalDuration: total}
+	for _, i := range order {
+		schedule.Items = append(schedule.Items, items[i])
+		if items[i].Critical {
+			schedule.CriticalPath = append(schedule.CriticalPath, items[i].Task.ID)
+		}
+	}
+
+	for _, e := range g.Edges {
+		dependent, ok1 := index[e.DependentTaskID]
+		dependency, ok2 := index[e.DependencyTaskID]
+		if !ok1 || !ok2 {
+			continue
+		}
+		endAt, dependencyEndAt := g.Nodes[dependent].EndAt, g.Nodes[dependency].EndAt
+		if endAt != nil && dependencyEndAt != nil && endAt.Before(*dependencyEndAt) {
+			schedule.Conflicts = append(schedule.Conflicts, ScheduleConflict{
+				TaskID:           e.DependentTaskID,
+				DependencyTaskID: e.DependencyTaskID,
+				EndAt:            *endAt,
+				DependencyEndAt:  *dependencyEndAt,
+			})
+		}
+	}
+	return schedule, nil
+}
@@ -0,0 +1,181 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const day = 24 * time.Hour
+
+type scheduleTask struct {
+	title      string
+	storyPoint uint
+	startAt    *time.Time
+	endAt      *time.Time
+}
+
+type scheduleTiming struct {
+	earliestStart time.Duration
+	latestStart   time.Duration
+	slack         time.Duration
+}
+
+func TestComputeSchedule(t *testing.T) {
+	boardStart := time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC)
+	at := func(days int) *time.Time {
+		tm := boardStart.Add(time.Duration(days) * day)
+		return &tm
+	}
+
+	scenarios := []struct {
+		name          string
+		tasks         []scheduleTask
+		edges         [][2]string // dependent, dependency
+		order         []string
+		timings       map[string]scheduleTiming
+		criticalPath  []string
+		totalDuration time.Duration
+		conflicts     [][2]string // dependent, dependency
+		expectedError error
+	}{
+		{
+			name:          "SingleTask",
+			tasks:         []scheduleTask{{title: "solo", storyPoint: 3}},
+			order:         []string{"solo"},
+			timings:       map[string]scheduleTiming{"solo": {0, 0, 0}},
+			criticalPath:  []string{"solo"},
+			totalDuration: 3 * day,
+		},
+		{
+			name: "ChainAndIndependentTask",
+			tasks: []scheduleTask{
+				{title: "deploy", storyPoint: 1},
+				{title: "build", storyPoint: 2},
+				{title: "docs", storyPoint: 1},
+			},
+			edges: [][2]string{{"deploy", "build"}},
+			order: []string{"build", "docs", "deploy"},
+			timings: map[string]scheduleTiming{
+				"build":  {0, 0, 0},
+				"docs":   {0, 2 * day, 2 * day},
+				"deploy": {2 * day, 2 * day, 0},
+			},
+			criticalPath:  []string{"build", "deploy"},
+			totalDuration: 3 * day,
+		},
+		{
+			name: "Diamond",
+			tasks: []scheduleTask{
+				{title: "design", storyPoint: 2},
+				{title: "backend", storyPoint: 3},
+				{title: "frontend", storyPoint: 1},
+				{title: "release", storyPoint: 2},
+			},
+			edges: [][2]string{
+				{"backend", "design"},
+				{"frontend", "design"},
+				{"release", "backend"},
+				{"release", "frontend"},
+			},
+			order: []string{"design", "backend", "frontend", "release"},
+			timings: map[string]scheduleTiming{
+				"design":   {0, 0, 0},
+				"backend":  {2 * day, 2 * day, 0},
+				"frontend": {2 * day, 4 * day, 2 * day},
+				"release":  {5 * day, 5 * day, 0},
+			},
+			criticalPath:  []string{"design", "backend", "release"},
+			totalDuration: 7 * day,
+		},
+		{
+			name: "DatesOverrideStoryPoints",
+			tasks: []scheduleTask{
+				{title: "review", storyPoint: 1, startAt: at(0), endAt: at(4)},
+				{title: "merge", storyPoint: 5, startAt: at(4), endAt: at(5)},
+			},
+			edges: [][2]string{{"merge", "review"}},
+			order: []string{"review", "merge"},
+			timings: map[string]scheduleTiming{
+				"review": {0, 0, 0},
+				"merge":  {4 * day, 4 * day, 0},
+			},
+			criticalPath:  []string{"review", "merge"},
+			totalDuration: 5 * day,
+		},
+		{
+			name: "EndAtBeforeDependencyEndAt",
+			tasks: []scheduleTask{
+				{title: "migrate", storyPoint: 1, startAt: at(0), endAt: at(3)},
+				{title: "launch", storyPoint: 1, endAt: at(1)},
+			},
+			edges: [][2]string{{"launch", "migrate"}},
+			order: []string{"migrate", "launch"},
+			timings: map[string]scheduleTiming{
+				"migrate": {0, 0, 0},
+				"launch":  {3 * day, 3 * day, 0},
+			},
+			criticalPath:  []string{"migrate", "launch"},
+			totalDuration: 4 * day,
+			conflicts:     [][2]string{{"launch", "migrate"}},
+		},
+		{
+			name: "Cycle",
+			tasks: []scheduleTask{
+				{title: "chicken", storyPoint: 1},
+				{title: "egg", storyPoint: 1},
+			},
+			edges:         [][2]string{{"chicken", "egg"}, {"egg", "chicken"}},
+			expectedError: ErrCircularDependency,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			ids := make(map[string]uuid.UUID)
+			titles := make(map[uuid.UUID]string)
+			graph := &DependencyGraph{}
+			for _, st := range scenario.tasks {
+				id := uuid.New()
+				ids[st.title], titles[id] = id, st.title
+				graph.Nodes = append(graph.Nodes, Task{ID: id, Title: st.title, StoryPoint: st.storyPoint, StartAt: st.startAt, EndAt: st.endAt})
+			}
+			for _, e := range scenario.edges {
+				graph.Edges = append(graph.Edges, TaskDependency{DependentTaskID: ids[e[0]], DependencyTaskID: ids[e[1]]})
+			}
+
+			schedule, err := ComputeSchedule(graph)
+			if scenario.expectedError != nil {
+				assert.ErrorIs(t, err, scenario.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+
+			var order []string
+			for _, item := range schedule.Items {
+				order = append(order, item.Task.Title)
+				expected := scenario.timings[item.Task.Title]
+				assert.Equal(t, expected.earliestStart, item.EarliestStart, "earliest start of %s", item.Task.Title)
+				assert.Equal(t, expected.latestStart, item.LatestStart, "latest start of %s", item.Task.Title)
+				assert.Equal(t, expected.slack, item.Slack, "slack of %s", item.Task.Title)
+				assert.Equal(t, expected.slack == 0, item.Critical, "criticality of %s", item.Task.Title)
+			}
+			assert.Equal(t, scenario.order, order)
+
+			var criticalPath []string
+			for _, id := range schedule.CriticalPath {
+				criticalPath = append(criticalPath, titles[id])
+			}
+			assert.Equal(t, scenario.criticalPath, criticalPath)
+			assert.Equal(t, scenario.totalDuration, schedule.TotalDuration)
+
+			var conflicts [][2]string
+			for _, c := range schedule.Conflicts {
+				conflicts = append(conflicts, [2]string{titles[c.TaskID], titles[c.DependencyTaskID]})
+			}
+			assert.Equal(t, scenario.conflicts, conflicts)
+		})
+	}
+}
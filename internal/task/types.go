@@ -10,6 +10,7 @@ package task
 import (
 	"context"
 	"errors"
+	"fmt"
 	"server/internal/comment"
 	userboardrole "server/internal/user_board_role"
 	"strings"
@@ -37,22 +38,65 @@ var (
 	ErrInvalidTaskID                  = errors.New("errInvalidColumnID")
 	ErrFailedToUpdateTask             = errors.New("failed to update column")
 	ErrLengthMismatch                 = errors.New("length mismatch")
+	ErrInvalidOrder                   = errors.New("invalid order: the orders must be 1 to the number of tasks, each used once")
+	ErrInvalidPosition                = errors.New("invalid position: after_id and before_id must be other tasks of the target column, after_id ranked before before_id")
+	ErrInvalidTimeRange               = errors.New("start_at must be before end_at")
+	ErrNothingToUpdate                = errors.New("no field given to update")
+	ErrFailedToDeleteTask             = errors.New("failed to delete task")
+	ErrFailedToRestoreTask            = errors.New("failed to restore task")
+	ErrTaskNotDeleted                 = errors.New("task is not in trash")
+	ErrParentTaskDeleted              = errors.New("parent task is in trash, restore it first")
+	ErrDependencyNotFound             = errors.New("dependency not found")
+	ErrFailedToRemoveDependency       = errors.New("failed to remove task dependencies")
+	ErrCrossBoardDependency           = errors.New("a task can only depend on tasks of the same board")
 )
 
+// CircularDependencyError carries the tasks forming the loop, starting and ending with the same task.
+type CircularDependencyError struct {
+	Path []uuid.UUID
+}
+
+func (e *CircularDependencyError) Error() string {
+	ids := make([]string, len(e.Path))
+	for i, id := range e.Path {
+		ids[i] = id.String()
+	}
+	return fmt.Sprintf("%s: %s", ErrCircularDependency, strings.Join(ids, " -> "))
+}
+
+func (e *CircularDependencyError) Unwrap() error {
+	return ErrCircularDependency
+}
+
 type Repo interface {
 	Insert(ctx context.Context, task *Task) error
+	InsertBatch(ctx context.Context, tasks []Task) error
+	InsertDependencies(ctx context.Context, dependencies []TaskDependency) error
 	GetByID(ctx context.Context, id uuid.UUID) (*Task, error)
 	GetFullByID(ctx context.Context, id uuid.UUID) (*Task, error)
 	UpdateTaskColumnByID(ctx context.Context, taskID uuid.UUID, colID uuid.UUID) (*Task, error)
 	AddDependency(ctx context.Context, t *Task) error
 	ReorderTasks(ctx context.Context, colID uuid.UUID, newOrder map[uuid.UUID]uint) ([]Task, error)
+	Update(ctx context.Context, taskID uuid.UUID, fields *UpdateFields) (*Task, error)
+	Delete(ctx context.Context, taskID uuid.UUID) error
+	GetDeletedByID(ctx context.Context, id uuid.UUID) (*Task, error)
+	GetDeletedByBoardID(ctx context.Context, boardID uuid.UUID, limit, offset uint) (tasks []Task, total uint, err error)
+	Restore(ctx context.Context, t *Task) error
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
+	GetSubtree(ctx context.Context, rootID uuid.UUID) ([]Task, error)
+	RemoveDependency(ctx context.Context, t *Task) error
+	GetDependencies(ctx context.Context, taskID uuid.UUID, transitive bool) (dependsOn []Task, dependentBy []Task, err error)
+	GetBoardDependencyGraph(ctx context.Context, boardID uuid.UUID) (*DependencyGraph, error)
+	CountColumnTasks(ctx context.Context, colID uuid.UUID) (int64, error)
+	Move(ctx context.Context, taskID, colID uuid.UUID, afterID, beforeID *uuid.UUID) (*Task, error)
 }
 
 type Task struct {
 	ID              uuid.UUID
 	Title           string
 	Description     string
-	Order           uint // in column which order is this
+	Order           uint   // in column which order is this
+	Rank            string // position in the column, see pkg/rank
 	StartAt         *time.Time
 	EndAt           *time.Time
 	StoryPoint      uint
@@ -62,6 +106,8 @@ type Task struct {
 	CreatedByUserID uuid.UUID
 	ColumnID        uuid.UUID
 	BoardID         uuid.UUID
+	DeletedAt       *time.Time
+	IsDone          bool // set when the task's column is loaded and it is a done column
 
 	ParentID   *uuid.UUID //can be null for tasks not sub tasks
 	Parent     *Task
@@ -75,11 +121,136 @@ type Task struct {
 	DependentByTaskIDs []uuid.UUID
 }
 
+// UpdateFields holds the fields of a partial task update, nil fields are left untouched.
+type UpdateFields struct {
+	Title           *string
+	Description     *string
+	StartAt         *time.Time
+	EndAt           *time.Time
+	StoryPoint      *uint
+	AssigneeUserID  *uuid.UUID
+	UserBoardRoleID *uuid.UUID
+}
+
+func (f *UpdateFields) IsEmpty() bool {
+	return f.Title == nil && f.Description == nil && f.StartAt == nil && f.EndAt == nil &&
+		f.StoryPoint == nil && f.AssigneeUserID == nil
+}
+
+// HasDetailChanges reports whether any field other than the assignee is being changed.
+func (f *UpdateFields) HasDetailChanges() bool {
+	return f.Title != nil || f.Description != nil || f.StartAt != nil || f.EndAt != nil || f.StoryPoint != nil
+}
+
+// Progress is the roll-up of a task and all of its subtasks.
+type Progress struct {
+	TotalTasks       uint
+	DoneTasks        uint
+	TotalStoryPoints uint
+	DoneStoryPoints  uint
+	Percentage       float64
+}
+
+type TreeNode struct {
+	Task     Task
+	Children []*TreeNode
+	Progress Progress
+}
+
+// buildTree links the flat list of a subtree under the task with rootID and rolls up progress on every node.
+func buildTree(rootID uuid.UUID, tasks []Task) (*TreeNode, error) {
+	nodes := make(map[uuid.UUID]*TreeNode, len(tasks))
+	for _, t := range tasks {
+		nodes[t.ID] = &TreeNode{Task: t}
+	}
+	root, ok := nodes[rootID]
+	if !ok {
+		return nil, ErrTaskNotFound
+	}
+	for _, t := range tasks {
+		if t.ID == rootID || t.ParentID == nil {
+			continue
+		}
+		if parent, ok := nodes[*t.ParentID]; ok {
+			parent.Children = append(parent.Children, nodes[t.ID])
+		}
+	}
+	root.rollUp()
+	return root, nil
+}
+
+func (n *TreeNode) rollUp() Progress {
+	p := Progress{TotalTasks: 1, TotalStoryPoints: n.Task.StoryPoint}
+	if n.Task.IsDone {
+		p.DoneTasks = 1
+		p.DoneStoryPoints = n.Task.StoryPoint
+	}
+	for _, child := range n.Children {
+		cp := child.rollUp()
+		p.TotalTasks += cp.TotalTasks
+		p.DoneTasks += cp.DoneTasks
+		p.TotalStoryPoints += cp.TotalStoryPoints
+		p.DoneStoryPoints += cp.DoneStoryPoints
+	}
+	p.Percentage = float64(p.DoneTasks) * 100 / float64(p.TotalTasks)
+	n.Progress = p
+	return p
+}
+
 type TaskDependency struct {
 	DependentTaskID  uuid.UUID
 	DependencyTaskID uuid.UUID
 }
 
+// isPermutation tells whether the orders are exactly 1 to len(newOrder).
+func isPermutation(newOrder map[uuid.UUID]uint) bool {
+	seen := make([]bool, len(newOrder))
+	for _, order := range newOrder {
+		if order < 1 || order > uint(len(newOrder)) || seen[order-1] {
+			return false
+		}
+		seen[order-1] = true
+	}
+	return true
+}
+
+// FindDependencyCycle reports the loop that adding "taskID depends on dependsOnID" would close in graph,
+// which maps each task to the tasks it depends on. It returns nil when the new edge is safe.
+func FindDependencyCycle(graph map[uuid.UUID][]uuid.UUID, taskID, dependsOnID uuid.UUID) []uuid.UUID {
+	visited := make(map[uuid.UUID]bool)
+	var path []uuid.UUID
+
+	var dfs func(current uuid.UUID) bool
+	dfs = func(current uuid.UUID) bool {
+		path = append(path, current)
+		if current == taskID {
+			return true
+		}
+		if !visited[current] {
+			visited[current] = true
+			for _, next := range graph[current] {
+				if dfs(next) {
+					return true
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		return false
+	}
+
+	if !dfs(dependsOnID) {
+		return nil
+	}
+	return append([]uuid.UUID{taskID}, path...)
+}
+
+// DependencyGraph is the dependency DAG of a board: every task is a node and
+// every edge points from a dependent task to the task it depends on.
+type DependencyGraph struct {
+	Nodes []Task
+	Edges []TaskDependency
+}
+
 func validateTitleAndDescription(title, description string) error {
 	if title == "" {
 		return ErrEmptyTitle
@@ -113,3 +284,10 @@ func validateStoryPoint(storyPoint uint) error {
 	}
 	return ErrInvalidStoryPoint
 }
+
+func validateTimeRange(startAt, endAt *time.Time) error {
+	if startAt != nil && endAt != nil && endAt.Before(*startAt) {
+		return ErrInvalidTimeRange
+	}
+	return nil
+}
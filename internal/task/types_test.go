@@ -0,0 +1,99 @@
+package task
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindDependencyCycle(t *testing.T) {
+	a, b, c, d := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	scenarios := []struct {
+		name        string
+		graph       map[uuid.UUID][]uuid.UUID
+		taskID      uuid.UUID
+		dependsOnID uuid.UUID
+		expected    []uuid.UUID
+	}{
+		{
+			name:        "EmptyGraph",
+			graph:       map[uuid.UUID][]uuid.UUID{},
+			taskID:      a,
+			dependsOnID: b,
+			expected:    nil,
+		},
+		{
+			name:        "SelfDependency",
+			graph:       map[uuid.UUID][]uuid.UUID{},
+			taskID:      a,
+			dependsOnID: a,
+			expected:    []uuid.UUID{a, a},
+		},
+		{
+			name:        "DirectCycle",
+			graph:       map[uuid.UUID][]uuid.UUID{b: {a}},
+			taskID:      a,
+			dependsOnID: b,
+			expected:    []uuid.UUID{a, b, a},
+		},
+		{
+			name:        "LongerCycle",
+			graph:       map[uuid.UUID][]uuid.UUID{b: {c}, c: {d}, d: {a}},
+			taskID:      a,
+			dependsOnID: b,
+			expected:    []uuid.UUID{a, b, c, d, a},
+		},
+		{
+			name:        "CycleBehindADeadEnd",
+			graph:       map[uuid.UUID][]uuid.UUID{b: {d, c}, c: {a}},
+			taskID:      a,
+			dependsOnID: b,
+			expected:    []uuid.UUID{a, b, c, a},
+		},
+		{
+			name:        "DiamondWithoutCycle",
+			graph:       map[uuid.UUID][]uuid.UUID{a: {b, c}, b: {d}, c: {d}},
+			taskID:      a,
+			dependsOnID: d,
+			expected:    nil,
+		},
+		{
+			name:        "ExistingCycleElsewhere",
+			graph:       map[uuid.UUID][]uuid.UUID{c: {d}, d: {c}},
+			taskID:      a,
+			dependsOnID: c,
+			expected:    nil,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			cycle := FindDependencyCycle(scenario.graph, scenario.taskID, scenario.dependsOnID)
+			assert.Equal(t, scenario.expected, cycle)
+		})
+	}
+}
+
+func TestIsPermutation(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+
+	scenarios := []struct {
+		name     string
+		newOrder map[uuid.UUID]uint
+		expected bool
+	}{
+		{name: "Empty", newOrder: map[uuid.UUID]uint{}, expected: true},
+		{name: "Permutation", newOrder: map[uuid.UUID]uint{a: 2, b: 3, c: 1}, expected: true},
+		{name: "ZeroOrder", newOrder: map[uuid.UUID]uint{a: 0, b: 1, c: 2}, expected: false},
+		{name: "OrderOutOfRange", newOrder: map[uuid.UUID]uint{a: 1, b: 2, c: 4}, expected: false},
+		{name: "DuplicateOrder", newOrder: map[uuid.UUID]uint{a: 1, b: 2, c: 2}, expected: false},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			assert.Equal(t, scenario.expected, isPermutation(scenario.newOrder))
+		})
+	}
+}
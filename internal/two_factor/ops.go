@@ -0,0 +1,179 @@
+package twofactor
+
+import (
+	"context"
+	"server/pkg/fp"
+	"server/pkg/totp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Ops struct {
+	repo Repo
+}
+
+func NewOps(repo Repo) *Ops {
+	return &Ops{repo}
+}
+
+// Enroll gives the user a new secret that stays pending until Enable confirms a code of it, enrolling again
+// before that replaces the secret.
+func (o *Ops) Enroll(ctx context.Context, userID uuid.UUID) (*TwoFactor, error) {
+	tf, err := o.repo.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if tf.IsEnabled() {
+		return nil, ErrAlreadyEnabled
+	}
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	tf = &TwoFactor{UserID: userID, Secret: secret}
+	if err := o.repo.Save(ctx, tf); err != nil {
+		return nil, err
+	}
+	return tf, nil
+}
+
+// Enable turns two-factor authentication on once code matches the pending secret and returns the recovery codes,
+// they can not be shown again.
+func (o *Ops) Enable(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	tf, err := o.repo.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if tf == nil {
+		return nil, ErrNotEnrolled
+	}
+	if tf.IsEnabled() {
+		return nil, ErrAlreadyEnabled
+	}
+	if err := o.checkCode(ctx, tf, code); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	tf.EnabledAt = &now
+	if err := o.repo.Save(ctx, tf); err != nil {
+		return nil, err
+	}
+	return o.RegenerateRecoveryCodes(ctx, userID)
+}
+
+func (o *Ops) IsEnabled(ctx context.Context, userID uuid.UUID) (bool, error) {
+	tf, err := o.repo.Get(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return tf.IsEnabled(), nil
+}
+
+func (o *Ops) GetStatus(ctx context.Context, userID uuid.UUID) (*Status, error) {
+	tf, err := o.repo.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !tf.IsEnabled() {
+		return &Status{}, nil
+	}
+	left, err := o.repo.CountRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &Status{Enabled: true, RecoveryCodesLeft: left}, nil
+}
+
+// Verify accepts a code of the authenticator app or one of the recovery codes of the user, each only once.
+func (o *Ops) Verify(ctx context.Context, userID uuid.UUID, code string) error {
+	tf, err := o.repo.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !tf.IsEnabled() {
+		return ErrNotEnabled
+	}
+	code = strings.TrimSpace(code)
+	if len(strings.ReplaceAll(code, " ", "")) == totp.Digits {
+		return o.checkCode(ctx, tf, code)
+	}
+
+	used, err := o.repo.UseRecoveryCode(ctx, userID, hash(code))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrInvalidCode
+	}
+	return nil
+}
+
+// StartChallenge gives the user a new login challenge, only the latest one of a user can be answered. The wrong
+// codes sent within window of the first challenge still count against it.
+func (o *Ops) StartChallenge(ctx context.Context, userID uuid.UUID, window time.Duration) (uuid.UUID, error) {
+	challengeID := uuid.New()
+	if err := o.repo.StartChallenge(ctx, userID, challengeID, time.Now().Add(-window)); err != nil {
+		return uuid.Nil, err
+	}
+	return challengeID, nil
+}
+
+// VerifyChallenge answers the login challenge challengeID with code like Verify does. A challenge is done once
+// a code was accepted and after maxChallengeAttempts wrong ones in its window.
+func (o *Ops) VerifyChallenge(ctx context.Context, userID, challengeID uuid.UUID, code string) error {
+	allowed, err := o.repo.UseChallengeAttempt(ctx, userID, challengeID, maxChallengeAttempts)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrChallengeUsed
+	}
+	if err := o.Verify(ctx, userID, code); err != nil {
+		return err
+	}
+
+	ended, err := o.repo.EndChallenge(ctx, userID, challengeID)
+	if err != nil {
+		return err
+	}
+	if !ended {
+		return ErrChallengeUsed
+	}
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the user with new ones and returns them.
+func (o *Ops) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	codes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := o.repo.ReplaceRecoveryCodes(ctx, userID, fp.Map(codes, hash)); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func (o *Ops) Disable(ctx context.Context, userID uuid.UUID) error {
+	return o.repo.Delete(ctx, userID)
+}
+
+// checkCode validates an app code and records its step so it can not be replayed.
+func (o *Ops) checkCode(ctx context.Context, tf *TwoFactor, code string) error {
+	step, ok := totp.Validate(tf.Secret, code, time.Now())
+	if !ok || step <= tf.LastStep {
+		return ErrInvalidCode
+	}
+	accepted, err := o.repo.UseStep(ctx, tf.UserID, step)
+	if err != nil {
+		return err
+	}
+	if !accepted {
+		return ErrInvalidCode
+	}
+	tf.LastStep = step
+	return nil
+}
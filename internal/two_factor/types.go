@@ -0,0 +1,105 @@
+/*
+Two-factor authentication asks a user for a code of their authenticator app after their password. A user enrolls
+with a TOTP secret, which is only enabled once they confirmed a first code, and receives single-use recovery codes
+for when the app is lost. Only hashes of the recovery codes are stored. The password step of a login opens a
+challenge that is answered with one of these codes, it works once. Only a few wrong codes are taken in a window,
+however many challenges it opened.
+*/
+
+package twofactor
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const recoveryCodeCount = 10
+
+// maxChallengeAttempts is how many codes can be tried with the login challenges of one window, logging in again
+// does not give more.
+const maxChallengeAttempts = 5
+
+var (
+	ErrNotEnrolled    = errors.New("two-factor authentication is not set up, enroll first")
+	ErrAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrNotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrInvalidCode    = errors.New("the code is invalid or already used")
+	ErrChallengeUsed  = errors.New("the login challenge was already used or got too many wrong codes, log in again")
+)
+
+type Repo interface {
+	// Get returns the two-factor setup of a user, nil when they have none.
+	Get(ctx context.Context, userID uuid.UUID) (*TwoFactor, error)
+	// Save creates or replaces the setup of a user.
+	Save(ctx context.Context, tf *TwoFactor) error
+	// Delete drops the setup of a user along with their recovery codes.
+	Delete(ctx context.Context, userID uuid.UUID) error
+	// UseStep records step as the last one a code was accepted for, it reports false when a code of this step
+	// or a later one was already accepted.
+	UseStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error)
+	// ReplaceRecoveryCodes drops the recovery codes of a user and stores the given hashes instead.
+	ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, hashes []string) error
+	// UseRecoveryCode marks the unused recovery code with the given hash as used, it reports false when there
+	// is none.
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, hash string) (bool, error)
+	CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (uint, error)
+	// StartChallenge makes challengeID the login challenge of a user, replacing the previous one. The attempts
+	// made since the window that started after since carry over, otherwise a new window starts with none.
+	StartChallenge(ctx context.Context, userID, challengeID uuid.UUID, since time.Time) error
+	// UseChallengeAttempt counts an attempt against the login challenge challengeID, it reports false when it
+	// is not the challenge of the user anymore or its max attempts were made.
+	UseChallengeAttempt(ctx context.Context, userID, challengeID uuid.UUID, max uint) (bool, error)
+	// EndChallenge drops the login challenge challengeID along with its window, it reports false when it was
+	// not the challenge of the user anymore.
+	EndChallenge(ctx context.Context, userID, challengeID uuid.UUID) (bool, error)
+}
+
+type TwoFactor struct {
+	UserID    uuid.UUID
+	Secret    string     // base32, as authenticator apps take it
+	EnabledAt *time.Time // nil until the first code is confirmed
+	LastStep  int64      // the time step of the last accepted code, a code is only accepted once
+	CreatedAt time.Time
+}
+
+func (tf *TwoFactor) IsEnabled() bool {
+	return tf != nil && tf.EnabledAt != nil
+}
+
+// Status tells whether two-factor authentication is on for a user and how many recovery codes they have left.
+type Status struct {
+	Enabled           bool
+	RecoveryCodesLeft uint
+}
+
+const recoveryAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+// newRecoveryCodes returns codes like "k7mq2-x9tfa", readable and easy to type.
+func newRecoveryCodes() ([]string, error) {
+	codes := make([]string, recoveryCodeCount)
+	b := make([]byte, 10)
+	for i := range codes {
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		code := make([]byte, len(b))
+		for j, v := range b {
+			code[j] = recoveryAlphabet[int(v)%len(recoveryAlphabet)]
+		}
+		codes[i] = string(code[:5]) + "-" + string(code[5:])
+	}
+	return codes, nil
+}
+
+func hash(code string) string {
+	code = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"server/pkg/utils"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -32,6 +34,7 @@ func (o *Ops) Create(ctx context.Context, user *User) (*User, error) {
 
 	// lowercase email
 	user.Email = LowerCaseEmail(user.Email)
+	user.Role = RoleUser
 	createdUser, err := o.repo.Create(ctx, user)
 	if err != nil {
 		if errors.Is(err, utils.DbErrDuplicateKey) {
@@ -61,6 +64,10 @@ func (o *Ops) GetUserByEmailAndPassword(ctx context.Context, email, password str
 		return nil, ErrInvalidAuthentication
 	}
 
+	if user.IsSuspended() {
+		return nil, ErrUserSuspended
+	}
+
 	return user, nil
 }
 
@@ -77,6 +84,138 @@ func (o *Ops) GetUserByEmail(ctx context.Context, email string) (*User, error) {
 	return user, nil
 }
 
+func (o *Ops) GetUsers(ctx context.Context, page, pageSize uint) ([]User, uint, error) {
+	limit := pageSize
+	offset := (page - 1) * pageSize
+
+	return o.repo.GetAll(ctx, limit, offset)
+}
+
+// MakeAdmin gives the global admin role to the user registered with email.
+func (o *Ops) MakeAdmin(ctx context.Context, email string) (*User, error) {
+	user, err := o.GetUserByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == RoleAdmin {
+		return user, nil
+	}
+	if err := o.repo.SetRole(ctx, user.ID, RoleAdmin); err != nil {
+		return nil, err
+	}
+	user.Role = RoleAdmin
+	return user, nil
+}
+
+func (o *Ops) Suspend(ctx context.Context, id uuid.UUID) error {
+	now := time.Now()
+	return o.repo.SetSuspendedAt(ctx, id, &now)
+}
+
+func (o *Ops) Unsuspend(ctx context.Context, id uuid.UUID) error {
+	return o.repo.SetSuspendedAt(ctx, id, nil)
+}
+
+func (o *Ops) MarkVerified(ctx context.Context, id uuid.UUID) error {
+	now := time.Now()
+	return o.repo.SetEmailVerifiedAt(ctx, id, &now)
+}
+
+// UpdateProfile changes the names and the email of a user, an email can only be taken once. A new email
+// has to be verified again.
+func (o *Ops) UpdateProfile(ctx context.Context, id uuid.UUID, fields *UpdateFields) (*User, error) {
+	fields.Password, fields.AvatarURL = nil, nil
+	if fields.FirstName == nil && fields.LastName == nil && fields.Email == nil {
+		return nil, ErrNothingToUpdate
+	}
+	for _, name := range []*string{fields.FirstName, fields.LastName} {
+		if name != nil && strings.TrimSpace(*name) == "" {
+			return nil, ErrInvalidName
+		}
+	}
+	if fields.Email != nil {
+		email := LowerCaseEmail(*fields.Email)
+		if err := ValidateEmail(email); err != nil {
+			return nil, err
+		}
+		other, err := o.repo.GetByEmail(ctx, email)
+		if err != nil {
+			return nil, err
+		}
+		if other != nil && other.ID != id {
+			return nil, ErrEmailAlreadyExists
+		}
+		fields.Email = &email
+		if other == nil {
+			if err := o.repo.SetEmailVerifiedAt(ctx, id, nil); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return o.update(ctx, id, fields)
+}
+
+// ChangePassword sets a new password once the current one is confirmed.
+func (o *Ops) ChangePassword(ctx context.Context, id uuid.UUID, oldPassword, newPassword string) error {
+	user, err := o.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+	if err := utils.CheckPasswordHash(oldPassword, user.Password); err != nil {
+		return ErrWrongPassword
+	}
+	return o.ResetPassword(ctx, id, newPassword)
+}
+
+// ResetPassword sets a new password without asking for the current one, the caller proves the user's
+// identity some other way.
+func (o *Ops) ResetPassword(ctx context.Context, id uuid.UUID, newPassword string) error {
+	if err := ValidatePasswordWithFeedback(newPassword); err != nil {
+		return err
+	}
+	hashedPass, err := utils.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+	_, err = o.update(ctx, id, &UpdateFields{Password: &hashedPass})
+	return err
+}
+
+func (o *Ops) SetAvatar(ctx context.Context, id uuid.UUID, avatarURL string) (*User, error) {
+	return o.update(ctx, id, &UpdateFields{AvatarURL: &avatarURL})
+}
+
+// CheckPassword confirms the password of a user before a sensitive action.
+func (o *Ops) CheckPassword(ctx context.Context, id uuid.UUID, password string) (*User, error) {
+	user, err := o.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	if err := utils.CheckPasswordHash(password, user.Password); err != nil {
+		return nil, ErrWrongPassword
+	}
+	return user, nil
+}
+
+// Delete anonymizes a user and deletes the account.
+func (o *Ops) Delete(ctx context.Context, id uuid.UUID) error {
+	return o.repo.Anonymize(ctx, id)
+}
+
+func (o *Ops) update(ctx context.Context, id uuid.UUID, fields *UpdateFields) (*User, error) {
+	user, err := o.repo.Update(ctx, id, fields)
+	if errors.Is(err, utils.DbErrDuplicateKey) {
+		return nil, ErrEmailAlreadyExists
+	}
+	return user, err
+}
+
 func validateUserRegistration(user *User) error {
 	err := ValidateEmail(user.Email)
 	if err != nil {
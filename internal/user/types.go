@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"regexp"
 	"strings"
+	"time"
 )
 
 var (
@@ -16,12 +17,25 @@ var (
 	ErrInvalidPassword       = errors.New("invalid password format")
 	ErrEmailAlreadyExists    = errors.New("email already exists")
 	ErrInvalidAuthentication = errors.New("email and password doesn't match")
+	ErrUserSuspended         = errors.New("this account is suspended")
+	ErrWrongPassword         = errors.New("wrong password")
+	ErrInvalidName           = errors.New("first and last name can not be empty")
+	ErrNothingToUpdate       = errors.New("no field given to update")
+	ErrEmailNotVerified      = errors.New("the email of this account is not verified")
 )
 
 type Repo interface {
 	Create(ctx context.Context, user *User) (*User, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*User, error)
 	GetByEmail(ctx context.Context, email string) (*User, error)
+	GetAll(ctx context.Context, limit, offset uint) (users []User, total uint, err error)
+	SetRole(ctx context.Context, id uuid.UUID, role Role) error
+	SetSuspendedAt(ctx context.Context, id uuid.UUID, suspendedAt *time.Time) error
+	SetEmailVerifiedAt(ctx context.Context, id uuid.UUID, verifiedAt *time.Time) error
+	// Update writes the non nil fields of a user and returns it.
+	Update(ctx context.Context, id uuid.UUID, fields *UpdateFields) (*User, error)
+	// Anonymize replaces the personal data of a user with placeholders and deletes the account.
+	Anonymize(ctx context.Context, id uuid.UUID) error
 }
 
 type Role uint8
@@ -43,12 +57,33 @@ const (
 )
 
 type User struct {
-	ID        uuid.UUID
-	FirstName string
-	LastName  string
-	Email     string
-	Password  string
-	Role      Role
+	ID          uuid.UUID
+	FirstName   string
+	LastName    string
+	Email       string
+	Password    string
+	Role        Role
+	SuspendedAt *time.Time // suspended users can not log in
+	AvatarURL   string
+	// EmailVerifiedAt is set once the user followed the link mailed to them, unverified users can not be invited
+	EmailVerifiedAt *time.Time
+}
+
+// UpdateFields holds the fields of a partial profile update, nil fields are left untouched.
+type UpdateFields struct {
+	FirstName *string
+	LastName  *string
+	Email     *string
+	Password  *string
+	AvatarURL *string
+}
+
+func (u *User) IsSuspended() bool {
+	return u.SuspendedAt != nil
+}
+
+func (u *User) IsVerified() bool {
+	return u.EmailVerifiedAt != nil
 }
 
 func (u *User) SetPassword(password string) {
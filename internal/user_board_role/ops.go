@@ -3,6 +3,7 @@ package userboardrole
 import (
 	"context"
 	"server/pkg/rbac"
+	"server/pkg/valuecontext"
 
 	"github.com/google/uuid"
 )
@@ -15,11 +16,19 @@ func NewOps(repo Repo) *Ops {
 	return &Ops{repo}
 }
 
+// GetUserBoardRole returns the role of a user on a board. A request limited to another board by an access
+// token sees no role, as for a non member.
 func (o *Ops) GetUserBoardRole(ctx context.Context, userID, boardID uuid.UUID) (rbac.Role, error) {
+	if valuecontext.GetBoardScope(ctx).Excludes(userID, boardID) {
+		return "", ErrUserRoleNotFound
+	}
 	return o.repo.GetUserBoardRole(ctx, userID, boardID)
 }
 
 func (o *Ops) GetUserBoardRoleObj(ctx context.Context, userID, boardID uuid.UUID) (*UserBoardRole, error) {
+	if valuecontext.GetBoardScope(ctx).Excludes(userID, boardID) {
+		return nil, ErrUserRoleNotFound
+	}
 	return o.repo.GetUserBoardRoleObj(ctx, userID, boardID)
 }
 
@@ -27,10 +36,49 @@ func (o *Ops) SetUserBoardRole(ctx context.Context, ub *UserBoardRole) error {
 	return o.repo.SetUserBoardRole(ctx, ub)
 }
 
-func (o *Ops) RemoveUserBoardRole(ctx context.Context, userID, boardID string) error {
-	return o.repo.RemoveUserBoardRole(ctx, userID, boardID)
+func (o *Ops) RemoveUserBoardRole(ctx context.Context, userID, boardID uuid.UUID, commentsTo *uuid.UUID) error {
+	return o.repo.RemoveUserBoardRole(ctx, userID, boardID, commentsTo)
+}
+
+func (o *Ops) GetBoardMembers(ctx context.Context, boardID uuid.UUID) ([]UserBoardRole, error) {
+	return o.repo.GetBoardMembers(ctx, boardID)
+}
+
+func (o *Ops) UpdateRole(ctx context.Context, userID, boardID uuid.UUID, role rbac.Role) error {
+	if !rbac.IsAPossibleRole(ctx, string(role)) {
+		return ErrWrongRole
+	}
+	return o.repo.UpdateRole(ctx, userID, boardID, role)
+}
+
+func (o *Ops) UpdateInheritedRole(ctx context.Context, userID, boardID uuid.UUID, role rbac.Role) error {
+	return o.repo.UpdateInheritedRole(ctx, userID, boardID, role)
 }
 
 func (o *Ops) GetUserIDByUserBoardRoleID(ctx context.Context, userBoardRoleID uuid.UUID) (*uuid.UUID, error) {
 	return o.repo.GetUserIDByUserBoardRoleID(ctx, userBoardRoleID)
 }
+
+func (o *Ops) CountOwners(ctx context.Context, boardID uuid.UUID) (int64, error) {
+	return o.repo.CountOwners(ctx, boardID)
+}
+
+func (o *Ops) GetSoleOwnedBoardIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	return o.repo.GetSoleOwnedBoardIDs(ctx, userID)
+}
+
+func (o *Ops) GetUserBoardIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	return o.repo.GetUserBoardIDs(ctx, userID)
+}
+
+func (o *Ops) SaveOwnershipTransfer(ctx context.Context, t *OwnershipTransfer) error {
+	return o.repo.SaveOwnershipTransfer(ctx, t)
+}
+
+func (o *Ops) GetOwnershipTransfer(ctx context.Context, boardID uuid.UUID) (*OwnershipTransfer, error) {
+	return o.repo.GetOwnershipTransfer(ctx, boardID)
+}
+
+func (o *Ops) DeleteOwnershipTransfer(ctx context.Context, boardID uuid.UUID) error {
+	return o.repo.DeleteOwnershipTransfer(ctx, boardID)
+}
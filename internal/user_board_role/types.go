@@ -5,6 +5,7 @@ import (
 	"errors"
 	"server/internal/user"
 	"server/pkg/rbac"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -12,14 +13,29 @@ import (
 var (
 	ErrUserRoleNotFound = errors.New("user role not found")
 	ErrWrongRole        = errors.New("wrong role")
+	ErrTransferNotFound = errors.New("there is no pending ownership transfer on this board")
 )
 
 type Repo interface {
 	GetUserBoardRole(ctx context.Context, userID, boardID uuid.UUID) (rbac.Role, error)
 	GetUserBoardRoleObj(ctx context.Context, userID, boardID uuid.UUID) (*UserBoardRole, error)
 	SetUserBoardRole(ctx context.Context, ub *UserBoardRole) error
-	RemoveUserBoardRole(ctx context.Context, userID, boardID string) error
+	// RemoveUserBoardRole takes a member off a board and unassigns their tasks. Their comments move
+	// to commentsTo when given and otherwise stay with the removed membership.
+	RemoveUserBoardRole(ctx context.Context, userID, boardID uuid.UUID, commentsTo *uuid.UUID) error
+	GetBoardMembers(ctx context.Context, boardID uuid.UUID) ([]UserBoardRole, error)
+	// UpdateRole changes the role of a member, which makes an inherited role explicit.
+	UpdateRole(ctx context.Context, userID, boardID uuid.UUID, role rbac.Role) error
+	UpdateInheritedRole(ctx context.Context, userID, boardID uuid.UUID, role rbac.Role) error
 	GetUserIDByUserBoardRoleID(ctx context.Context, userBoardRoleID uuid.UUID) (*uuid.UUID, error)
+	CountOwners(ctx context.Context, boardID uuid.UUID) (int64, error)
+	// GetSoleOwnedBoardIDs returns the boards on which userID is the only owner.
+	GetSoleOwnedBoardIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	GetUserBoardIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	// SaveOwnershipTransfer stores t as the pending transfer of its board, replacing any earlier one.
+	SaveOwnershipTransfer(ctx context.Context, t *OwnershipTransfer) error
+	GetOwnershipTransfer(ctx context.Context, boardID uuid.UUID) (*OwnershipTransfer, error)
+	DeleteOwnershipTransfer(ctx context.Context, boardID uuid.UUID) error
 }
 
 type UserBoardRole struct {
@@ -28,4 +44,16 @@ type UserBoardRole struct {
 	User    *user.User
 	BoardID uuid.UUID
 	Role    string
+	// Inherited roles come from the workspace role of the user and follow it until they are changed on the board
+	Inherited bool
+}
+
+// OwnershipTransfer is an owner's pending offer to make another member an owner of the board.
+type OwnershipTransfer struct {
+	ID            uuid.UUID
+	BoardID       uuid.UUID
+	FromUserID    uuid.UUID
+	ToUserID      uuid.UUID
+	KeepOwnership bool // the proposer stays a co-owner instead of becoming a maintainer
+	CreatedAt     time.Time
 }
@@ -0,0 +1,46 @@
+package verification
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Ops struct {
+	repo Repo
+}
+
+func NewOps(repo Repo) *Ops {
+	return &Ops{repo}
+}
+
+// Issue creates a token for purpose valid during ttl and returns the secret to mail, the earlier
+// unused tokens of the user for the same purpose stop working.
+func (o *Ops) Issue(ctx context.Context, userID uuid.UUID, purpose Purpose, ttl time.Duration) (string, error) {
+	if err := o.repo.DeleteUnused(ctx, userID, purpose); err != nil {
+		return "", err
+	}
+	secret, err := newSecret()
+	if err != nil {
+		return "", err
+	}
+	t := &Token{
+		UserID:    userID,
+		Purpose:   purpose,
+		Hash:      hash(secret),
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := o.repo.Insert(ctx, t); err != nil {
+		return "", err
+	}
+	return secret, nil
+}
+
+// Consume uses the token behind secret, which only works once.
+func (o *Ops) Consume(ctx context.Context, secret string, purpose Purpose) (*Token, error) {
+	if secret == "" {
+		return nil, ErrInvalidToken
+	}
+	return o.repo.Use(ctx, hash(secret), purpose)
+}
@@ -0,0 +1,58 @@
+/*
+Verification tokens are single-use, time-limited secrets mailed to a user to prove they own their email,
+e.g. to verify it or to reset their password. Only a hash of a token is stored.
+*/
+
+package verification
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Purpose string
+
+const (
+	PurposeVerifyEmail   Purpose = "verify_email"
+	PurposeResetPassword Purpose = "reset_password"
+)
+
+var ErrInvalidToken = errors.New("the token is invalid, expired or already used")
+
+type Repo interface {
+	Insert(ctx context.Context, t *Token) error
+	// Use marks the unused and unexpired token with the given hash and purpose as used and returns it.
+	Use(ctx context.Context, hash string, purpose Purpose) (*Token, error)
+	// DeleteUnused drops the tokens of a user for purpose that were not used yet.
+	DeleteUnused(ctx context.Context, userID uuid.UUID, purpose Purpose) error
+}
+
+type Token struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Purpose   Purpose
+	Hash      string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,147 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"server/internal/board"
+	"server/pkg/valuecontext"
+
+	"github.com/google/uuid"
+)
+
+type Ops struct {
+	repo Repo
+}
+
+func NewOps(repo Repo) *Ops {
+	return &Ops{repo}
+}
+
+// Create stores the workspace and makes its creator the first admin.
+func (o *Ops) Create(ctx context.Context, w *Workspace) error {
+	if err := validateName(w.Name); err != nil {
+		return err
+	}
+	if len(w.Description) > 1000 {
+		return ErrLongDescription
+	}
+	if err := o.repo.Insert(ctx, w); err != nil {
+		return err
+	}
+	w.MyRole = RoleAdmin
+	return o.repo.AddMember(ctx, &Member{WorkspaceID: w.ID, UserID: w.CreatedByUserID, Role: RoleAdmin})
+}
+
+func (o *Ops) GetByID(ctx context.Context, id uuid.UUID) (*Workspace, error) {
+	return o.repo.GetByID(ctx, id)
+}
+
+func (o *Ops) GetUserWorkspaces(ctx context.Context, userID uuid.UUID) ([]Workspace, error) {
+	return o.repo.GetUserWorkspaces(ctx, userID)
+}
+
+func (o *Ops) AddMember(ctx context.Context, m *Member) error {
+	if !m.Role.IsValid() {
+		return ErrInvalidRole
+	}
+	existing, err := o.repo.GetMember(ctx, m.WorkspaceID, m.UserID)
+	if err != nil && !errors.Is(err, ErrMemberNotFound) {
+		return err
+	}
+	if existing != nil {
+		return ErrAlreadyMember
+	}
+	return o.repo.AddMember(ctx, m)
+}
+
+// GetMember returns the membership of a user in a workspace. A request limited to a board by an access token
+// sees no workspace membership of its user.
+func (o *Ops) GetMember(ctx context.Context, workspaceID, userID uuid.UUID) (*Member, error) {
+	if valuecontext.GetBoardScope(ctx).Limits(userID) {
+		return nil, ErrMemberNotFound
+	}
+	return o.repo.GetMember(ctx, workspaceID, userID)
+}
+
+func (o *Ops) GetMembers(ctx context.Context, workspaceID uuid.UUID) ([]Member, error) {
+	return o.repo.GetMembers(ctx, workspaceID)
+}
+
+// UpdateMemberRole changes the role of a member, the last admin can not be demoted.
+func (o *Ops) UpdateMemberRole(ctx context.Context, m *Member, role Role) error {
+	if !role.IsValid() {
+		return ErrInvalidRole
+	}
+	if m.Role == RoleAdmin && role != RoleAdmin {
+		if err := o.checkNotLastAdmin(ctx, m.WorkspaceID); err != nil {
+			return err
+		}
+	}
+	if err := o.repo.UpdateMemberRole(ctx, m.WorkspaceID, m.UserID, role); err != nil {
+		return err
+	}
+	m.Role = role
+	return nil
+}
+
+// RemoveMember takes a member out of the workspace, the last admin can not be removed.
+func (o *Ops) RemoveMember(ctx context.Context, m *Member) error {
+	if m.Role == RoleAdmin {
+		if err := o.checkNotLastAdmin(ctx, m.WorkspaceID); err != nil {
+			return err
+		}
+	}
+	return o.repo.RemoveMember(ctx, m.WorkspaceID, m.UserID)
+}
+
+// RemoveDeletedMember takes the member of a deleted account out of the workspace. When they were its last
+// admin the member who joined first, members before guests, becomes an admin and is returned.
+func (o *Ops) RemoveDeletedMember(ctx context.Context, m *Member) (*Member, error) {
+	var successor *Member
+	if m.Role == RoleAdmin {
+		if err := o.checkNotLastAdmin(ctx, m.WorkspaceID); errors.Is(err, ErrLastAdmin) {
+			members, err := o.repo.GetMembers(ctx, m.WorkspaceID)
+			if err != nil {
+				return nil, err
+			}
+			for i := range members {
+				candidate := &members[i]
+				if candidate.UserID == m.UserID {
+					continue
+				}
+				if successor == nil || candidate.Role == RoleMember && successor.Role == RoleGuest ||
+					candidate.Role == successor.Role && candidate.JoinedAt.Before(successor.JoinedAt) {
+					successor = candidate
+				}
+			}
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	if successor != nil {
+		if err := o.repo.UpdateMemberRole(ctx, successor.WorkspaceID, successor.UserID, RoleAdmin); err != nil {
+			return nil, err
+		}
+		successor.Role = RoleAdmin
+	}
+	return successor, o.repo.RemoveMember(ctx, m.WorkspaceID, m.UserID)
+}
+
+func (o *Ops) GetBoards(ctx context.Context, workspaceID uuid.UUID) ([]board.Board, error) {
+	return o.repo.GetBoards(ctx, workspaceID)
+}
+
+func (o *Ops) GetVisibleBoards(ctx context.Context, workspaceID, userID uuid.UUID) ([]board.Board, error) {
+	return o.repo.GetVisibleBoards(ctx, workspaceID, userID)
+}
+
+func (o *Ops) checkNotLastAdmin(ctx context.Context, workspaceID uuid.UUID) error {
+	admins, err := o.repo.CountAdmins(ctx, workspaceID)
+	if err != nil {
+		return err
+	}
+	if admins <= 1 {
+		return ErrLastAdmin
+	}
+	return nil
+}
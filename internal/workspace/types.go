@@ -0,0 +1,96 @@
+/*
+A workspace groups the boards and the people of an organization. Its members have a workspace role
+which gives them a default role on the boards of the workspace. These roles are stored as inherited
+user board roles that follow the workspace role until the role is changed on the board itself.
+*/
+
+package workspace
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"server/internal/board"
+	"server/internal/user"
+	"server/pkg/rbac"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Role string
+
+const (
+	RoleAdmin  Role = "admin"  // manages the workspace and owns all of its boards
+	RoleMember Role = "member" // edits the workspace and public boards of the workspace
+	RoleGuest  Role = "guest"  // sees only the boards they are invited to
+)
+
+var (
+	ErrWorkspaceNotFound = errors.New("workspace not found")
+	ErrInvalidName       = errors.New("invalid workspace name: must be 1-100 characters long and can only contain alphanumeric characters, spaces, hyphens, underscores, and periods")
+	ErrLongDescription   = errors.New("description cannot be longer than 1000 characters")
+	ErrInvalidRole       = errors.New("invalid workspace role: must be admin, member or guest")
+	ErrMemberNotFound    = errors.New("user is not a member of this workspace")
+	ErrAlreadyMember     = errors.New("user already is a member of this workspace")
+	ErrLastAdmin         = errors.New("a workspace must keep at least one admin")
+)
+
+type Repo interface {
+	Insert(ctx context.Context, w *Workspace) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Workspace, error)
+	// GetUserWorkspaces returns the workspaces userID is a member of, with their role in MyRole.
+	GetUserWorkspaces(ctx context.Context, userID uuid.UUID) ([]Workspace, error)
+	AddMember(ctx context.Context, m *Member) error
+	GetMember(ctx context.Context, workspaceID, userID uuid.UUID) (*Member, error)
+	GetMembers(ctx context.Context, workspaceID uuid.UUID) ([]Member, error)
+	UpdateMemberRole(ctx context.Context, workspaceID, userID uuid.UUID, role Role) error
+	RemoveMember(ctx context.Context, workspaceID, userID uuid.UUID) error
+	CountAdmins(ctx context.Context, workspaceID uuid.UUID) (int64, error)
+	// GetBoards returns every board of the workspace, archived ones included.
+	GetBoards(ctx context.Context, workspaceID uuid.UUID) ([]board.Board, error)
+	// GetVisibleBoards returns the boards of the workspace userID has a role on and the public ones.
+	GetVisibleBoards(ctx context.Context, workspaceID, userID uuid.UUID) ([]board.Board, error)
+}
+
+type Workspace struct {
+	ID              uuid.UUID
+	Name            string
+	Description     string
+	CreatedByUserID uuid.UUID
+	CreatedAt       time.Time
+	MyRole          Role
+}
+
+type Member struct {
+	WorkspaceID uuid.UUID
+	UserID      uuid.UUID
+	User        *user.User
+	Role        Role
+	JoinedAt    time.Time
+}
+
+func (r Role) IsValid() bool {
+	return r == RoleAdmin || r == RoleMember || r == RoleGuest
+}
+
+// DefaultBoardRole is the role a workspace role gives on a board of the workspace, false when it gives none.
+func DefaultBoardRole(role Role, boardType string) (rbac.Role, bool) {
+	switch role {
+	case RoleAdmin:
+		return rbac.RoleOwner, true
+	case RoleMember:
+		if boardType == string(board.Workspace) || boardType == string(board.Public) {
+			return rbac.RoleEditor, true
+		}
+	}
+	return "", false
+}
+
+func validateName(name string) error {
+	var validName = regexp.MustCompile(`^[a-zA-Z0-9 ._-]{1,100}$`)
+	if !validName.MatchString(name) {
+		return ErrInvalidName
+	}
+	return nil
+}
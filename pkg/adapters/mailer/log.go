@@ -0,0 +1,50 @@
+package mailer
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"server/pkg/mailer"
+	"sync"
+	"time"
+)
+
+type logMailer struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewLogMailer does not send anything. The messages are appended as JSON lines to the file at path,
+// or written to the standard logger when path is empty; meant for development and tests.
+func NewLogMailer(path string) mailer.Mailer {
+	return &logMailer{path: path}
+}
+
+type loggedMessage struct {
+	To      string    `json:"to"`
+	Subject string    `json:"subject"`
+	Body    string    `json:"body"`
+	SentAt  time.Time `json:"sent_at"`
+}
+
+func (m *logMailer) Send(ctx context.Context, msg mailer.Message) error {
+	line, err := json.Marshal(loggedMessage{To: msg.To, Subject: msg.Subject, Body: msg.Body, SentAt: time.Now()})
+	if err != nil {
+		return err
+	}
+	if m.path == "" {
+		log.Printf("mail: %s", line)
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	f, err := os.OpenFile(m.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
@@ -0,0 +1,48 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"net/smtp"
+	"server/pkg/mailer"
+	"strings"
+)
+
+type smtpMailer struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPMailer sends the messages through an SMTP server. Without a user no authentication is done,
+// which is what local stand-ins like MailHog expect.
+func NewSMTPMailer(host string, port int, user, pass, from string) mailer.Mailer {
+	m := &smtpMailer{
+		addr: fmt.Sprintf("%s:%d", host, port),
+		from: from,
+	}
+	if user != "" {
+		m.auth = smtp.PlainAuth("", user, pass, host)
+	}
+	return m
+}
+
+func (m *smtpMailer) Send(ctx context.Context, msg mailer.Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", m.from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	// the envelope takes the bare address of a "Name <address>" sender
+	sender := m.from
+	if addr, err := mail.ParseAddress(m.from); err == nil {
+		sender = addr.Address
+	}
+	return smtp.SendMail(m.addr, m.auth, sender, []string{msg.To}, []byte(b.String()))
+}
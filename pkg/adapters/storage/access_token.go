@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"context"
+	accesstoken "server/internal/access_token"
+	"server/pkg/adapters/storage/entities"
+	"server/pkg/adapters/storage/mappers"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type accessTokenRepo struct {
+	db *gorm.DB
+}
+
+func NewAccessTokenRepo(db *gorm.DB) accesstoken.Repo {
+	return &accessTokenRepo{db}
+}
+
+func (r *accessTokenRepo) Insert(ctx context.Context, t *accesstoken.AccessToken) error {
+	e := mappers.AccessTokenDomainToEntity(t)
+	if err := r.db.WithContext(ctx).Create(e).Error; err != nil {
+		return err
+	}
+	t.ID = e.ID
+	t.CreatedAt = e.CreatedAt
+	return nil
+}
+
+func (r *accessTokenRepo) GetByID(ctx context.Context, id uuid.UUID) (*accesstoken.AccessToken, error) {
+	return r.getOne(ctx, "id = ?", id)
+}
+
+func (r *accessTokenRepo) GetByHash(ctx context.Context, hash string) (*accesstoken.AccessToken, error) {
+	return r.getOne(ctx, "hash = ?", hash)
+}
+
+func (r *accessTokenRepo) getOne(ctx context.Context, query string, arg interface{}) (*accesstoken.AccessToken, error) {
+	var tokens []entities.AccessToken
+	if err := r.db.WithContext(ctx).Where(query, arg).Limit(1).Find(&tokens).Error; err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+	return mappers.AccessTokenEntityToDomain(&tokens[0]), nil
+}
+
+func (r *accessTokenRepo) GetByUserID(ctx context.Context, userID uuid.UUID) ([]accesstoken.AccessToken, error) {
+	var tokens []entities.AccessToken
+	if err := r.db.WithContext(ctx).Where("user_id = ? AND revoked_at IS NULL", userID).
+		Order("created_at DESC").Find(&tokens).Error; err != nil {
+		return nil, err
+	}
+	return mappers.BatchAccessTokenEntityToDomain(tokens), nil
+}
+
+func (r *accessTokenRepo) Revoke(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&entities.AccessToken{}).Where("id = ?", id).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *accessTokenRepo) SetLastUsedAt(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&entities.AccessToken{}).Where("id = ?", id).
+		Update("last_used_at", at).Error
+}
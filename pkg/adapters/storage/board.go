@@ -6,6 +6,7 @@ import (
 	"server/internal/board"
 	"server/pkg/adapters/storage/entities"
 	"server/pkg/adapters/storage/mappers"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -35,13 +36,25 @@ func (r *boardRepo) GetByID(ctx context.Context, id uuid.UUID) (*board.Board, er
 	return &board, nil
 }
 
-func (r *boardRepo) GetUserBoards(ctx context.Context, userID uuid.UUID, limit, offset uint) (userBoards []board.Board, total uint, err error) {
+// listedBoards leaves deleted boards out of the raw board queries, and archived ones unless asked for.
+func listedBoards(withArchived bool) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Where("boards.deleted_at IS NULL")
+		if !withArchived {
+			db = db.Where("boards.archived_at IS NULL")
+		}
+		return db
+	}
+}
+
+func (r *boardRepo) GetUserBoards(ctx context.Context, userID uuid.UUID, limit, offset uint, withArchived bool) (userBoards []board.Board, total uint, err error) {
 	var int64Total int64
 	var userBoardsEntities []entities.Board
 	// Query to get the count of user boards
 	userBoardsCountQuery := r.db.Table("boards").
 		Joins("JOIN user_board_roles ubr ON ubr.board_id = boards.id").
-		Where("ubr.user_id = ?", userID).
+		Where("ubr.user_id = ? AND ubr.deleted_at IS NULL", userID).
+		Scopes(listedBoards(withArchived)).
 		Count(&int64Total)
 
 	if userBoardsCountQuery.Error != nil {
@@ -50,9 +63,10 @@ func (r *boardRepo) GetUserBoards(ctx context.Context, userID uuid.UUID, limit,
 
 	// Query to get the boards where the user has a role
 	userBoardsQuery := r.db.Table("boards").
-		Select("boards.id, boards.name, boards.type, boards.created_at").
+		Select("boards.id, boards.name, boards.description, boards.type, boards.wip_policy, boards.archived_at, boards.workspace_id, boards.created_at").
 		Joins("JOIN user_board_roles ubr ON ubr.board_id = boards.id").
-		Where("ubr.user_id = ?", userID).
+		Where("ubr.user_id = ? AND ubr.deleted_at IS NULL", userID).
+		Scopes(listedBoards(withArchived)).
 		Order("boards.created_at DESC")
 
 	if offset > 0 {
@@ -75,14 +89,15 @@ func (r *boardRepo) GetUserBoards(ctx context.Context, userID uuid.UUID, limit,
 	return userBoards, total, nil
 }
 
-func (r *boardRepo) GetPublicBoards(ctx context.Context, userID uuid.UUID, limit, offset uint) (publicBoards []board.Board, total uint, err error) {
+func (r *boardRepo) GetPublicBoards(ctx context.Context, userID uuid.UUID, limit, offset uint, withArchived bool) (publicBoards []board.Board, total uint, err error) {
 	var publicBoardsEntities []entities.Board
 	var int64Total int64
 	// Query to get the count of user boards
 	publicBoardsCountQuery := r.db.Table("boards").
-		Select("boards.id, boards.name, boards.type, boards.created_at").
+		Select("boards.id, boards.name, boards.description, boards.type, boards.wip_policy, boards.archived_at, boards.workspace_id, boards.created_at").
 		Where("boards.type = ? AND boards.id NOT IN (?)", "public",
-			r.db.Table("user_board_roles").Select("board_id").Where("user_id = ?", userID)).
+			r.db.Table("user_board_roles").Select("board_id").Where("user_id = ? AND deleted_at IS NULL", userID)).
+		Scopes(listedBoards(withArchived)).
 		Count(&int64Total)
 
 	if publicBoardsCountQuery.Error != nil {
@@ -91,8 +106,9 @@ func (r *boardRepo) GetPublicBoards(ctx context.Context, userID uuid.UUID, limit
 
 	// Query to get the public boards where the user does not have a role
 	publicBoardsQuery := r.db.Table("boards").
-		Select("boards.id, boards.name, boards.type, boards.created_at").
+		Select("boards.id, boards.name, boards.description, boards.type, boards.wip_policy, boards.archived_at, boards.workspace_id, boards.created_at").
 		Where("boards.type = ?", "public").
+		Scopes(listedBoards(withArchived)).
 		Order("boards.created_at DESC")
 
 	if offset > 0 {
@@ -114,6 +130,26 @@ func (r *boardRepo) GetPublicBoards(ctx context.Context, userID uuid.UUID, limit
 	return publicBoards, total, nil
 }
 
+func (r *boardRepo) GetAll(ctx context.Context, limit, offset uint) ([]board.Board, uint, error) {
+	var boardEntities []entities.Board
+	var total int64
+	if err := r.db.WithContext(ctx).Model(&entities.Board{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query := r.db.WithContext(ctx).Model(&entities.Board{}).Order("created_at DESC")
+	if offset > 0 {
+		query = query.Offset(int(offset))
+	}
+	if limit > 0 {
+		query = query.Limit(int(limit))
+	}
+	if err := query.Find(&boardEntities).Error; err != nil {
+		return nil, 0, err
+	}
+	return mappers.BatchBoardEntitiesToDomain(boardEntities), uint(total), nil
+}
+
 func (r *boardRepo) Insert(ctx context.Context, b *board.Board) error {
 	boardEntity := mappers.BoardDomainToEntity(b)
 	if err := r.db.WithContext(ctx).Save(&boardEntity).Error; err != nil {
@@ -127,12 +163,20 @@ func (r *boardRepo) Insert(ctx context.Context, b *board.Board) error {
 func (r *boardRepo) GetFullByID(ctx context.Context, id uuid.UUID) (*board.Board, error) {
 	var b entities.Board
 
-	if err := r.db.Preload("Users").
-		Preload("Columns").
-		Preload("Columns.Tasks").
+	byRank := func(db *gorm.DB) *gorm.DB {
+		return db.Order("rank ASC")
+	}
+	if err := r.db.Preload("Columns", byRank).
+		Preload("Columns.Tasks", byRank).
 		First(&b, "id = ?", id).Error; err != nil {
 		return nil, err
 	}
+	// members are loaded through their roles, a many2many preload would also return members who left
+	if err := r.db.Joins("JOIN user_board_roles ubr ON ubr.user_id = users.id").
+		Where("ubr.board_id = ? AND ubr.deleted_at IS NULL", id).
+		Find(&b.Users).Error; err != nil {
+		return nil, err
+	}
 	domainBoard := mappers.BoardEntityToDomain(b)
 	return &domainBoard, nil
 }
@@ -173,3 +217,33 @@ func (r *boardRepo) deleteTaskDependencies(ctx context.Context, taskIDs []uuid.U
 
 	return nil
 }
+
+func (r *boardRepo) Update(ctx context.Context, boardID uuid.UUID, fields *board.UpdateFields) (*board.Board, error) {
+	result := r.db.WithContext(ctx).Model(&entities.Board{}).Where("id = ?", boardID).
+		Updates(mappers.BoardUpdateFieldsToColumns(fields))
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, board.ErrBoardNotFound
+	}
+	b, err := r.GetByID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, board.ErrBoardNotFound
+	}
+	return b, nil
+}
+
+func (r *boardRepo) SetArchivedAt(ctx context.Context, boardID uuid.UUID, archivedAt *time.Time) error {
+	result := r.db.WithContext(ctx).Model(&entities.Board{}).Where("id = ?", boardID).Update("archived_at", archivedAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return board.ErrBoardNotFound
+	}
+	return nil
+}
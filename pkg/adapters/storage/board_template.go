@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	boardtemplate "server/internal/board_template"
+	"server/pkg/adapters/storage/entities"
+	"server/pkg/adapters/storage/mappers"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type boardTemplateRepo struct {
+	db *gorm.DB
+}
+
+func NewBoardTemplateRepo(db *gorm.DB) boardtemplate.Repo {
+	return &boardTemplateRepo{db}
+}
+
+func (r *boardTemplateRepo) Insert(ctx context.Context, t *boardtemplate.Template) error {
+	templateEntity := mappers.BoardTemplateDomainToEntity(t)
+	// columns and tasks are created along with the template
+	if err := r.db.WithContext(ctx).Create(templateEntity).Error; err != nil {
+		return err
+	}
+	t.ID = templateEntity.ID
+	t.CreatedAt = templateEntity.CreatedAt
+	return nil
+}
+
+func (r *boardTemplateRepo) GetByID(ctx context.Context, id uuid.UUID) (*boardtemplate.Template, error) {
+	var t entities.BoardTemplate
+	err := r.db.WithContext(ctx).
+		Preload("Columns", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC") }).
+		Preload("Tasks", func(db *gorm.DB) *gorm.DB { return db.Order("column_position ASC, position ASC") }).
+		First(&t, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, boardtemplate.ErrTemplateNotFound
+		}
+		return nil, err
+	}
+	domainTemplate := mappers.BoardTemplateEntityToDomain(t)
+	return &domainTemplate, nil
+}
+
+func (r *boardTemplateRepo) GetUserTemplates(ctx context.Context, userID uuid.UUID) ([]boardtemplate.Template, error) {
+	var templates []entities.BoardTemplate
+	err := r.db.WithContext(ctx).
+		Preload("Columns", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC") }).
+		Where("created_by_user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&templates).Error
+	if err != nil {
+		return nil, err
+	}
+	return mappers.BatchBoardTemplateEntitiesToDomain(templates), nil
+}
+
+func (r *boardTemplateRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&entities.BoardTemplate{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return boardtemplate.ErrTemplateNotFound
+	}
+	return nil
+}
@@ -2,9 +2,12 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"server/internal/column"
 	"server/pkg/adapters/storage/entities"
 	"server/pkg/adapters/storage/mappers"
+	"server/pkg/fp"
+	"server/pkg/rank"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -22,6 +25,11 @@ func NewColumnRepo(db *gorm.DB) column.Repo {
 
 func (r *columnRepo) SetDoneAsDefault(ctx context.Context, column *column.Column) error {
 	columnEntity := mappers.ColumnDomainToEntity(*column)
+	lastRank, err := r.lastRank(ctx, column.BoardID, uuid.Nil)
+	if err != nil {
+		return err
+	}
+	columnEntity.Rank = rank.Between(lastRank, "")
 	if err := r.db.WithContext(ctx).Save(&columnEntity).Error; err != nil {
 		return err
 	}
@@ -29,8 +37,56 @@ func (r *columnRepo) SetDoneAsDefault(ctx context.Context, column *column.Column
 	return nil
 }
 
+func (r *columnRepo) UpdateKind(ctx context.Context, id uuid.UUID, kind column.Kind) error {
+	result := r.db.WithContext(ctx).Model(&entities.Column{}).Where("id = ?", id).Update("kind", string(kind))
+	if err := result.Error; err != nil {
+		return column.ErrFailedToUpdateColumn
+	}
+	if result.RowsAffected == 0 {
+		return column.ErrColumnNotFound
+	}
+	return nil
+}
+
+func (r *columnRepo) Update(ctx context.Context, id uuid.UUID, fields *column.UpdateFields) (*column.Column, error) {
+	var colEntity entities.Column
+	if err := r.db.WithContext(ctx).First(&colEntity, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, column.ErrColumnNotFound
+		}
+		return nil, err
+	}
+
+	if fields.Name != nil && *fields.Name != colEntity.Name {
+		// idx_together_name_board_id also covers soft deleted columns
+		var count int64
+		if err := r.db.WithContext(ctx).Unscoped().Model(&entities.Column{}).
+			Where("board_id = ? AND name = ? AND id <> ?", colEntity.BoardID, *fields.Name, id).
+			Count(&count).Error; err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return nil, column.ErrDuplicateName
+		}
+	}
+
+	if err := r.db.WithContext(ctx).Model(&colEntity).Updates(mappers.ColumnUpdateFieldsToColumns(fields)).Error; err != nil {
+		return nil, column.ErrFailedToUpdateColumn
+	}
+	if err := r.db.WithContext(ctx).First(&colEntity, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	col := mappers.ColumnEntityToDomain(colEntity)
+	return &col, nil
+}
+
 func (r *columnRepo) Create(ctx context.Context, col *column.Column) (*column.Column, error) {
 	columnEntity := mappers.ColumnDomainToEntity(*col)
+	lastRank, err := r.lastRank(ctx, col.BoardID, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	columnEntity.Rank = rank.Between(lastRank, "")
 	if err := r.db.WithContext(ctx).Save(&columnEntity).Error; err != nil {
 		return nil, err
 	}
@@ -56,11 +112,11 @@ func (r *columnRepo) GetMaxOrderForBoard(ctx context.Context, boardID uuid.UUID)
 	return maxOrder, err
 }
 func (r *columnRepo) GetMinOrderColumn(ctx context.Context, boardID uuid.UUID) (*column.Column, error) {
-	// Query to find the column with the minimum order
+	// Query to find the first column of the board
 	var minOrderColumn entities.Column
 	if err := r.db.WithContext(ctx).
 		Where("board_id = ?", boardID).
-		Order("order_num ASC").
+		Order("rank ASC").
 		First(&minOrderColumn).Error; err != nil {
 		return nil, err
 	}
@@ -69,6 +125,16 @@ func (r *columnRepo) GetMinOrderColumn(ctx context.Context, boardID uuid.UUID) (
 }
 func (r *columnRepo) CreateBatch(ctx context.Context, cols []column.Column) ([]column.Column, error) {
 	columnEntities := mappers.ColumnDomainsToEntities(cols)
+	if len(columnEntities) > 0 {
+		lastRank, err := r.lastRank(ctx, columnEntities[0].BoardID, uuid.Nil)
+		if err != nil {
+			return nil, err
+		}
+		for i := range columnEntities {
+			columnEntities[i].Rank = rank.Between(lastRank, "")
+			lastRank = columnEntities[i].Rank
+		}
+	}
 	if err := r.db.WithContext(ctx).Create(&columnEntities).Error; err != nil {
 		return nil, err
 	}
@@ -92,12 +158,14 @@ func (r *columnRepo) Delete(ctx context.Context, columnID uuid.UUID) error {
 	if err := result.Error; err != nil {
 		return err
 	}
-	return nil
+	// columns are soft deleted, so the foreign keys don't clean the workflow up
+	return r.db.WithContext(ctx).Where("from_column_id = ? OR to_column_id = ?", columnID, columnID).
+		Delete(&entities.ColumnTransition{}).Error
 }
 
 func (r *columnRepo) GetByBoardID(ctx context.Context, boardID uuid.UUID) ([]column.Column, error) {
 	var colEntities []entities.Column
-	if err := r.db.WithContext(ctx).Where("board_id = ?", boardID).Find(&colEntities).Error; err != nil {
+	if err := r.db.WithContext(ctx).Where("board_id = ?", boardID).Order("rank ASC").Find(&colEntities).Error; err != nil {
 		return nil, err
 	}
 	return mappers.BatchColumnEntitiesToDomain(colEntities), nil
@@ -162,12 +230,14 @@ func (r *columnRepo) ReorderColumns(ctx context.Context, boardID uuid.UUID, newO
 		tempOrder++
 	}
 
+	ranks := rank.Spread(len(columns))
 	for _, col := range columns {
 		newOrderNum, exists := newOrder[col.ID]
 		if !exists {
 			continue
 		}
-		if err := r.db.WithContext(ctx).Model(&col).Update("order_num", newOrderNum).Error; err != nil {
+		updates := map[string]interface{}{"order_num": newOrderNum, "rank": ranks[newOrderNum-1]}
+		if err := r.db.WithContext(ctx).Model(&col).Updates(updates).Error; err != nil {
 			return column.ErrFailedToUpdateColumn
 		}
 	}
@@ -178,7 +248,7 @@ func (r *columnRepo) ReorderColumns(ctx context.Context, boardID uuid.UUID, newO
 func (r *columnRepo) GetColumns(ctx context.Context, boardID uuid.UUID) ([]column.Column, error) {
 	var columns []entities.Column
 	err := r.db.WithContext(ctx).Where("board_id = ?", boardID).
-		Order("order_num ASC").
+		Order("rank ASC").
 		Find(&columns).Error
 	if err != nil {
 		return nil, column.ErrFailedToFetchColumns
@@ -186,3 +256,133 @@ func (r *columnRepo) GetColumns(ctx context.Context, boardID uuid.UUID) ([]colum
 	cols := mappers.BatchColumnEntitiesToDomain(columns)
 	return cols, nil
 }
+
+func (r *columnRepo) GetTransitions(ctx context.Context, boardID uuid.UUID) ([]column.Transition, error) {
+	var transitions []entities.ColumnTransition
+	if err := r.db.WithContext(ctx).Where("board_id = ?", boardID).Find(&transitions).Error; err != nil {
+		return nil, err
+	}
+	return mappers.BatchColumnTransitionEntitiesToDomain(transitions), nil
+}
+
+func (r *columnRepo) ReplaceTransitions(ctx context.Context, boardID uuid.UUID, transitions []column.Transition) error {
+	if err := r.db.WithContext(ctx).Where("board_id = ?", boardID).Delete(&entities.ColumnTransition{}).Error; err != nil {
+		return err
+	}
+	if len(transitions) == 0 {
+		return nil
+	}
+	transitionEntities := fp.Map(transitions, mappers.ColumnTransitionDomainToEntity)
+	return r.db.WithContext(ctx).Create(&transitionEntities).Error
+}
+
+func (r *columnRepo) Move(ctx context.Context, id uuid.UUID, afterID, beforeID *uuid.UUID) (*column.Column, error) {
+	var col entities.Column
+	if err := r.db.WithContext(ctx).First(&col, "id = ?", id).Error; err != nil {
+		return nil, column.ErrColumnNotFound
+	}
+
+	neighbourRank := func(neighbourID uuid.UUID) (string, error) {
+		var neighbour entities.Column
+		if err := r.db.WithContext(ctx).Where("id = ? AND board_id = ?", neighbourID, col.BoardID).First(&neighbour).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return "", column.ErrInvalidPosition
+			}
+			return "", err
+		}
+		return neighbour.Rank, nil
+	}
+
+	var (
+		prev, next string
+		err        error
+	)
+	switch {
+	case afterID != nil && beforeID != nil:
+		if prev, err = neighbourRank(*afterID); err != nil {
+			return nil, err
+		}
+		if next, err = neighbourRank(*beforeID); err != nil {
+			return nil, err
+		}
+		if prev >= next {
+			return nil, column.ErrInvalidPosition
+		}
+	case afterID != nil:
+		if prev, err = neighbourRank(*afterID); err != nil {
+			return nil, err
+		}
+		next, err = r.adjacentRank(ctx, col.BoardID, id, prev, true)
+	case beforeID != nil:
+		if next, err = neighbourRank(*beforeID); err != nil {
+			return nil, err
+		}
+		prev, err = r.adjacentRank(ctx, col.BoardID, id, next, false)
+	default:
+		prev, err = r.lastRank(ctx, col.BoardID, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	newRank := rank.Between(prev, next)
+	if err := r.db.WithContext(ctx).Model(&col).Update("rank", newRank).Error; err != nil {
+		return nil, column.ErrFailedToUpdateColumn
+	}
+	if rank.TooLong(newRank) {
+		if err := r.rebalanceBoard(ctx, col.BoardID); err != nil {
+			return nil, err
+		}
+		if err := r.db.WithContext(ctx).First(&col, "id = ?", id).Error; err != nil {
+			return nil, err
+		}
+	}
+	domainColumn := mappers.ColumnEntityToDomain(col)
+	return &domainColumn, nil
+}
+
+// adjacentRank returns the rank right after (or right before) the given one on a board, "" at the ends.
+func (r *columnRepo) adjacentRank(ctx context.Context, boardID, excludeID uuid.UUID, of string, after bool) (string, error) {
+	query := r.db.WithContext(ctx).Model(&entities.Column{}).Where("board_id = ? AND id <> ?", boardID, excludeID)
+	if after {
+		query = query.Where("rank > ?", of).Order("rank ASC")
+	} else {
+		query = query.Where("rank < ?", of).Order("rank DESC")
+	}
+	var ranks []string
+	if err := query.Limit(1).Pluck("rank", &ranks).Error; err != nil {
+		return "", err
+	}
+	if len(ranks) == 0 {
+		return "", nil
+	}
+	return ranks[0], nil
+}
+
+func (r *columnRepo) lastRank(ctx context.Context, boardID, excludeID uuid.UUID) (string, error) {
+	var ranks []string
+	if err := r.db.WithContext(ctx).Model(&entities.Column{}).
+		Where("board_id = ? AND id <> ?", boardID, excludeID).
+		Order("rank DESC").Limit(1).Pluck("rank", &ranks).Error; err != nil {
+		return "", err
+	}
+	if len(ranks) == 0 {
+		return "", nil
+	}
+	return ranks[0], nil
+}
+
+// rebalanceBoard spreads the column ranks of a board out again once they got too long.
+func (r *columnRepo) rebalanceBoard(ctx context.Context, boardID uuid.UUID) error {
+	var ids []uuid.UUID
+	if err := r.db.WithContext(ctx).Model(&entities.Column{}).Where("board_id = ?", boardID).
+		Order("rank ASC").Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+	for i, newRank := range rank.Spread(len(ids)) {
+		if err := r.db.WithContext(ctx).Model(&entities.Column{}).Where("id = ?", ids[i]).Update("rank", newRank).Error; err != nil {
+			return column.ErrFailedToUpdateColumn
+		}
+	}
+	return nil
+}
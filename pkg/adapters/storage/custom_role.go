@@ -0,0 +1,122 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	customrole "server/internal/custom_role"
+	"server/pkg/adapters/storage/entities"
+	"server/pkg/adapters/storage/mappers"
+	"server/pkg/rbac"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type customRoleRepo struct {
+	db *gorm.DB
+}
+
+func NewCustomRoleRepo(db *gorm.DB) customrole.Repo {
+	return &customRoleRepo{db}
+}
+
+func (r *customRoleRepo) Insert(ctx context.Context, role *customrole.CustomRole) error {
+	roleEntity := mappers.CustomRoleDomainToEntity(role)
+	if err := r.db.WithContext(ctx).Create(roleEntity).Error; err != nil {
+		return err
+	}
+	role.ID = roleEntity.ID
+	role.CreatedAt = roleEntity.CreatedAt
+	return nil
+}
+
+func (r *customRoleRepo) GetByID(ctx context.Context, id uuid.UUID) (*customrole.CustomRole, error) {
+	var role entities.CustomRole
+	if err := r.db.WithContext(ctx).First(&role, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customrole.ErrRoleNotFound
+		}
+		return nil, err
+	}
+	domainRole := mappers.CustomRoleEntityToDomain(role)
+	return &domainRole, nil
+}
+
+func (r *customRoleRepo) GetBoardRoles(ctx context.Context, boardID uuid.UUID, workspaceID *uuid.UUID) ([]customrole.CustomRole, error) {
+	var roles []entities.CustomRole
+	query := r.db.WithContext(ctx).Where("board_id = ?", boardID)
+	if workspaceID != nil {
+		query = query.Or("workspace_id = ?", *workspaceID)
+	}
+	if err := query.Order("name ASC").Find(&roles).Error; err != nil {
+		return nil, err
+	}
+	return mappers.BatchCustomRoleEntitiesToDomain(roles), nil
+}
+
+func (r *customRoleRepo) GetWorkspaceRoles(ctx context.Context, workspaceID uuid.UUID) ([]customrole.CustomRole, error) {
+	var roles []entities.CustomRole
+	if err := r.db.WithContext(ctx).Where("workspace_id = ?", workspaceID).
+		Order("name ASC").Find(&roles).Error; err != nil {
+		return nil, err
+	}
+	return mappers.BatchCustomRoleEntitiesToDomain(roles), nil
+}
+
+func (r *customRoleRepo) Update(ctx context.Context, id uuid.UUID, fields *customrole.UpdateFields) (*customrole.CustomRole, error) {
+	columns := make(map[string]interface{})
+	if fields.Name != nil {
+		columns["name"] = *fields.Name
+	}
+	if fields.Permissions != nil {
+		columns["permissions"] = mappers.JoinPermissions(fields.Permissions)
+	}
+	result := r.db.WithContext(ctx).Model(&entities.CustomRole{}).Where("id = ?", id).Updates(columns)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, customrole.ErrRoleNotFound
+	}
+	return r.GetByID(ctx, id)
+}
+
+func (r *customRoleRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&entities.CustomRole{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return customrole.ErrRoleNotFound
+	}
+	return nil
+}
+
+func (r *customRoleRepo) CountHolders(ctx context.Context, role rbac.Role) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entities.UserBoardRole{}).
+		Where("user_role = ?", string(role)).
+		Count(&count).Error
+	return count, err
+}
+
+type rbacStore struct {
+	db *gorm.DB
+}
+
+// NewRBACStore resolves custom roles for the rbac package.
+func NewRBACStore(db *gorm.DB) rbac.Store {
+	return &rbacStore{db}
+}
+
+func (s *rbacStore) GetPermissions(ctx context.Context, role rbac.Role) ([]rbac.Permission, bool) {
+	id, ok := customrole.ParseRole(string(role))
+	if !ok {
+		return nil, false
+	}
+	var customRole entities.CustomRole
+	if err := s.db.WithContext(ctx).Select("permissions").First(&customRole, "id = ?", id).Error; err != nil {
+		return nil, false
+	}
+	return mappers.SplitPermissions(customRole.Permissions), true
+}
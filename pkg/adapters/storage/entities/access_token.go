@@ -0,0 +1,25 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccessToken struct: Represents a personal access token, the secret itself is never stored.
+type AccessToken struct {
+	ID         uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	UserID     uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Name       string     `gorm:"not null"`
+	Scopes     string     `gorm:"not null"` // comma separated
+	BoardID    *uuid.UUID `gorm:"type:uuid"`
+	Prefix     string     `gorm:"not null"`
+	Hash       string     `gorm:"not null;uniqueIndex"`
+	ExpiresAt  time.Time  `gorm:"not null"`
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+
+	User  *User  `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Board *Board `gorm:"foreignKey:BoardID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
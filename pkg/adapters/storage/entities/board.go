@@ -8,15 +8,20 @@ import (
 )
 
 type Board struct {
-	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
-	Name      string    `gorm:"index"`
-	Type      string
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt gorm.DeletedAt `gorm:"index"`
+	ID          uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	Name        string    `gorm:"index"`
+	Description string    `gorm:"type:text;not null;default:''"`
+	Type        string
+	WIPPolicy   string     `gorm:"type:varchar(10);not null;default:enforce"`
+	ArchivedAt  *time.Time `gorm:"index"`
+	WorkspaceID *uuid.UUID `gorm:"type:uuid;index"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
 	// Relationships
 	Users          []User          `gorm:"many2many:user_board_roles;constraint:OnDelete:CASCADE;"`
 	Tasks          []Task          `gorm:"foreignKey:BoardID;constraint:OnDelete:CASCADE;"`
 	UserBoardRoles []UserBoardRole `gorm:"foreignKey:BoardID;constraint:OnDelete:CASCADE"`
 	Columns        []Column        `gorm:"foreignKey:BoardID;constraint:OnDelete:CASCADE"`
+	Workspace      *Workspace      `gorm:"foreignKey:WorkspaceID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
 }
@@ -0,0 +1,42 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BoardTemplate struct: Represents a saved board layout that new boards can be created from.
+type BoardTemplate struct {
+	ID              uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	Name            string    `gorm:"not null"`
+	Description     string    `gorm:"type:text;not null;default:''"`
+	Type            string    `gorm:"not null"`
+	WIPPolicy       string    `gorm:"type:varchar(10);not null;default:enforce"`
+	CreatedByUserID uuid.UUID `gorm:"type:uuid;not null;index"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+
+	CreatedBy *User            `gorm:"foreignKey:CreatedByUserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Columns   []TemplateColumn `gorm:"foreignKey:TemplateID;constraint:OnDelete:CASCADE"`
+	Tasks     []TemplateTask   `gorm:"foreignKey:TemplateID;constraint:OnDelete:CASCADE"`
+}
+
+type TemplateColumn struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	TemplateID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Position   uint      `gorm:"not null"`
+	Name       string    `gorm:"not null"`
+	Kind       string    `gorm:"type:varchar(20);not null;default:in_progress"`
+	WIPLimit   *uint
+}
+
+type TemplateTask struct {
+	ID             uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	TemplateID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	Position       uint      `gorm:"not null"`
+	ColumnPosition uint      `gorm:"not null"`
+	Title          string    `gorm:"not null"`
+	Description    string
+	StoryPoint     uint
+}
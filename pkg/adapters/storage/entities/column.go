@@ -16,5 +16,19 @@ type Column struct {
 	BoardID   uuid.UUID      `gorm:"index:idx_together_order_board_id,unique; index:idx_together_name_board_id,unique"`
 	Board     Board          `gorm:"foreignKey:BoardID;constraint:OnDelete:CASCADE"`
 	OrderNum  uint           `gorm:"index:idx_together_order_board_id,unique"`
+	Rank      string         `gorm:"index"`
+	Kind      string         `gorm:"type:varchar(20);not null;default:in_progress"`
 	Tasks     []Task         `gorm:"foreignKey:ColumnID;constraint:OnDelete:CASCADE"`
+	WIPLimit  *uint
+}
+
+type ColumnTransition struct {
+	ID           uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	BoardID      uuid.UUID `gorm:"type:uuid;not null;index"`
+	Board        Board     `gorm:"foreignKey:BoardID;constraint:OnDelete:CASCADE"`
+	FromColumnID uuid.UUID `gorm:"type:uuid;not null;index:idx_together_from_to_column_id,unique"`
+	FromColumn   Column    `gorm:"foreignKey:FromColumnID;constraint:OnDelete:CASCADE"`
+	ToColumnID   uuid.UUID `gorm:"type:uuid;not null;index:idx_together_from_to_column_id,unique"`
+	ToColumn     Column    `gorm:"foreignKey:ToColumnID;constraint:OnDelete:CASCADE"`
+	Roles        string    // comma separated, empty for every role
 }
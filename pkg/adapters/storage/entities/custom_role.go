@@ -0,0 +1,21 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CustomRole struct: Represents a role defined by the owners of a board or the admins of a workspace.
+type CustomRole struct {
+	ID          uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	Name        string     `gorm:"not null"`
+	BoardID     *uuid.UUID `gorm:"type:uuid;index"`
+	WorkspaceID *uuid.UUID `gorm:"type:uuid;index"`
+	Permissions string     `gorm:"type:text;not null"` // comma separated
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+
+	Board     *Board     `gorm:"foreignKey:BoardID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Workspace *Workspace `gorm:"foreignKey:WorkspaceID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
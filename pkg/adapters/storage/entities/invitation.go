@@ -0,0 +1,38 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Invitation struct: Represents an invitation of an email to a board, kept after it is answered.
+type Invitation struct {
+	ID            uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	BoardID       uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Email         string     `gorm:"not null;index"`
+	InviteeUserID *uuid.UUID `gorm:"type:uuid;index"`
+	InviterUserID uuid.UUID  `gorm:"type:uuid;not null"`
+	Role          string     `gorm:"not null"`
+	Status        string     `gorm:"type:varchar(10);not null;default:pending;index"`
+	ExpiresAt     *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+
+	Board *Board `gorm:"foreignKey:BoardID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// InviteLink struct: Represents a shareable link to join a board with a fixed role.
+type InviteLink struct {
+	ID              uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	BoardID         uuid.UUID `gorm:"type:uuid;not null;index"`
+	Role            string    `gorm:"not null"`
+	CreatedByUserID uuid.UUID `gorm:"type:uuid;not null"`
+	MaxUses         uint      `gorm:"not null;default:0"`
+	Uses            uint      `gorm:"not null;default:0"`
+	ExpiresAt       *time.Time
+	RevokedAt       *time.Time
+	CreatedAt       time.Time
+
+	Board *Board `gorm:"foreignKey:BoardID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
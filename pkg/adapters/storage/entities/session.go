@@ -0,0 +1,22 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session struct: Represents a login of a user on a device, identified by its refresh token.
+type Session struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	TokenID    uuid.UUID `gorm:"type:uuid;not null"`
+	Device     string    `gorm:"not null;default:''"`
+	IP         string    `gorm:"not null;default:''"`
+	ExpiresAt  time.Time `gorm:"not null"`
+	RevokedAt  *time.Time
+	LastUsedAt time.Time `gorm:"not null"`
+	CreatedAt  time.Time
+
+	User *User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
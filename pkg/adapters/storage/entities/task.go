@@ -18,7 +18,8 @@ type Task struct {
 	ID          uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
 	Title       string    `gorm:"not null"`
 	Description string
-	Order       uint // in column which order is this
+	Order       uint   // in column which order is this
+	Rank        string `gorm:"index"`
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 	DeletedAt   gorm.DeletedAt `gorm:"index"`
@@ -42,7 +43,7 @@ type Task struct {
 	Subtasks []Task     `gorm:"foreignKey:ParentID;constraint:OnDelete:CASCADE"`
 
 	DependsOn   []Task `gorm:"many2many:task_dependencies;joinForeignKey:dependent_task_id;joinReferences:dependency_task_id;constraint:OnDelete:CASCADE"`
-	DependentBy []Task `gorm:"many2many:task_dependencies;joinForeignKey:dependent_task_id;joinReferences:dependency_task_id;constraint:OnDelete:CASCADE"`
+	DependentBy []Task `gorm:"many2many:task_dependencies;joinForeignKey:dependency_task_id;joinReferences:dependent_task_id;constraint:OnDelete:CASCADE"`
 }
 
 type TaskDependency struct {
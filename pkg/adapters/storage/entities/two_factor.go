@@ -0,0 +1,29 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TwoFactor struct {
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Secret    string    `gorm:"not null"`
+	EnabledAt *time.Time
+	LastStep  int64 `gorm:"not null;default:0"`
+	// the login challenge awaiting a code and the codes tried since the window started
+	ChallengeID        *uuid.UUID `gorm:"type:uuid"`
+	ChallengeAttempts  uint       `gorm:"not null;default:0"`
+	ChallengeStartedAt *time.Time
+	CreatedAt         time.Time
+	User              *User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+type RecoveryCode struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	Hash      string    `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+	User      *User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
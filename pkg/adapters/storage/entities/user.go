@@ -8,14 +8,17 @@ import (
 )
 
 type User struct {
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt gorm.DeletedAt `gorm:"index"`
-	ID        uuid.UUID      `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
-	FirstName string
-	LastName  string
-	Email     string `gorm:"uniqueIndex"`
-	Password  string
-	Role      uint8
-	Boards    []Board `gorm:"many2many:user_board_roles"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DeletedAt       gorm.DeletedAt `gorm:"index"`
+	ID              uuid.UUID      `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	FirstName       string
+	LastName        string
+	Email           string `gorm:"uniqueIndex"`
+	Password        string
+	Role            uint8
+	SuspendedAt     *time.Time
+	AvatarURL       string `gorm:"not null;default:''"`
+	EmailVerifiedAt *time.Time
+	Boards          []Board `gorm:"many2many:user_board_roles"`
 }
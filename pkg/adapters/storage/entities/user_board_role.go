@@ -13,6 +13,7 @@ type UserBoardRole struct {
 	UserID    uuid.UUID `gorm:"type:uuid;not null"`
 	BoardID   uuid.UUID `gorm:"type:uuid;not null"`
 	UserRole  string    `gorm:"not null"`
+	Inherited bool      `gorm:"not null;default:false"`
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt `gorm:"index"`
@@ -20,3 +21,17 @@ type UserBoardRole struct {
 	User  User   `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 	Board *Board `gorm:"foreignKey:BoardID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 }
+
+// OwnershipTransfer struct: Represents a pending offer of the ownership of a board, at most one per board.
+type OwnershipTransfer struct {
+	ID            uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	BoardID       uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	FromUserID    uuid.UUID `gorm:"type:uuid;not null"`
+	ToUserID      uuid.UUID `gorm:"type:uuid;not null"`
+	KeepOwnership bool      `gorm:"not null;default:false"`
+	CreatedAt     time.Time
+
+	Board    *Board `gorm:"foreignKey:BoardID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	FromUser *User  `gorm:"foreignKey:FromUserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	ToUser   *User  `gorm:"foreignKey:ToUserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
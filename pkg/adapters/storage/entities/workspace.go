@@ -0,0 +1,30 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Workspace struct: Represents an organization grouping boards and users.
+type Workspace struct {
+	ID              uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	Name            string    `gorm:"not null;index"`
+	Description     string    `gorm:"type:text;not null;default:''"`
+	CreatedByUserID uuid.UUID `gorm:"type:uuid;not null"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+
+	Members []WorkspaceMember `gorm:"foreignKey:WorkspaceID;constraint:OnDelete:CASCADE"`
+}
+
+// WorkspaceMember struct: Represents the role of a user in a workspace, at most one per user and workspace.
+type WorkspaceMember struct {
+	WorkspaceID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserID      uuid.UUID `gorm:"type:uuid;primaryKey;index"`
+	Role        string    `gorm:"type:varchar(10);not null"`
+	CreatedAt   time.Time
+
+	Workspace *Workspace `gorm:"foreignKey:WorkspaceID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	User      *User      `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
@@ -0,0 +1,147 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"server/internal/invitation"
+	"server/pkg/adapters/storage/entities"
+	"server/pkg/adapters/storage/mappers"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type invitationRepo struct {
+	db *gorm.DB
+}
+
+func NewInvitationRepo(db *gorm.DB) invitation.Repo {
+	return &invitationRepo{db}
+}
+
+func (r *invitationRepo) Insert(ctx context.Context, inv *invitation.Invitation) error {
+	invitationEntity := mappers.InvitationDomainToEntity(inv)
+	if err := r.db.WithContext(ctx).Create(invitationEntity).Error; err != nil {
+		return err
+	}
+	inv.ID = invitationEntity.ID
+	inv.CreatedAt = invitationEntity.CreatedAt
+	return nil
+}
+
+func (r *invitationRepo) GetByID(ctx context.Context, id uuid.UUID) (*invitation.Invitation, error) {
+	var inv entities.Invitation
+	if err := r.db.WithContext(ctx).Preload("Board").First(&inv, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, invitation.ErrInvitationNotFound
+		}
+		return nil, err
+	}
+	domainInvitation := mappers.InvitationEntityToDomain(inv)
+	return &domainInvitation, nil
+}
+
+func (r *invitationRepo) GetPending(ctx context.Context, boardID uuid.UUID, email string) (*invitation.Invitation, error) {
+	var invitations []entities.Invitation
+	if err := r.db.WithContext(ctx).
+		Where("board_id = ? AND email = ? AND status = ?", boardID, email, string(invitation.StatusPending)).
+		Limit(1).Find(&invitations).Error; err != nil {
+		return nil, err
+	}
+	if len(invitations) == 0 {
+		return nil, nil
+	}
+	domainInvitation := mappers.InvitationEntityToDomain(invitations[0])
+	return &domainInvitation, nil
+}
+
+// openInvitations keeps the pending invitations that did not expire yet.
+func openInvitations(db *gorm.DB) *gorm.DB {
+	return db.Where("invitations.status = ? AND (invitations.expires_at IS NULL OR invitations.expires_at > ?)",
+		string(invitation.StatusPending), time.Now())
+}
+
+func (r *invitationRepo) GetPendingByBoardID(ctx context.Context, boardID uuid.UUID) ([]invitation.Invitation, error) {
+	var invitations []entities.Invitation
+	if err := r.db.WithContext(ctx).Preload("Board").Scopes(openInvitations).
+		Where("board_id = ?", boardID).Order("created_at DESC").Find(&invitations).Error; err != nil {
+		return nil, err
+	}
+	return mappers.BatchInvitationEntitiesToDomain(invitations), nil
+}
+
+func (r *invitationRepo) GetPendingByUserID(ctx context.Context, userID uuid.UUID) ([]invitation.Invitation, error) {
+	var invitations []entities.Invitation
+	if err := r.db.WithContext(ctx).Preload("Board").Scopes(openInvitations).
+		Where("invitee_user_id = ?", userID).Order("created_at DESC").Find(&invitations).Error; err != nil {
+		return nil, err
+	}
+	return mappers.BatchInvitationEntitiesToDomain(invitations), nil
+}
+
+func (r *invitationRepo) UpdateStatus(ctx context.Context, id uuid.UUID, status invitation.Status) error {
+	result := r.db.WithContext(ctx).Model(&entities.Invitation{}).Where("id = ?", id).Update("status", string(status))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return invitation.ErrInvitationNotFound
+	}
+	return nil
+}
+
+func (r *invitationRepo) ClaimByEmail(ctx context.Context, email string, userID uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&entities.Invitation{}).
+		Where("email = ? AND invitee_user_id IS NULL AND status = ?", email, string(invitation.StatusPending)).
+		Update("invitee_user_id", userID).Error
+}
+
+func (r *invitationRepo) InsertLink(ctx context.Context, link *invitation.Link) error {
+	linkEntity := mappers.InviteLinkDomainToEntity(link)
+	if err := r.db.WithContext(ctx).Create(linkEntity).Error; err != nil {
+		return err
+	}
+	link.ID = linkEntity.ID
+	link.CreatedAt = linkEntity.CreatedAt
+	return nil
+}
+
+func (r *invitationRepo) GetLinkByID(ctx context.Context, id uuid.UUID) (*invitation.Link, error) {
+	var link entities.InviteLink
+	if err := r.db.WithContext(ctx).First(&link, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, invitation.ErrLinkNotFound
+		}
+		return nil, err
+	}
+	domainLink := mappers.InviteLinkEntityToDomain(link)
+	return &domainLink, nil
+}
+
+func (r *invitationRepo) GetLinksByBoardID(ctx context.Context, boardID uuid.UUID) ([]invitation.Link, error) {
+	var links []entities.InviteLink
+	if err := r.db.WithContext(ctx).Where("board_id = ?", boardID).Order("created_at DESC").Find(&links).Error; err != nil {
+		return nil, err
+	}
+	return mappers.BatchInviteLinkEntitiesToDomain(links), nil
+}
+
+func (r *invitationRepo) RevokeLink(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&entities.InviteLink{}).
+		Where("id = ? AND revoked_at IS NULL", id).Update("revoked_at", time.Now()).Error
+}
+
+func (r *invitationRepo) UseLink(ctx context.Context, id uuid.UUID) error {
+	// the limit is checked in the update itself so concurrent joins can not overuse the link
+	result := r.db.WithContext(ctx).Model(&entities.InviteLink{}).
+		Where("id = ? AND revoked_at IS NULL AND (max_uses = 0 OR uses < max_uses)", id).
+		Update("uses", gorm.Expr("uses + 1"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return invitation.ErrLinkExhausted
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package mappers
+
+import (
+	accesstoken "server/internal/access_token"
+	"server/pkg/adapters/storage/entities"
+	"server/pkg/fp"
+	"strings"
+)
+
+func AccessTokenEntityToDomain(e *entities.AccessToken) *accesstoken.AccessToken {
+	return &accesstoken.AccessToken{
+		ID:         e.ID,
+		UserID:     e.UserID,
+		Name:       e.Name,
+		Scopes:     fp.Map(strings.Split(e.Scopes, ","), func(s string) accesstoken.Scope { return accesstoken.Scope(s) }),
+		BoardID:    e.BoardID,
+		Prefix:     e.Prefix,
+		Hash:       e.Hash,
+		ExpiresAt:  e.ExpiresAt,
+		LastUsedAt: e.LastUsedAt,
+		RevokedAt:  e.RevokedAt,
+		CreatedAt:  e.CreatedAt,
+	}
+}
+
+func accessTokenEntityToDomain(e entities.AccessToken) accesstoken.AccessToken {
+	return *AccessTokenEntityToDomain(&e)
+}
+
+func BatchAccessTokenEntityToDomain(es []entities.AccessToken) []accesstoken.AccessToken {
+	return fp.Map(es, accessTokenEntityToDomain)
+}
+
+func AccessTokenDomainToEntity(t *accesstoken.AccessToken) *entities.AccessToken {
+	return &entities.AccessToken{
+		ID:         t.ID,
+		UserID:     t.UserID,
+		Name:       t.Name,
+		Scopes:     strings.Join(fp.Map(t.Scopes, func(s accesstoken.Scope) string { return string(s) }), ","),
+		BoardID:    t.BoardID,
+		Prefix:     t.Prefix,
+		Hash:       t.Hash,
+		ExpiresAt:  t.ExpiresAt,
+		LastUsedAt: t.LastUsedAt,
+		RevokedAt:  t.RevokedAt,
+	}
+}
@@ -10,12 +10,16 @@ func BoardEntityToDomain(boardEntity entities.Board) board.Board {
 	domainUsers := BatchUserEntityToDomain(boardEntity.Users)
 	domainColumns := BatchColumnEntitiesToDomain(boardEntity.Columns)
 	return board.Board{
-		ID:        boardEntity.ID,
-		CreatedAt: boardEntity.CreatedAt,
-		Name:      boardEntity.Name,
-		Type:      boardEntity.Type,
-		Users:     domainUsers,
-		Columns:   domainColumns,
+		ID:          boardEntity.ID,
+		CreatedAt:   boardEntity.CreatedAt,
+		Name:        boardEntity.Name,
+		Description: boardEntity.Description,
+		Type:        boardEntity.Type,
+		WIPPolicy:   board.WIPPolicy(boardEntity.WIPPolicy),
+		ArchivedAt:  boardEntity.ArchivedAt,
+		WorkspaceID: boardEntity.WorkspaceID,
+		Users:       domainUsers,
+		Columns:     domainColumns,
 	}
 }
 
@@ -25,8 +29,28 @@ func BatchBoardEntitiesToDomain(boardEntities []entities.Board) []board.Board {
 
 func BoardDomainToEntity(b *board.Board) *entities.Board {
 	return &entities.Board{
-		CreatedAt: b.CreatedAt,
-		Name:      b.Name,
-		Type:      b.Type,
+		CreatedAt:   b.CreatedAt,
+		Name:        b.Name,
+		Description: b.Description,
+		Type:        b.Type,
+		WIPPolicy:   string(b.WIPPolicy),
+		WorkspaceID: b.WorkspaceID,
 	}
 }
+
+func BoardUpdateFieldsToColumns(f *board.UpdateFields) map[string]interface{} {
+	columns := make(map[string]interface{})
+	if f.Name != nil {
+		columns["name"] = *f.Name
+	}
+	if f.Description != nil {
+		columns["description"] = *f.Description
+	}
+	if f.Type != nil {
+		columns["type"] = *f.Type
+	}
+	if f.WIPPolicy != nil {
+		columns["wip_policy"] = string(*f.WIPPolicy)
+	}
+	return columns
+}
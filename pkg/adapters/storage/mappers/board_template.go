@@ -0,0 +1,77 @@
+package mappers
+
+import (
+	"server/internal/board"
+	boardtemplate "server/internal/board_template"
+	"server/internal/column"
+	"server/pkg/adapters/storage/entities"
+	"server/pkg/fp"
+)
+
+func BoardTemplateDomainToEntity(t *boardtemplate.Template) *entities.BoardTemplate {
+	return &entities.BoardTemplate{
+		Name:            t.Name,
+		Description:     t.Description,
+		Type:            t.Type,
+		WIPPolicy:       string(t.WIPPolicy),
+		CreatedByUserID: t.CreatedByUserID,
+		Columns:         fp.Map(t.Columns, TemplateColumnDomainToEntity),
+		Tasks:           fp.Map(t.Tasks, TemplateTaskDomainToEntity),
+	}
+}
+
+func BoardTemplateEntityToDomain(t entities.BoardTemplate) boardtemplate.Template {
+	return boardtemplate.Template{
+		ID:              t.ID,
+		Name:            t.Name,
+		Description:     t.Description,
+		Type:            t.Type,
+		WIPPolicy:       board.WIPPolicy(t.WIPPolicy),
+		CreatedByUserID: t.CreatedByUserID,
+		CreatedAt:       t.CreatedAt,
+		Columns:         fp.Map(t.Columns, TemplateColumnEntityToDomain),
+		Tasks:           fp.Map(t.Tasks, TemplateTaskEntityToDomain),
+	}
+}
+
+func BatchBoardTemplateEntitiesToDomain(templates []entities.BoardTemplate) []boardtemplate.Template {
+	return fp.Map(templates, BoardTemplateEntityToDomain)
+}
+
+func TemplateColumnDomainToEntity(c boardtemplate.Column) entities.TemplateColumn {
+	return entities.TemplateColumn{
+		Position: c.Position,
+		Name:     c.Name,
+		Kind:     string(c.Kind),
+		WIPLimit: c.WIPLimit,
+	}
+}
+
+func TemplateColumnEntityToDomain(c entities.TemplateColumn) boardtemplate.Column {
+	return boardtemplate.Column{
+		Position: c.Position,
+		Name:     c.Name,
+		Kind:     column.Kind(c.Kind),
+		WIPLimit: c.WIPLimit,
+	}
+}
+
+func TemplateTaskDomainToEntity(t boardtemplate.Task) entities.TemplateTask {
+	return entities.TemplateTask{
+		Position:       t.Position,
+		ColumnPosition: t.ColumnPosition,
+		Title:          t.Title,
+		Description:    t.Description,
+		StoryPoint:     t.StoryPoint,
+	}
+}
+
+func TemplateTaskEntityToDomain(t entities.TemplateTask) boardtemplate.Task {
+	return boardtemplate.Task{
+		Position:       t.Position,
+		ColumnPosition: t.ColumnPosition,
+		Title:          t.Title,
+		Description:    t.Description,
+		StoryPoint:     t.StoryPoint,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"server/internal/column"
 	"server/pkg/adapters/storage/entities"
 	"server/pkg/fp"
+	"strings"
 
 	"gorm.io/gorm"
 )
@@ -15,6 +16,9 @@ func ColumnEntityToDomain(col entities.Column) column.Column {
 		Name:      col.Name,
 		BoardID:   col.BoardID,
 		OrderNum:  col.OrderNum,
+		Rank:      col.Rank,
+		Kind:      column.Kind(col.Kind),
+		WIPLimit:  col.WIPLimit,
 		CreatedAt: col.CreatedAt,
 		UpdatedAt: col.UpdatedAt,
 		Tasks:     tasks,
@@ -31,6 +35,9 @@ func ColumnDomainToEntity(col column.Column) entities.Column {
 		Name:      col.Name,
 		BoardID:   col.BoardID,
 		OrderNum:  col.OrderNum,
+		Rank:      col.Rank,
+		Kind:      string(col.Kind),
+		WIPLimit:  col.WIPLimit,
 		CreatedAt: col.CreatedAt,
 		UpdatedAt: col.UpdatedAt,
 		DeletedAt: gorm.DeletedAt{},
@@ -40,3 +47,46 @@ func ColumnDomainToEntity(col column.Column) entities.Column {
 func ColumnDomainsToEntities(cols []column.Column) []entities.Column {
 	return fp.Map(cols, ColumnDomainToEntity)
 }
+
+func ColumnUpdateFieldsToColumns(fields *column.UpdateFields) map[string]interface{} {
+	columns := make(map[string]interface{})
+	if fields.Name != nil {
+		columns["name"] = *fields.Name
+	}
+	if fields.WIPLimit != nil {
+		if *fields.WIPLimit == 0 {
+			columns["wip_limit"] = nil
+		} else {
+			columns["wip_limit"] = *fields.WIPLimit
+		}
+	}
+	return columns
+}
+
+func ColumnTransitionEntityToDomain(t entities.ColumnTransition) column.Transition {
+	var roles []string
+	if t.Roles != "" {
+		roles = strings.Split(t.Roles, ",")
+	}
+	return column.Transition{
+		ID:           t.ID,
+		BoardID:      t.BoardID,
+		FromColumnID: t.FromColumnID,
+		ToColumnID:   t.ToColumnID,
+		Roles:        roles,
+	}
+}
+
+func BatchColumnTransitionEntitiesToDomain(ts []entities.ColumnTransition) []column.Transition {
+	return fp.Map(ts, ColumnTransitionEntityToDomain)
+}
+
+func ColumnTransitionDomainToEntity(t column.Transition) entities.ColumnTransition {
+	return entities.ColumnTransition{
+		ID:           t.ID,
+		BoardID:      t.BoardID,
+		FromColumnID: t.FromColumnID,
+		ToColumnID:   t.ToColumnID,
+		Roles:        strings.Join(t.Roles, ","),
+	}
+}
@@ -0,0 +1,44 @@
+package mappers
+
+import (
+	customrole "server/internal/custom_role"
+	"server/pkg/adapters/storage/entities"
+	"server/pkg/fp"
+	"server/pkg/rbac"
+	"strings"
+)
+
+func CustomRoleDomainToEntity(r *customrole.CustomRole) *entities.CustomRole {
+	return &entities.CustomRole{
+		Name:        r.Name,
+		BoardID:     r.BoardID,
+		WorkspaceID: r.WorkspaceID,
+		Permissions: JoinPermissions(r.Permissions),
+	}
+}
+
+func CustomRoleEntityToDomain(r entities.CustomRole) customrole.CustomRole {
+	return customrole.CustomRole{
+		ID:          r.ID,
+		Name:        r.Name,
+		BoardID:     r.BoardID,
+		WorkspaceID: r.WorkspaceID,
+		Permissions: SplitPermissions(r.Permissions),
+		CreatedAt:   r.CreatedAt,
+	}
+}
+
+func BatchCustomRoleEntitiesToDomain(roles []entities.CustomRole) []customrole.CustomRole {
+	return fp.Map(roles, CustomRoleEntityToDomain)
+}
+
+func JoinPermissions(permissions []rbac.Permission) string {
+	return strings.Join(fp.Map(permissions, func(p rbac.Permission) string { return string(p) }), ",")
+}
+
+func SplitPermissions(permissions string) []rbac.Permission {
+	if permissions == "" {
+		return nil
+	}
+	return fp.Map(strings.Split(permissions, ","), func(p string) rbac.Permission { return rbac.Permission(p) })
+}
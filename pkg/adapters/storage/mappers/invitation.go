@@ -0,0 +1,69 @@
+package mappers
+
+import (
+	"server/internal/invitation"
+	"server/pkg/adapters/storage/entities"
+	"server/pkg/fp"
+)
+
+func InvitationDomainToEntity(i *invitation.Invitation) *entities.Invitation {
+	return &entities.Invitation{
+		BoardID:       i.BoardID,
+		Email:         i.Email,
+		InviteeUserID: i.InviteeUserID,
+		InviterUserID: i.InviterUserID,
+		Role:          i.Role,
+		Status:        string(i.Status),
+		ExpiresAt:     i.ExpiresAt,
+	}
+}
+
+func InvitationEntityToDomain(i entities.Invitation) invitation.Invitation {
+	inv := invitation.Invitation{
+		ID:            i.ID,
+		BoardID:       i.BoardID,
+		Email:         i.Email,
+		InviteeUserID: i.InviteeUserID,
+		InviterUserID: i.InviterUserID,
+		Role:          i.Role,
+		Status:        invitation.Status(i.Status),
+		ExpiresAt:     i.ExpiresAt,
+		CreatedAt:     i.CreatedAt,
+	}
+	if i.Board != nil {
+		inv.BoardName = i.Board.Name
+	}
+	return inv
+}
+
+func BatchInvitationEntitiesToDomain(invitations []entities.Invitation) []invitation.Invitation {
+	return fp.Map(invitations, InvitationEntityToDomain)
+}
+
+func InviteLinkDomainToEntity(l *invitation.Link) *entities.InviteLink {
+	return &entities.InviteLink{
+		BoardID:         l.BoardID,
+		Role:            l.Role,
+		CreatedByUserID: l.CreatedByUserID,
+		MaxUses:         l.MaxUses,
+		ExpiresAt:       l.ExpiresAt,
+	}
+}
+
+func InviteLinkEntityToDomain(l entities.InviteLink) invitation.Link {
+	return invitation.Link{
+		ID:              l.ID,
+		BoardID:         l.BoardID,
+		Role:            l.Role,
+		CreatedByUserID: l.CreatedByUserID,
+		MaxUses:         l.MaxUses,
+		Uses:            l.Uses,
+		ExpiresAt:       l.ExpiresAt,
+		RevokedAt:       l.RevokedAt,
+		CreatedAt:       l.CreatedAt,
+	}
+}
+
+func BatchInviteLinkEntitiesToDomain(links []entities.InviteLink) []invitation.Link {
+	return fp.Map(links, InviteLinkEntityToDomain)
+}
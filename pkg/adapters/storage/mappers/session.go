@@ -0,0 +1,42 @@
+package mappers
+
+import (
+	"server/internal/session"
+	"server/pkg/adapters/storage/entities"
+	"server/pkg/fp"
+)
+
+func SessionEntityToDomain(e *entities.Session) *session.Session {
+	return &session.Session{
+		ID:         e.ID,
+		UserID:     e.UserID,
+		TokenID:    e.TokenID,
+		Device:     e.Device,
+		IP:         e.IP,
+		ExpiresAt:  e.ExpiresAt,
+		RevokedAt:  e.RevokedAt,
+		LastUsedAt: e.LastUsedAt,
+		CreatedAt:  e.CreatedAt,
+	}
+}
+
+func sessionEntityToDomain(e entities.Session) session.Session {
+	return *SessionEntityToDomain(&e)
+}
+
+func BatchSessionEntityToDomain(es []entities.Session) []session.Session {
+	return fp.Map(es, sessionEntityToDomain)
+}
+
+func SessionDomainToEntity(s *session.Session) *entities.Session {
+	return &entities.Session{
+		ID:         s.ID,
+		UserID:     s.UserID,
+		TokenID:    s.TokenID,
+		Device:     s.Device,
+		IP:         s.IP,
+		ExpiresAt:  s.ExpiresAt,
+		RevokedAt:  s.RevokedAt,
+		LastUsedAt: s.LastUsedAt,
+	}
+}
@@ -1,9 +1,11 @@
 package mappers
 
 import (
+	"server/internal/column"
 	"server/internal/task"
 	"server/pkg/adapters/storage/entities"
 	"server/pkg/fp"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -12,11 +14,17 @@ func TaskEntityToDomain(taskEntity entities.Task) task.Task {
 	subTasks := BatchTaskEntitiesToDomain(taskEntity.Subtasks)
 	ubr := UserBoardRoleEntityToDomain(taskEntity.UserBoardRole)
 	dependencies := BatchTaskEntitiesToDomain(taskEntity.DependsOn)
+	dependents := BatchTaskEntitiesToDomain(taskEntity.DependentBy)
 	comments := BatchCommentEntitiesToDomain(taskEntity.Comments)
+	var deletedAt *time.Time
+	if taskEntity.DeletedAt.Valid {
+		deletedAt = &taskEntity.DeletedAt.Time
+	}
+	isDone := taskEntity.Column != nil && taskEntity.Column.Kind == string(column.KindDone)
 	return task.Task{
 		ID:              taskEntity.ID,
 		Title:           taskEntity.Title,
-		ColumnID: taskEntity.ColumnID,
+		ColumnID:        taskEntity.ColumnID,
 		Description:     taskEntity.Description,
 		StartAt:         taskEntity.StartAt,
 		EndAt:           taskEntity.EndAt,
@@ -26,9 +34,13 @@ func TaskEntityToDomain(taskEntity entities.Task) task.Task {
 		ParentID:        taskEntity.ParentID,
 		Subtasks:        subTasks,
 		DependsOn:       dependencies,
+		DependentBy:     dependents,
 		UserBoardRole:   &ubr,
 		Order:           taskEntity.Order,
+		Rank:            taskEntity.Rank,
 		Comments:        comments,
+		DeletedAt:       deletedAt,
+		IsDone:          isDone,
 	}
 }
 
@@ -50,6 +62,29 @@ func TaskDomainToEntity(t *task.Task) *entities.Task {
 	}
 }
 
+func TaskUpdateFieldsToColumns(f *task.UpdateFields) map[string]interface{} {
+	updates := make(map[string]interface{})
+	if f.Title != nil {
+		updates["title"] = *f.Title
+	}
+	if f.Description != nil {
+		updates["description"] = *f.Description
+	}
+	if f.StartAt != nil {
+		updates["start_at"] = *f.StartAt
+	}
+	if f.EndAt != nil {
+		updates["end_at"] = *f.EndAt
+	}
+	if f.StoryPoint != nil {
+		updates["story_point"] = *f.StoryPoint
+	}
+	if f.UserBoardRoleID != nil {
+		updates["user_board_role_id"] = *f.UserBoardRoleID
+	}
+	return updates
+}
+
 func TaskDependencyDomainToTaskEntity(id uuid.UUID) entities.Task {
 	return entities.Task{ID: id}
 }
@@ -63,3 +98,14 @@ func BatchTaskDependencyDomainToTask(taskIDs []uuid.UUID) []entities.Task {
 	}
 	return tasks
 }
+
+func TaskDependencyEntityToDomain(d entities.TaskDependency) task.TaskDependency {
+	return task.TaskDependency{
+		DependentTaskID:  d.DependentTaskID,
+		DependencyTaskID: d.DependencyTaskID,
+	}
+}
+
+func BatchTaskDependencyEntitiesToDomain(deps []entities.TaskDependency) []task.TaskDependency {
+	return fp.Map(deps, TaskDependencyEntityToDomain)
+}
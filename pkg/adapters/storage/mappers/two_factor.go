@@ -0,0 +1,25 @@
+package mappers
+
+import (
+	twofactor "server/internal/two_factor"
+	"server/pkg/adapters/storage/entities"
+)
+
+func TwoFactorEntityToDomain(e *entities.TwoFactor) *twofactor.TwoFactor {
+	return &twofactor.TwoFactor{
+		UserID:    e.UserID,
+		Secret:    e.Secret,
+		EnabledAt: e.EnabledAt,
+		LastStep:  e.LastStep,
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+func TwoFactorDomainToEntity(tf *twofactor.TwoFactor) *entities.TwoFactor {
+	return &entities.TwoFactor{
+		UserID:    tf.UserID,
+		Secret:    tf.Secret,
+		EnabledAt: tf.EnabledAt,
+		LastStep:  tf.LastStep,
+	}
+}
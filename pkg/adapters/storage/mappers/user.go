@@ -8,22 +8,28 @@ import (
 
 func UserEntityToDomain(entity *entities.User) *user.User {
 	return &user.User{
-		ID:        entity.ID,
-		FirstName: entity.FirstName,
-		LastName:  entity.LastName,
-		Email:     entity.Email,
-		Password:  entity.Password,
-		Role:      user.Role(entity.Role),
+		ID:              entity.ID,
+		FirstName:       entity.FirstName,
+		LastName:        entity.LastName,
+		Email:           entity.Email,
+		Password:        entity.Password,
+		Role:            user.Role(entity.Role),
+		SuspendedAt:     entity.SuspendedAt,
+		AvatarURL:       entity.AvatarURL,
+		EmailVerifiedAt: entity.EmailVerifiedAt,
 	}
 }
 func userEntityToDomain(entity entities.User) user.User {
 	return user.User{
-		ID:        entity.ID,
-		FirstName: entity.FirstName,
-		LastName:  entity.LastName,
-		Email:     entity.Email,
-		Password:  entity.Password,
-		Role:      user.Role(entity.Role),
+		ID:              entity.ID,
+		FirstName:       entity.FirstName,
+		LastName:        entity.LastName,
+		Email:           entity.Email,
+		Password:        entity.Password,
+		Role:            user.Role(entity.Role),
+		SuspendedAt:     entity.SuspendedAt,
+		AvatarURL:       entity.AvatarURL,
+		EmailVerifiedAt: entity.EmailVerifiedAt,
 	}
 }
 
@@ -37,5 +43,6 @@ func UserDomainToEntity(domainUser *user.User) *entities.User {
 		LastName:  domainUser.LastName,
 		Email:     domainUser.Email,
 		Password:  domainUser.Password,
+		Role:      uint8(domainUser.Role),
 	}
 }
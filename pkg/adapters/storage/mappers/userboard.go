@@ -3,21 +3,49 @@ package mappers
 import (
 	userboardrole "server/internal/user_board_role"
 	"server/pkg/adapters/storage/entities"
+	"server/pkg/fp"
 )
 
 func UserBoardRoleDomainToEntity(b *userboardrole.UserBoardRole) *entities.UserBoardRole {
 	return &entities.UserBoardRole{
-		UserID:   b.UserID,
-		BoardID:  b.BoardID,
-		UserRole: string(b.Role),
+		UserID:    b.UserID,
+		BoardID:   b.BoardID,
+		UserRole:  string(b.Role),
+		Inherited: b.Inherited,
 	}
 }
 func UserBoardRoleEntityToDomain(b entities.UserBoardRole) userboardrole.UserBoardRole {
 	u := UserEntityToDomain(&b.User)
 	return userboardrole.UserBoardRole{
-		ID:      b.ID,
-		User:    u,
-		BoardID: b.BoardID,
-		Role:    b.UserRole,
+		ID:        b.ID,
+		UserID:    b.UserID,
+		User:      u,
+		BoardID:   b.BoardID,
+		Role:      b.UserRole,
+		Inherited: b.Inherited,
+	}
+}
+
+func BatchUserBoardRoleEntitiesToDomain(ubrs []entities.UserBoardRole) []userboardrole.UserBoardRole {
+	return fp.Map(ubrs, UserBoardRoleEntityToDomain)
+}
+
+func OwnershipTransferDomainToEntity(t *userboardrole.OwnershipTransfer) *entities.OwnershipTransfer {
+	return &entities.OwnershipTransfer{
+		BoardID:       t.BoardID,
+		FromUserID:    t.FromUserID,
+		ToUserID:      t.ToUserID,
+		KeepOwnership: t.KeepOwnership,
+	}
+}
+
+func OwnershipTransferEntityToDomain(t entities.OwnershipTransfer) userboardrole.OwnershipTransfer {
+	return userboardrole.OwnershipTransfer{
+		ID:            t.ID,
+		BoardID:       t.BoardID,
+		FromUserID:    t.FromUserID,
+		ToUserID:      t.ToUserID,
+		KeepOwnership: t.KeepOwnership,
+		CreatedAt:     t.CreatedAt,
 	}
 }
@@ -0,0 +1,29 @@
+package mappers
+
+import (
+	"server/internal/verification"
+	"server/pkg/adapters/storage/entities"
+)
+
+func VerificationTokenEntityToDomain(e *entities.VerificationToken) *verification.Token {
+	return &verification.Token{
+		ID:        e.ID,
+		UserID:    e.UserID,
+		Purpose:   verification.Purpose(e.Purpose),
+		Hash:      e.Hash,
+		ExpiresAt: e.ExpiresAt,
+		UsedAt:    e.UsedAt,
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+func VerificationTokenDomainToEntity(t *verification.Token) *entities.VerificationToken {
+	return &entities.VerificationToken{
+		ID:        t.ID,
+		UserID:    t.UserID,
+		Purpose:   string(t.Purpose),
+		Hash:      t.Hash,
+		ExpiresAt: t.ExpiresAt,
+		UsedAt:    t.UsedAt,
+	}
+}
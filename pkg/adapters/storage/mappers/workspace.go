@@ -0,0 +1,50 @@
+package mappers
+
+import (
+	"server/internal/workspace"
+	"server/pkg/adapters/storage/entities"
+	"server/pkg/fp"
+)
+
+func WorkspaceDomainToEntity(w *workspace.Workspace) *entities.Workspace {
+	return &entities.Workspace{
+		Name:            w.Name,
+		Description:     w.Description,
+		CreatedByUserID: w.CreatedByUserID,
+	}
+}
+
+func WorkspaceEntityToDomain(w entities.Workspace) workspace.Workspace {
+	return workspace.Workspace{
+		ID:              w.ID,
+		Name:            w.Name,
+		Description:     w.Description,
+		CreatedByUserID: w.CreatedByUserID,
+		CreatedAt:       w.CreatedAt,
+	}
+}
+
+func WorkspaceMemberDomainToEntity(m *workspace.Member) *entities.WorkspaceMember {
+	return &entities.WorkspaceMember{
+		WorkspaceID: m.WorkspaceID,
+		UserID:      m.UserID,
+		Role:        string(m.Role),
+	}
+}
+
+func WorkspaceMemberEntityToDomain(m entities.WorkspaceMember) workspace.Member {
+	member := workspace.Member{
+		WorkspaceID: m.WorkspaceID,
+		UserID:      m.UserID,
+		Role:        workspace.Role(m.Role),
+		JoinedAt:    m.CreatedAt,
+	}
+	if m.User != nil {
+		member.User = UserEntityToDomain(m.User)
+	}
+	return member
+}
+
+func BatchWorkspaceMemberEntitiesToDomain(members []entities.WorkspaceMember) []workspace.Member {
+	return fp.Map(members, WorkspaceMemberEntityToDomain)
+}
@@ -63,6 +63,39 @@ func (r *notificationRepo) NotifBroadCasting(ctx context.Context, notif *notific
 	return nil
 }
 
+func (r *notificationRepo) NotifBoardManagers(ctx context.Context, notif *notification.Notification, boardID, userID uuid.UUID) error {
+	return r.notifBoardRoles(ctx, notif, boardID, userID, []string{"maintainer", "owner"})
+}
+
+func (r *notificationRepo) NotifBoardMembers(ctx context.Context, notif *notification.Notification, boardID, userID uuid.UUID) error {
+	return r.notifBoardRoles(ctx, notif, boardID, userID, nil)
+}
+
+// notifBoardRoles sends notif to the members of the board with one of roles, every member when roles is nil, but userID.
+func (r *notificationRepo) notifBoardRoles(ctx context.Context, notif *notification.Notification, boardID, userID uuid.UUID, roles []string) error {
+	query := r.db.WithContext(ctx).Where("board_id = ? AND user_id <> ?", boardID, userID)
+	if roles != nil {
+		query = query.Where("user_role IN ?", roles)
+	}
+	var userBoardRoles []entities.UserBoardRole
+	if err := query.Find(&userBoardRoles).Error; err != nil {
+		return notification.ErrFailedToCreateNotif
+	}
+
+	var notifs []entities.Notification
+	for _, obj := range userBoardRoles {
+		notif.UserBoardRoleID = obj.ID
+		notifs = append(notifs, *mappers.NotificationDomainToEntity(notif))
+	}
+	if len(notifs) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).Create(&notifs).Error; err != nil {
+		return notification.ErrFailedToCreateNotif
+	}
+	return nil
+}
+
 func (r *notificationRepo) CreateNotification(ctx context.Context, notif *notification.Notification) error {
 	var userBoardRole entities.UserBoardRole
 	//	TODO REMOVE REPEATED ONES
@@ -85,7 +118,7 @@ func (r *notificationRepo) GetUserUnseenNotifications(ctx context.Context, userI
 	result := r.db.WithContext(ctx).
 		Model(&entities.Notification{}).
 		Joins("LEFT JOIN user_board_roles ON notifications.user_board_role_id = user_board_roles.id").
-		Where("user_board_roles.user_id = ?", userID).
+		Where("user_board_roles.user_id = ? AND user_board_roles.deleted_at IS NULL", userID).
 		Find(&notifications)
 	if result.Error != nil {
 		return nil, result.Error
@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"context"
+	"server/internal/session"
+	"server/pkg/adapters/storage/entities"
+	"server/pkg/adapters/storage/mappers"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type sessionRepo struct {
+	db *gorm.DB
+}
+
+func NewSessionRepo(db *gorm.DB) session.Repo {
+	return &sessionRepo{db}
+}
+
+func (r *sessionRepo) Insert(ctx context.Context, s *session.Session) error {
+	e := mappers.SessionDomainToEntity(s)
+	if err := r.db.WithContext(ctx).Create(e).Error; err != nil {
+		return err
+	}
+	s.ID = e.ID
+	s.CreatedAt = e.CreatedAt
+	return nil
+}
+
+func (r *sessionRepo) GetByID(ctx context.Context, id uuid.UUID) (*session.Session, error) {
+	var sessions []entities.Session
+	if err := r.db.WithContext(ctx).Where("id = ?", id).Limit(1).Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		return nil, nil
+	}
+	return mappers.SessionEntityToDomain(&sessions[0]), nil
+}
+
+func (r *sessionRepo) GetActive(ctx context.Context, userID uuid.UUID) ([]session.Session, error) {
+	var sessions []entities.Session
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("last_used_at DESC").Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+	return mappers.BatchSessionEntityToDomain(sessions), nil
+}
+
+func (r *sessionRepo) Rotate(ctx context.Context, s *session.Session, oldTokenID uuid.UUID) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&entities.Session{}).
+		Where("id = ? AND token_id = ? AND revoked_at IS NULL", s.ID, oldTokenID).
+		Updates(map[string]interface{}{
+			"token_id":     s.TokenID,
+			"ip":           s.IP,
+			"expires_at":   s.ExpiresAt,
+			"last_used_at": s.LastUsedAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *sessionRepo) Revoke(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&entities.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).Update("revoked_at", time.Now()).Error
+}
+
+func (r *sessionRepo) RevokeAll(ctx context.Context, userID uuid.UUID, except *uuid.UUID) error {
+	query := r.db.WithContext(ctx).Model(&entities.Session{}).Where("user_id = ? AND revoked_at IS NULL", userID)
+	if except != nil {
+		query = query.Where("id <> ?", *except)
+	}
+	return query.Update("revoked_at", time.Now()).Error
+}
@@ -3,8 +3,11 @@ package storage
 import (
 	"fmt"
 	"server/config"
+	"server/internal/column"
 	"server/pkg/adapters/storage/entities"
+	"server/pkg/rank"
 
+	"github.com/google/uuid"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -21,13 +24,68 @@ func AddExtension(db *gorm.DB) error {
 
 func Migrate(db *gorm.DB) error {
 	migrator := db.Migrator()
+	// columns created before kinds existed are told apart from done ones only by their name
+	markDoneColumns := migrator.HasTable(&entities.Column{}) && !migrator.HasColumn(&entities.Column{}, "kind")
+	// rows created before ranks existed keep the order they had
+	rankColumns := migrator.HasTable(&entities.Column{}) && !migrator.HasColumn(&entities.Column{}, "rank")
+	rankTasks := migrator.HasTable(&entities.Task{}) && !migrator.HasColumn(&entities.Task{}, "rank")
+	// accounts registered before verification existed are trusted as they are
+	verifyUsers := migrator.HasTable(&entities.User{}) && !migrator.HasColumn(&entities.User{}, "email_verified_at")
 
 	err := migrator.AutoMigrate(&entities.User{},
 		&entities.Board{}, &entities.UserBoardRole{},
-		&entities.Task{}, &entities.TaskDependency{}, &entities.Board{}, &entities.UserBoardRole{}, &entities.Column{}, &entities.Notification{},
+		&entities.Task{}, &entities.TaskDependency{}, &entities.Board{}, &entities.UserBoardRole{}, &entities.Column{}, &entities.ColumnTransition{}, &entities.Notification{}, &entities.OwnershipTransfer{}, &entities.Invitation{}, &entities.InviteLink{}, &entities.BoardTemplate{}, &entities.TemplateColumn{}, &entities.TemplateTask{}, &entities.Workspace{}, &entities.WorkspaceMember{}, &entities.CustomRole{}, &entities.VerificationToken{}, &entities.Session{}, &entities.AccessToken{}, &entities.TwoFactor{}, &entities.RecoveryCode{},
 		entities.Comment{})
 	if err != nil {
 		return err
 	}
+
+	if markDoneColumns {
+		if err := db.Model(&entities.Column{}).Where("name = ?", column.DoneDefaultColumn).
+			Update("kind", string(column.KindDone)).Error; err != nil {
+			return err
+		}
+	}
+	if verifyUsers {
+		if err := db.Model(&entities.User{}).Where("1 = 1").Update("email_verified_at", gorm.Expr("created_at")).Error; err != nil {
+			return err
+		}
+	}
+	if rankColumns {
+		if err := backfillRanks(db, &entities.Column{}, "board_id", "order_num, created_at"); err != nil {
+			return err
+		}
+	}
+	if rankTasks {
+		if err := backfillRanks(db, &entities.Task{}, "column_id", `"order", created_at`); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backfillRanks gives every group of rows evenly spread ranks following their old order.
+func backfillRanks(db *gorm.DB, model interface{}, groupColumn, orderBy string) error {
+	var rows []struct {
+		ID      uuid.UUID
+		GroupID uuid.UUID
+	}
+	if err := db.Unscoped().Model(model).Select("id, " + groupColumn + " AS group_id").
+		Order(groupColumn + ", " + orderBy).Scan(&rows).Error; err != nil {
+		return err
+	}
+
+	for start := 0; start < len(rows); {
+		end := start
+		for end < len(rows) && rows[end].GroupID == rows[start].GroupID {
+			end++
+		}
+		for i, r := range rank.Spread(end - start) {
+			if err := db.Unscoped().Model(model).Where("id = ?", rows[start+i].ID).Update("rank", r).Error; err != nil {
+				return err
+			}
+		}
+		start = end
+	}
 	return nil
 }
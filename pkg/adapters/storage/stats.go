@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"context"
+	"server/internal/stats"
+	"server/pkg/adapters/storage/entities"
+
+	"gorm.io/gorm"
+)
+
+type statsRepo struct {
+	db *gorm.DB
+}
+
+func NewStatsRepo(db *gorm.DB) stats.Repo {
+	return &statsRepo{db}
+}
+
+func (r *statsRepo) Get(ctx context.Context) (*stats.Stats, error) {
+	var s stats.Stats
+	db := r.db.WithContext(ctx)
+	counts := []struct {
+		query *gorm.DB
+		dest  *int64
+	}{
+		{db.Model(&entities.User{}), &s.Users},
+		{db.Model(&entities.User{}).Where("suspended_at IS NOT NULL"), &s.SuspendedUsers},
+		{db.Model(&entities.Board{}), &s.Boards},
+		{db.Model(&entities.Board{}).Where("archived_at IS NOT NULL"), &s.ArchivedBoards},
+		{db.Model(&entities.Workspace{}), &s.Workspaces},
+		{db.Model(&entities.Task{}), &s.Tasks},
+		{db.Model(&entities.Comment{}), &s.Comments},
+	}
+	for _, c := range counts {
+		if err := c.query.Count(c.dest).Error; err != nil {
+			return nil, err
+		}
+	}
+	return &s, nil
+}
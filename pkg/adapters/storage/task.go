@@ -5,9 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"gorm.io/gorm/clause"
+	"server/internal/column"
 	"server/internal/task"
 	"server/pkg/adapters/storage/entities"
 	"server/pkg/adapters/storage/mappers"
+	"server/pkg/rank"
+	"sort"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -46,6 +50,11 @@ func (r *taskRepo) Insert(ctx context.Context, t *task.Task) error {
 		return err
 	}
 	taskEntity.Order = maxTaskColumnOrder
+	lastRank, err := r.lastRank(ctx, t.ColumnID, uuid.Nil)
+	if err != nil {
+		return err
+	}
+	taskEntity.Rank = rank.Between(lastRank, "")
 	if err := r.db.WithContext(ctx).Save(&taskEntity).Error; err != nil {
 		return err
 	}
@@ -60,6 +69,11 @@ func (r *taskRepo) Insert(ctx context.Context, t *task.Task) error {
 		if len(existingTasks) != len(t.DependsOnTaskIDs) {
 			return task.ErrFailedToFindDependsOnTasks
 		}
+		for _, existingTask := range existingTasks {
+			if existingTask.BoardID != t.BoardID {
+				return fmt.Errorf("%w: task %v", task.ErrCrossBoardDependency, existingTask.ID)
+			}
+		}
 
 		var taskDependencies []entities.TaskDependency
 		for _, dependencyID := range t.DependsOnTaskIDs {
@@ -76,72 +90,125 @@ func (r *taskRepo) Insert(ctx context.Context, t *task.Task) error {
 	return nil
 }
 
-func (r *taskRepo) AddDependency(ctx context.Context, t *task.Task) error {
-	var errs error
-
-	var existingTasks []entities.Task
-	if err := r.db.Where("id IN ?", t.DependsOnTaskIDs).Find(&existingTasks).Error; err != nil {
+func (r *taskRepo) InsertBatch(ctx context.Context, tasks []task.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	lastRanks := make(map[uuid.UUID]string)
+	taskEntities := make([]entities.Task, len(tasks))
+	for i := range tasks {
+		t := &tasks[i]
+		taskEntity := mappers.TaskDomainToEntity(t)
+		taskEntity.ID = t.ID
+		taskEntity.Order = t.Order
+		taskEntity.Rank = t.Rank
+		if taskEntity.Rank == "" {
+			lastRank, ok := lastRanks[t.ColumnID]
+			if !ok {
+				var err error
+				if lastRank, err = r.lastRank(ctx, t.ColumnID, uuid.Nil); err != nil {
+					return err
+				}
+			}
+			taskEntity.Rank = rank.Between(lastRank, "")
+			lastRanks[t.ColumnID] = taskEntity.Rank
+		}
+		taskEntities[i] = *taskEntity
+	}
+	// one statement, so subtasks may come before their parent
+	if err := r.db.WithContext(ctx).Create(&taskEntities).Error; err != nil {
 		return err
 	}
-
-	if len(existingTasks) != len(t.DependsOnTaskIDs) {
-		return task.ErrFailedToFindDependsOnTasks
+	for i := range tasks {
+		tasks[i].ID = taskEntities[i].ID
+		tasks[i].Rank = taskEntities[i].Rank
 	}
+	return nil
+}
 
-	// Check for circular dependencies
-	for _, dependsOnID := range t.DependsOnTaskIDs {
-		if r.CheckCircularDependency(t.ID, dependsOnID) {
-			errs = errors.Join(errs, fmt.Errorf("circular dependency detected with task %v", dependsOnID))
+func (r *taskRepo) InsertDependencies(ctx context.Context, dependencies []task.TaskDependency) error {
+	if len(dependencies) == 0 {
+		return nil
+	}
+	taskDependencies := make([]entities.TaskDependency, len(dependencies))
+	for i, d := range dependencies {
+		taskDependencies[i] = entities.TaskDependency{
+			DependentTaskID:  d.DependentTaskID,
+			DependencyTaskID: d.DependencyTaskID,
 		}
 	}
-	if errs != nil {
-		return errors.Join(task.ErrCircularDependency, errs)
+	if err := r.db.WithContext(ctx).Create(&taskDependencies).Error; err != nil {
+		return errors.Join(task.ErrFailedToCreateTaskDependencies, err)
 	}
+	return nil
+}
+
+func (r *taskRepo) AddDependency(ctx context.Context, t *task.Task) error {
 	// Retrieve the main task entity
 	var tEntity entities.Task
-	fmt.Println(t.ID)
 	if err := r.db.WithContext(ctx).Model(&entities.Task{}).Where("id = ?", t.ID).First(&tEntity).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return task.ErrTaskNotFound
 		}
 		return err
 	}
-	// TO DO : Bulk
+
+	var existingTasks []entities.Task
+	if err := r.db.WithContext(ctx).Where("id IN ?", t.DependsOnTaskIDs).Find(&existingTasks).Error; err != nil {
+		return err
+	}
+
+	if len(existingTasks) != len(t.DependsOnTaskIDs) {
+		return task.ErrFailedToFindDependsOnTasks
+	}
 	for _, existingTask := range existingTasks {
-		if err := r.db.WithContext(ctx).Model(&tEntity).Association("DependsOn").Append(&existingTask); err != nil {
-			return fmt.Errorf("failed to add dependency %v: %w", existingTask.ID, err)
+		if existingTask.BoardID != tEntity.BoardID {
+			return fmt.Errorf("%w: task %v", task.ErrCrossBoardDependency, existingTask.ID)
 		}
 	}
 
-	return nil
-}
+	// Check for circular dependencies against the board graph loaded once
+	graph, err := r.boardDependencyGraph(ctx, tEntity.BoardID)
+	if err != nil {
+		return err
+	}
+	for _, dependsOnID := range t.DependsOnTaskIDs {
+		if cycle := task.FindDependencyCycle(graph, t.ID, dependsOnID); cycle != nil {
+			return &task.CircularDependencyError{Path: cycle}
+		}
+	}
 
-// CheckCircularDependency using Depth First Search
-func (r *taskRepo) CheckCircularDependency(taskID, dependencyID uuid.UUID) bool {
-	visited := make(map[uuid.UUID]bool)
-	var dfs func(current uuid.UUID) bool
+	var taskDependencies []entities.TaskDependency
+	for _, dependsOnID := range t.DependsOnTaskIDs {
+		taskDependencies = append(taskDependencies, entities.TaskDependency{
+			DependentTaskID:  tEntity.ID,
+			DependencyTaskID: dependsOnID,
+		})
+	}
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&taskDependencies).Error; err != nil {
+		return errors.Join(task.ErrFailedToCreateTaskDependencies, err)
+	}
 
-	dfs = func(current uuid.UUID) bool {
-		if current == taskID {
-			return true
-		}
-		if visited[current] {
-			return false
-		}
-		visited[current] = true
+	return nil
+}
 
-		var dependencies []entities.Task
-		r.db.Model(&entities.Task{ID: current}).Association("DependsOn").Find(&dependencies)
+// boardDependencyGraph loads every dependency edge of a board in one query,
+// keyed by dependent task and listing the tasks it depends on.
+func (r *taskRepo) boardDependencyGraph(ctx context.Context, boardID uuid.UUID) (map[uuid.UUID][]uuid.UUID, error) {
+	var edges []entities.TaskDependency
+	err := r.db.WithContext(ctx).
+		Joins("JOIN tasks ON tasks.id = task_dependencies.dependent_task_id").
+		Where("tasks.board_id = ? AND tasks.deleted_at IS NULL", boardID).
+		Find(&edges).Error
+	if err != nil {
+		return nil, task.ErrFailedToFetchTasks
+	}
 
-		for _, dep := range dependencies {
-			if dfs(dep.ID) {
-				return true
-			}
-		}
-		return false
+	graph := make(map[uuid.UUID][]uuid.UUID)
+	for _, e := range edges {
+		graph[e.DependentTaskID] = append(graph[e.DependentTaskID], e.DependencyTaskID)
 	}
-	dependentCircle := dfs(dependencyID)
-	return dependentCircle
+	return graph, nil
 }
 
 func (r *taskRepo) GetBoardID(ctx context.Context, id uuid.UUID) (*uuid.UUID, error) {
@@ -184,13 +251,13 @@ func (r *taskRepo) UpdateTaskColumnByID(ctx context.Context, taskID uuid.UUID, c
 		return nil, task.ErrTaskNotFound
 	}
 
-	// Load the new column to check its name
+	// Load the new column to check its kind
 	var newColumn entities.Column
 	if err := r.db.WithContext(ctx).First(&newColumn, "id = ?", colID).Error; err != nil {
 		return nil, task.ErrColumnNotFound
 	}
-	// If the new column name is "done", delete relevant TaskDependency records
-	if newColumn.Name == "done" {
+	// If the new column is a done column, delete relevant TaskDependency records
+	if newColumn.Kind == string(column.KindDone) {
 		// Check if there are any dependencies where this task is a dependent
 		var dependencyCount int64
 		if err := r.db.WithContext(ctx).Model(&entities.TaskDependency{}).Where("dependent_task_id = ?", t.ID).Count(&dependencyCount).Error; err != nil {
@@ -213,6 +280,13 @@ func (r *taskRepo) UpdateTaskColumnByID(ctx context.Context, taskID uuid.UUID, c
 		return nil, err
 	}
 
+	if t.ColumnID != colID {
+		lastRank, err := r.lastRank(ctx, colID, t.ID)
+		if err != nil {
+			return nil, err
+		}
+		t.Rank = rank.Between(lastRank, "")
+	}
 	t.ColumnID = colID
 	// Save the updated task and its subtasks
 	if err := r.db.WithContext(ctx).Save(&t).Error; err != nil {
@@ -245,33 +319,400 @@ func (r *taskRepo) ReorderTasks(ctx context.Context, colID uuid.UUID, newOrder m
 		}
 	}
 
-	var maxOrder uint
-	for _, t := range tasks {
-		if t.Order > maxOrder {
-			maxOrder = t.Order
+	// order has no unique index for tasks, so every task is written once with its rank
+	ranks := rank.Spread(len(tasks))
+	for i := range tasks {
+		t := &tasks[i]
+		newOrderNum := newOrder[t.ID]
+		updates := map[string]interface{}{"order": newOrderNum, "rank": ranks[newOrderNum-1]}
+		if err := r.db.WithContext(ctx).Model(t).Updates(updates).Error; err != nil {
+			return nil, task.ErrFailedToUpdateTask
 		}
 	}
-	tempOrder := maxOrder + 1
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].Order < tasks[j].Order })
 
-	for _, t := range tasks {
-		if err := r.db.WithContext(ctx).Model(&t).Update("order", tempOrder).Error; err != nil {
-			return nil, task.ErrFailedToUpdateTask
+	domainTasks := mappers.BatchTaskEntitiesToDomain(tasks)
+	return domainTasks, nil
+
+}
+
+func (r *taskRepo) Update(ctx context.Context, taskID uuid.UUID, fields *task.UpdateFields) (*task.Task, error) {
+	updates := mappers.TaskUpdateFieldsToColumns(fields)
+
+	result := r.db.WithContext(ctx).Model(&entities.Task{}).Where("id = ?", taskID).Updates(updates)
+	if result.Error != nil {
+		return nil, errors.Join(task.ErrFailedToUpdateTask, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, task.ErrTaskNotFound
+	}
+
+	var t entities.Task
+	if err := r.db.WithContext(ctx).First(&t, "id = ?", taskID).Error; err != nil {
+		return nil, err
+	}
+	domainTask := mappers.TaskEntityToDomain(t)
+	return &domainTask, nil
+}
+
+// subtreeIDs returns the id of the given task and all of its subtasks at any depth.
+// With deleted set it walks the trash instead, following only the tasks deleted at the same time as the root.
+func (r *taskRepo) subtreeIDs(ctx context.Context, rootID uuid.UUID, deleted bool) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	query := `
+		WITH RECURSIVE subtree AS (
+			SELECT id, deleted_at FROM tasks WHERE id = ? AND deleted_at IS NULL
+			UNION ALL
+			SELECT t.id, t.deleted_at FROM tasks t JOIN subtree s ON t.parent_id = s.id WHERE t.deleted_at IS NULL
+		)
+		SELECT id FROM subtree`
+	if deleted {
+		query = `
+		WITH RECURSIVE subtree AS (
+			SELECT id, deleted_at FROM tasks WHERE id = ? AND deleted_at IS NOT NULL
+			UNION ALL
+			SELECT t.id, t.deleted_at FROM tasks t JOIN subtree s ON t.parent_id = s.id WHERE t.deleted_at = s.deleted_at
+		)
+		SELECT id FROM subtree`
+	}
+	if err := r.db.WithContext(ctx).Raw(query, rootID).Scan(&ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *taskRepo) Delete(ctx context.Context, taskID uuid.UUID) error {
+	ids, err := r.subtreeIDs(ctx, taskID, false)
+	if err != nil {
+		return errors.Join(task.ErrFailedToDeleteTask, err)
+	}
+	if len(ids) == 0 {
+		return task.ErrTaskNotFound
+	}
+
+	// the whole subtree shares one deletion time so it can be restored together
+	now := time.Now()
+	if err := r.db.WithContext(ctx).Model(&entities.Task{}).Where("id IN ?", ids).Update("deleted_at", now).Error; err != nil {
+		return errors.Join(task.ErrFailedToDeleteTask, err)
+	}
+
+	if err := r.db.WithContext(ctx).Where("dependent_task_id IN ? OR dependency_task_id IN ?", ids, ids).Delete(&entities.TaskDependency{}).Error; err != nil {
+		return errors.Join(task.ErrFailedToDeleteTask, err)
+	}
+	return nil
+}
+
+func (r *taskRepo) GetDeletedByID(ctx context.Context, id uuid.UUID) (*task.Task, error) {
+	var t entities.Task
+
+	err := r.db.WithContext(ctx).Unscoped().Model(&entities.Task{}).Where("id = ?", id).First(&t).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
 		}
-		tempOrder++
+		return nil, err
 	}
+	domainTask := mappers.TaskEntityToDomain(t)
+	return &domainTask, nil
+}
+
+func (r *taskRepo) GetDeletedByBoardID(ctx context.Context, boardID uuid.UUID, limit, offset uint) (tasks []task.Task, total uint, err error) {
+	var int64Total int64
+	var taskEntities []entities.Task
 
-	for _, t := range tasks {
-		newOrderNum, exists := newOrder[t.ID]
-		if !exists {
-			continue
+	query := r.db.WithContext(ctx).Unscoped().Model(&entities.Task{}).
+		Where("board_id = ? AND deleted_at IS NOT NULL", boardID)
+
+	if err := query.Count(&int64Total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query = query.Order("deleted_at DESC")
+	if offset > 0 {
+		query = query.Offset(int(offset))
+	}
+	if limit > 0 {
+		query = query.Limit(int(limit))
+	}
+	if err := query.Find(&taskEntities).Error; err != nil {
+		return nil, 0, task.ErrFailedToFetchTasks
+	}
+
+	return mappers.BatchTaskEntitiesToDomain(taskEntities), uint(int64Total), nil
+}
+
+func (r *taskRepo) Restore(ctx context.Context, t *task.Task) error {
+	ids, err := r.subtreeIDs(ctx, t.ID, true)
+	if err != nil {
+		return errors.Join(task.ErrFailedToRestoreTask, err)
+	}
+	if len(ids) == 0 {
+		return task.ErrTaskNotDeleted
+	}
+
+	err = r.db.WithContext(ctx).Unscoped().Model(&entities.Task{}).Where("id IN ?", ids).
+		Updates(map[string]interface{}{"deleted_at": nil, "column_id": t.ColumnID}).Error
+	if err != nil {
+		return errors.Join(task.ErrFailedToRestoreTask, err)
+	}
+	t.DeletedAt = nil
+	return nil
+}
+
+func (r *taskRepo) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", before).Delete(&entities.Task{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *taskRepo) GetSubtree(ctx context.Context, rootID uuid.UUID) ([]task.Task, error) {
+	ids, err := r.subtreeIDs(ctx, rootID, false)
+	if err != nil {
+		return nil, task.ErrFailedToFetchTasks
+	}
+	if len(ids) == 0 {
+		return nil, task.ErrTaskNotFound
+	}
+
+	var tasks []entities.Task
+	if err := r.db.WithContext(ctx).Preload("Column").Where("id IN ?", ids).Order("rank ASC").Find(&tasks).Error; err != nil {
+		return nil, task.ErrFailedToFetchTasks
+	}
+	return mappers.BatchTaskEntitiesToDomain(tasks), nil
+}
+
+func (r *taskRepo) RemoveDependency(ctx context.Context, t *task.Task) error {
+	result := r.db.WithContext(ctx).
+		Where("dependent_task_id = ? AND dependency_task_id IN ?", t.ID, t.DependsOnTaskIDs).
+		Delete(&entities.TaskDependency{})
+	if result.Error != nil {
+		return errors.Join(task.ErrFailedToRemoveDependency, result.Error)
+	}
+	// the same id may be sent twice, it only removes one row
+	unique := make(map[uuid.UUID]struct{}, len(t.DependsOnTaskIDs))
+	for _, id := range t.DependsOnTaskIDs {
+		unique[id] = struct{}{}
+	}
+	if result.RowsAffected != int64(len(unique)) {
+		return task.ErrDependencyNotFound
+	}
+	return nil
+}
+
+func (r *taskRepo) GetDependencies(ctx context.Context, taskID uuid.UUID, transitive bool) (dependsOn []task.Task, dependentBy []task.Task, err error) {
+	if !transitive {
+		var t entities.Task
+		if err := r.db.WithContext(ctx).Preload("DependsOn").Preload("DependentBy").First(&t, "id = ?", taskID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, nil, task.ErrTaskNotFound
+			}
+			return nil, nil, err
 		}
-		if err := r.db.WithContext(ctx).Model(&t).Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}, {Name: "title"}, {Name: "order"}}}).Update("order", newOrderNum).Error; err != nil {
-			return nil, task.ErrFailedToUpdateTask
+		return mappers.BatchTaskEntitiesToDomain(t.DependsOn), mappers.BatchTaskEntitiesToDomain(t.DependentBy), nil
+	}
+
+	// UNION drops rows already seen so the walk stops even if the stored graph has a loop
+	dependsOnQuery := `
+		WITH RECURSIVE deps AS (
+			SELECT dependency_task_id AS id FROM task_dependencies WHERE dependent_task_id = ?
+			UNION
+			SELECT td.dependency_task_id FROM task_dependencies td JOIN deps d ON td.dependent_task_id = d.id
+		)
+		SELECT id FROM deps`
+	dependentByQuery := `
+		WITH RECURSIVE deps AS (
+			SELECT dependent_task_id AS id FROM task_dependencies WHERE dependency_task_id = ?
+			UNION
+			SELECT td.dependent_task_id FROM task_dependencies td JOIN deps d ON td.dependency_task_id = d.id
+		)
+		SELECT id FROM deps`
+
+	dependsOn, err = r.tasksByRawIDs(ctx, dependsOnQuery, taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	dependentBy, err = r.tasksByRawIDs(ctx, dependentByQuery, taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return dependsOn, dependentBy, nil
+}
+
+// tasksByRawIDs loads the tasks whose ids are selected by query.
+func (r *taskRepo) tasksByRawIDs(ctx context.Context, query string, args ...interface{}) ([]task.Task, error) {
+	var ids []uuid.UUID
+	if err := r.db.WithContext(ctx).Raw(query, args...).Scan(&ids).Error; err != nil {
+		return nil, task.ErrFailedToFetchTasks
+	}
+	if len(ids) == 0 {
+		return []task.Task{}, nil
+	}
+
+	var tasks []entities.Task
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&tasks).Error; err != nil {
+		return nil, task.ErrFailedToFetchTasks
+	}
+	return mappers.BatchTaskEntitiesToDomain(tasks), nil
+}
+
+func (r *taskRepo) GetBoardDependencyGraph(ctx context.Context, boardID uuid.UUID) (*task.DependencyGraph, error) {
+	var tasks []entities.Task
+	if err := r.db.WithContext(ctx).Preload("Column").Where("board_id = ?", boardID).Find(&tasks).Error; err != nil {
+		return nil, task.ErrFailedToFetchTasks
+	}
+
+	var edges []entities.TaskDependency
+	err := r.db.WithContext(ctx).
+		Joins("JOIN tasks ON tasks.id = task_dependencies.dependent_task_id").
+		Where("tasks.board_id = ? AND tasks.deleted_at IS NULL", boardID).
+		Find(&edges).Error
+	if err != nil {
+		return nil, task.ErrFailedToFetchTasks
+	}
+
+	return &task.DependencyGraph{
+		Nodes: mappers.BatchTaskEntitiesToDomain(tasks),
+		Edges: mappers.BatchTaskDependencyEntitiesToDomain(edges),
+	}, nil
+}
+
+// CountColumnTasks locks the column row before counting, so a concurrent transaction putting a task
+// in the same column waits for this one to finish and then counts its task too.
+func (r *taskRepo) CountColumnTasks(ctx context.Context, colID uuid.UUID) (int64, error) {
+	if err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").First(&entities.Column{}, "id = ?", colID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, column.ErrColumnNotFound
 		}
-		t.Order = newOrderNum
+		return 0, err
 	}
 
-	domainTasks := mappers.BatchTaskEntitiesToDomain(tasks)
-	return domainTasks, nil
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&entities.Task{}).
+		Where("column_id = ? AND parent_id IS NULL", colID).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *taskRepo) Move(ctx context.Context, taskID, colID uuid.UUID, afterID, beforeID *uuid.UUID) (*task.Task, error) {
+	var t entities.Task
+	if err := r.db.WithContext(ctx).First(&t, "id = ?", taskID).Error; err != nil {
+		return nil, task.ErrTaskNotFound
+	}
+	if t.ColumnID != colID {
+		return nil, task.ErrColumnNotFound
+	}
+
+	newRank, err := r.rankBetween(ctx, taskID, colID, afterID, beforeID)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.WithContext(ctx).Model(&t).Update("rank", newRank).Error; err != nil {
+		return nil, task.ErrFailedToUpdateTask
+	}
+	if rank.TooLong(newRank) {
+		if err := r.rebalanceColumn(ctx, colID); err != nil {
+			return nil, err
+		}
+		if err := r.db.WithContext(ctx).First(&t, "id = ?", taskID).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	domainTask := mappers.TaskEntityToDomain(t)
+	return &domainTask, nil
+}
+
+// rankBetween finds the ranks around the wanted position of a task and returns one between them.
+func (r *taskRepo) rankBetween(ctx context.Context, taskID, colID uuid.UUID, afterID, beforeID *uuid.UUID) (string, error) {
+	neighbourRank := func(id uuid.UUID) (string, error) {
+		var neighbour entities.Task
+		if err := r.db.WithContext(ctx).Where("id = ? AND column_id = ?", id, colID).First(&neighbour).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return "", task.ErrInvalidPosition
+			}
+			return "", err
+		}
+		return neighbour.Rank, nil
+	}
+
+	var (
+		prev, next string
+		err        error
+	)
+	switch {
+	case afterID != nil && beforeID != nil:
+		if prev, err = neighbourRank(*afterID); err != nil {
+			return "", err
+		}
+		if next, err = neighbourRank(*beforeID); err != nil {
+			return "", err
+		}
+		if prev >= next {
+			return "", task.ErrInvalidPosition
+		}
+	case afterID != nil:
+		if prev, err = neighbourRank(*afterID); err != nil {
+			return "", err
+		}
+		next, err = r.adjacentRank(ctx, colID, taskID, prev, true)
+	case beforeID != nil:
+		if next, err = neighbourRank(*beforeID); err != nil {
+			return "", err
+		}
+		prev, err = r.adjacentRank(ctx, colID, taskID, next, false)
+	default:
+		prev, err = r.lastRank(ctx, colID, taskID)
+	}
+	if err != nil {
+		return "", err
+	}
+	return rank.Between(prev, next), nil
+}
 
+// adjacentRank returns the rank right after (or right before) the given one in a column, "" at the ends.
+func (r *taskRepo) adjacentRank(ctx context.Context, colID, excludeID uuid.UUID, of string, after bool) (string, error) {
+	query := r.db.WithContext(ctx).Model(&entities.Task{}).Where("column_id = ? AND id <> ?", colID, excludeID)
+	if after {
+		query = query.Where("rank > ?", of).Order("rank ASC")
+	} else {
+		query = query.Where("rank < ?", of).Order("rank DESC")
+	}
+	var ranks []string
+	if err := query.Limit(1).Pluck("rank", &ranks).Error; err != nil {
+		return "", err
+	}
+	if len(ranks) == 0 {
+		return "", nil
+	}
+	return ranks[0], nil
+}
+
+func (r *taskRepo) lastRank(ctx context.Context, colID, excludeID uuid.UUID) (string, error) {
+	var ranks []string
+	if err := r.db.WithContext(ctx).Model(&entities.Task{}).
+		Where("column_id = ? AND id <> ?", colID, excludeID).
+		Order("rank DESC").Limit(1).Pluck("rank", &ranks).Error; err != nil {
+		return "", err
+	}
+	if len(ranks) == 0 {
+		return "", nil
+	}
+	return ranks[0], nil
+}
+
+// rebalanceColumn spreads the ranks of a column out again once they got too long.
+func (r *taskRepo) rebalanceColumn(ctx context.Context, colID uuid.UUID) error {
+	var ids []uuid.UUID
+	if err := r.db.WithContext(ctx).Model(&entities.Task{}).Where("column_id = ?", colID).
+		Order("rank ASC").Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+	for i, newRank := range rank.Spread(len(ids)) {
+		if err := r.db.WithContext(ctx).Model(&entities.Task{}).Where("id = ?", ids[i]).Update("rank", newRank).Error; err != nil {
+			return task.ErrFailedToUpdateTask
+		}
+	}
+	return nil
 }
@@ -0,0 +1,122 @@
+package storage
+
+import (
+	"context"
+	twofactor "server/internal/two_factor"
+	"server/pkg/adapters/storage/entities"
+	"server/pkg/adapters/storage/mappers"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type twoFactorRepo struct {
+	db *gorm.DB
+}
+
+func NewTwoFactorRepo(db *gorm.DB) twofactor.Repo {
+	return &twoFactorRepo{db}
+}
+
+func (r *twoFactorRepo) Get(ctx context.Context, userID uuid.UUID) (*twofactor.TwoFactor, error) {
+	var setups []entities.TwoFactor
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Limit(1).Find(&setups).Error; err != nil {
+		return nil, err
+	}
+	if len(setups) == 0 {
+		return nil, nil
+	}
+	return mappers.TwoFactorEntityToDomain(&setups[0]), nil
+}
+
+func (r *twoFactorRepo) Save(ctx context.Context, tf *twofactor.TwoFactor) error {
+	e := mappers.TwoFactorDomainToEntity(tf)
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"secret", "enabled_at", "last_step"}),
+	}).Create(e).Error
+	if err != nil {
+		return err
+	}
+	tf.CreatedAt = e.CreatedAt
+	return nil
+}
+
+func (r *twoFactorRepo) Delete(ctx context.Context, userID uuid.UUID) error {
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&entities.RecoveryCode{}).Error; err != nil {
+		return err
+	}
+	return r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&entities.TwoFactor{}).Error
+}
+
+func (r *twoFactorRepo) UseStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	// the condition and the update are one statement so a code can not be used twice concurrently
+	result := r.db.WithContext(ctx).Model(&entities.TwoFactor{}).
+		Where("user_id = ? AND last_step < ?", userID, step).
+		Update("last_step", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *twoFactorRepo) StartChallenge(ctx context.Context, userID, challengeID uuid.UUID, since time.Time) error {
+	// one statement, so a login running next to wrong codes can not reset them
+	return r.db.WithContext(ctx).Model(&entities.TwoFactor{}).Where("user_id = ?", userID).
+		Updates(map[string]interface{}{
+			"challenge_id":         challengeID,
+			"challenge_attempts":   gorm.Expr("CASE WHEN challenge_started_at > ? THEN challenge_attempts ELSE 0 END", since),
+			"challenge_started_at": gorm.Expr("CASE WHEN challenge_started_at > ? THEN challenge_started_at ELSE ? END", since, time.Now()),
+		}).Error
+}
+
+func (r *twoFactorRepo) UseChallengeAttempt(ctx context.Context, userID, challengeID uuid.UUID, max uint) (bool, error) {
+	// one statement, so concurrent attempts can not go over max together
+	result := r.db.WithContext(ctx).Model(&entities.TwoFactor{}).
+		Where("user_id = ? AND challenge_id = ? AND challenge_attempts < ?", userID, challengeID, max).
+		Update("challenge_attempts", gorm.Expr("challenge_attempts + 1"))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *twoFactorRepo) EndChallenge(ctx context.Context, userID, challengeID uuid.UUID) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&entities.TwoFactor{}).
+		Where("user_id = ? AND challenge_id = ?", userID, challengeID).
+		Updates(map[string]interface{}{"challenge_id": nil, "challenge_attempts": 0, "challenge_started_at": nil})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *twoFactorRepo) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, hashes []string) error {
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&entities.RecoveryCode{}).Error; err != nil {
+		return err
+	}
+	codes := make([]entities.RecoveryCode, len(hashes))
+	for i, h := range hashes {
+		codes[i] = entities.RecoveryCode{UserID: userID, Hash: h}
+	}
+	return r.db.WithContext(ctx).Create(&codes).Error
+}
+
+func (r *twoFactorRepo) UseRecoveryCode(ctx context.Context, userID uuid.UUID, hash string) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&entities.RecoveryCode{}).
+		Where("user_id = ? AND hash = ? AND used_at IS NULL", userID, hash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *twoFactorRepo) CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (uint, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entities.RecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).Count(&count).Error
+	return uint(count), err
+}
@@ -3,12 +3,14 @@ package storage
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/google/uuid"
 	"server/internal/user"
 	"server/pkg/adapters/storage/entities"
 	"server/pkg/adapters/storage/mappers"
 	"server/pkg/utils"
 	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -60,3 +62,95 @@ func (r *userRepo) GetByEmail(ctx context.Context, email string) (*user.User, er
 	}
 	return mappers.UserEntityToDomain(&user), nil
 }
+
+func (r *userRepo) GetAll(ctx context.Context, limit, offset uint) ([]user.User, uint, error) {
+	var users []entities.User
+	var total int64
+	if err := r.db.WithContext(ctx).Model(&entities.User{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query := r.db.WithContext(ctx).Model(&entities.User{}).Order("created_at DESC")
+	if offset > 0 {
+		query = query.Offset(int(offset))
+	}
+	if limit > 0 {
+		query = query.Limit(int(limit))
+	}
+	if err := query.Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+	return mappers.BatchUserEntityToDomain(users), uint(total), nil
+}
+
+func (r *userRepo) SetRole(ctx context.Context, id uuid.UUID, role user.Role) error {
+	return r.update(ctx, id, "role", uint8(role))
+}
+
+func (r *userRepo) SetSuspendedAt(ctx context.Context, id uuid.UUID, suspendedAt *time.Time) error {
+	return r.update(ctx, id, "suspended_at", suspendedAt)
+}
+
+func (r *userRepo) SetEmailVerifiedAt(ctx context.Context, id uuid.UUID, verifiedAt *time.Time) error {
+	return r.update(ctx, id, "email_verified_at", verifiedAt)
+}
+
+func (r *userRepo) update(ctx context.Context, id uuid.UUID, column string, value interface{}) error {
+	result := r.db.WithContext(ctx).Model(&entities.User{}).Where("id = ?", id).Update(column, value)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return user.ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *userRepo) Update(ctx context.Context, id uuid.UUID, fields *user.UpdateFields) (*user.User, error) {
+	updates := map[string]interface{}{}
+	if fields.FirstName != nil {
+		updates["first_name"] = *fields.FirstName
+	}
+	if fields.LastName != nil {
+		updates["last_name"] = *fields.LastName
+	}
+	if fields.Email != nil {
+		updates["email"] = *fields.Email
+	}
+	if fields.Password != nil {
+		updates["password"] = *fields.Password
+	}
+	if fields.AvatarURL != nil {
+		updates["avatar_url"] = *fields.AvatarURL
+	}
+
+	result := r.db.WithContext(ctx).Model(&entities.User{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		if strings.Contains(result.Error.Error(), "duplicate key value violates unique constraint") {
+			return nil, utils.DbErrDuplicateKey
+		}
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, user.ErrUserNotFound
+	}
+	return r.GetByID(ctx, id)
+}
+
+func (r *userRepo) Anonymize(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Model(&entities.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"first_name": "Deleted",
+		"last_name":  "User",
+		"email":      fmt.Sprintf("deleted-%s@deleted.invalid", id),
+		"password":   "",
+		"avatar_url": "",
+		"deleted_at": time.Now(),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return user.ErrUserNotFound
+	}
+	return nil
+}
@@ -4,12 +4,18 @@ This file provides methods for managing user-board roles in the storage layer:
 GetUserBoardRole method: Retrieves the role of a user for a specific board.
 SetUserBoardRole method: Sets the role of a user for a specific board.
 RemoveUserBoardRole method: Removes the role of a user for a specific board.
+GetBoardMembers method: Lists the members of a board with their roles.
+UpdateRole method: Changes the role of a member of a board.
+UpdateInheritedRole method: Follows a change of the workspace role of a member on a board they got through the workspace.
+CountOwners and GetSoleOwnedBoardIDs methods: Keep boards from ending up without an owner.
+SaveOwnershipTransfer, GetOwnershipTransfer and DeleteOwnershipTransfer methods: Manage the pending ownership transfer of a board.
 */
 
 package storage
 
 import (
 	"context"
+	"errors"
 	userboardrole "server/internal/user_board_role"
 	"server/pkg/adapters/storage/entities"
 	"server/pkg/adapters/storage/mappers"
@@ -17,6 +23,7 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type userBoardRepo struct {
@@ -47,7 +54,66 @@ func (r *userBoardRepo) SetUserBoardRole(ctx context.Context, ub *userboardrole.
 	return nil
 }
 
-func (r *userBoardRepo) RemoveUserBoardRole(ctx context.Context, userID, boardID string) error {
+func (r *userBoardRepo) RemoveUserBoardRole(ctx context.Context, userID, boardID uuid.UUID, commentsTo *uuid.UUID) error {
+	var userBoardRole entities.UserBoardRole
+	if err := r.db.WithContext(ctx).Where("user_id = ? AND board_id = ?", userID, boardID).
+		First(&userBoardRole).Error; err != nil {
+		return userboardrole.ErrUserRoleNotFound
+	}
+
+	if err := r.db.WithContext(ctx).Model(&entities.Task{}).Where("user_board_role_id = ?", userBoardRole.ID).
+		Update("user_board_role_id", nil).Error; err != nil {
+		return err
+	}
+
+	if commentsTo != nil {
+		var newAuthor entities.UserBoardRole
+		if err := r.db.WithContext(ctx).Where("user_id = ? AND board_id = ?", *commentsTo, boardID).
+			First(&newAuthor).Error; err != nil {
+			return userboardrole.ErrUserRoleNotFound
+		}
+		if err := r.db.WithContext(ctx).Model(&entities.Comment{}).Where("user_board_role_id = ?", userBoardRole.ID).
+			Update("user_board_role_id", newAuthor.ID).Error; err != nil {
+			return err
+		}
+	}
+
+	// soft delete, so kept comments and notifications still point to an existing row
+	return r.db.WithContext(ctx).Delete(&userBoardRole).Error
+}
+
+func (r *userBoardRepo) GetBoardMembers(ctx context.Context, boardID uuid.UUID) ([]userboardrole.UserBoardRole, error) {
+	var userBoardRoles []entities.UserBoardRole
+	if err := r.db.WithContext(ctx).Preload("User").Where("board_id = ?", boardID).
+		Order("created_at ASC").Find(&userBoardRoles).Error; err != nil {
+		return nil, err
+	}
+	return mappers.BatchUserBoardRoleEntitiesToDomain(userBoardRoles), nil
+}
+
+func (r *userBoardRepo) UpdateRole(ctx context.Context, userID, boardID uuid.UUID, role rbac.Role) error {
+	result := r.db.WithContext(ctx).Model(&entities.UserBoardRole{}).
+		Where("user_id = ? AND board_id = ?", userID, boardID).
+		Updates(map[string]interface{}{"user_role": string(role), "inherited": false})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return userboardrole.ErrUserRoleNotFound
+	}
+	return nil
+}
+
+func (r *userBoardRepo) UpdateInheritedRole(ctx context.Context, userID, boardID uuid.UUID, role rbac.Role) error {
+	result := r.db.WithContext(ctx).Model(&entities.UserBoardRole{}).
+		Where("user_id = ? AND board_id = ? AND inherited", userID, boardID).
+		Update("user_role", string(role))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return userboardrole.ErrUserRoleNotFound
+	}
 	return nil
 }
 
@@ -72,3 +138,59 @@ func (r *userBoardRepo) GetUserIDByUserBoardRoleID(ctx context.Context, userBoar
 	}
 	return &userBoardRole.UserID, nil
 }
+
+// CountOwners locks the owner rows of the board before counting them, so two owners stepping down at the same
+// time can not both see the other one as remaining owner. The second waits for the first to finish.
+func (r *userBoardRepo) CountOwners(ctx context.Context, boardID uuid.UUID) (int64, error) {
+	var ids []uuid.UUID
+	err := r.db.WithContext(ctx).Model(&entities.UserBoardRole{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("board_id = ? AND user_role = ?", boardID, string(rbac.RoleOwner)).
+		Pluck("id", &ids).Error
+	return int64(len(ids)), err
+}
+
+func (r *userBoardRepo) GetSoleOwnedBoardIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	var boardIDs []uuid.UUID
+	err := r.db.WithContext(ctx).Model(&entities.UserBoardRole{}).
+		Where("user_id = ? AND user_role = ?", userID, string(rbac.RoleOwner)).
+		Where("board_id NOT IN (?)", r.db.Model(&entities.UserBoardRole{}).Select("board_id").
+			Where("user_id <> ? AND user_role = ?", userID, string(rbac.RoleOwner))).
+		Pluck("board_id", &boardIDs).Error
+	return boardIDs, err
+}
+
+func (r *userBoardRepo) GetUserBoardIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	var boardIDs []uuid.UUID
+	err := r.db.WithContext(ctx).Model(&entities.UserBoardRole{}).
+		Where("user_id = ?", userID).Pluck("board_id", &boardIDs).Error
+	return boardIDs, err
+}
+
+func (r *userBoardRepo) SaveOwnershipTransfer(ctx context.Context, t *userboardrole.OwnershipTransfer) error {
+	if err := r.DeleteOwnershipTransfer(ctx, t.BoardID); err != nil {
+		return err
+	}
+	transferEntity := mappers.OwnershipTransferDomainToEntity(t)
+	if err := r.db.WithContext(ctx).Create(transferEntity).Error; err != nil {
+		return err
+	}
+	t.ID = transferEntity.ID
+	t.CreatedAt = transferEntity.CreatedAt
+	return nil
+}
+
+func (r *userBoardRepo) GetOwnershipTransfer(ctx context.Context, boardID uuid.UUID) (*userboardrole.OwnershipTransfer, error) {
+	var transfer entities.OwnershipTransfer
+	if err := r.db.WithContext(ctx).Where("board_id = ?", boardID).First(&transfer).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, userboardrole.ErrTransferNotFound
+		}
+		return nil, err
+	}
+	t := mappers.OwnershipTransferEntityToDomain(transfer)
+	return &t, nil
+}
+
+func (r *userBoardRepo) DeleteOwnershipTransfer(ctx context.Context, boardID uuid.UUID) error {
+	return r.db.WithContext(ctx).Where("board_id = ?", boardID).Delete(&entities.OwnershipTransfer{}).Error
+}
@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"context"
+	"server/internal/verification"
+	"server/pkg/adapters/storage/entities"
+	"server/pkg/adapters/storage/mappers"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type verificationRepo struct {
+	db *gorm.DB
+}
+
+func NewVerificationRepo(db *gorm.DB) verification.Repo {
+	return &verificationRepo{db}
+}
+
+func (r *verificationRepo) Insert(ctx context.Context, t *verification.Token) error {
+	e := mappers.VerificationTokenDomainToEntity(t)
+	if err := r.db.WithContext(ctx).Create(e).Error; err != nil {
+		return err
+	}
+	t.ID = e.ID
+	t.CreatedAt = e.CreatedAt
+	return nil
+}
+
+func (r *verificationRepo) Use(ctx context.Context, hash string, purpose verification.Purpose) (*verification.Token, error) {
+	var tokens []entities.VerificationToken
+	// the condition and the update are one statement so a token can not be used twice concurrently
+	result := r.db.WithContext(ctx).Model(&tokens).Clauses(clause.Returning{}).
+		Where("hash = ? AND purpose = ? AND used_at IS NULL AND expires_at > ?", hash, string(purpose), time.Now()).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if len(tokens) == 0 {
+		return nil, verification.ErrInvalidToken
+	}
+	return mappers.VerificationTokenEntityToDomain(&tokens[0]), nil
+}
+
+func (r *verificationRepo) DeleteUnused(ctx context.Context, userID uuid.UUID, purpose verification.Purpose) error {
+	return r.db.WithContext(ctx).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, string(purpose)).
+		Delete(&entities.VerificationToken{}).Error
+}
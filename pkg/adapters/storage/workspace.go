@@ -0,0 +1,157 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"server/internal/board"
+	"server/internal/workspace"
+	"server/pkg/adapters/storage/entities"
+	"server/pkg/adapters/storage/mappers"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type workspaceRepo struct {
+	db *gorm.DB
+}
+
+func NewWorkspaceRepo(db *gorm.DB) workspace.Repo {
+	return &workspaceRepo{db}
+}
+
+func (r *workspaceRepo) Insert(ctx context.Context, w *workspace.Workspace) error {
+	workspaceEntity := mappers.WorkspaceDomainToEntity(w)
+	if err := r.db.WithContext(ctx).Create(workspaceEntity).Error; err != nil {
+		return err
+	}
+	w.ID = workspaceEntity.ID
+	w.CreatedAt = workspaceEntity.CreatedAt
+	return nil
+}
+
+func (r *workspaceRepo) GetByID(ctx context.Context, id uuid.UUID) (*workspace.Workspace, error) {
+	var w entities.Workspace
+	if err := r.db.WithContext(ctx).First(&w, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, workspace.ErrWorkspaceNotFound
+		}
+		return nil, err
+	}
+	domainWorkspace := mappers.WorkspaceEntityToDomain(w)
+	return &domainWorkspace, nil
+}
+
+func (r *workspaceRepo) GetUserWorkspaces(ctx context.Context, userID uuid.UUID) ([]workspace.Workspace, error) {
+	var rows []struct {
+		entities.Workspace
+		MyRole string
+	}
+	err := r.db.WithContext(ctx).Table("workspaces").
+		Select("workspaces.*, wm.role AS my_role").
+		Joins("JOIN workspace_members wm ON wm.workspace_id = workspaces.id").
+		Where("wm.user_id = ?", userID).
+		Order("workspaces.name ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	workspaces := make([]workspace.Workspace, len(rows))
+	for i, row := range rows {
+		workspaces[i] = mappers.WorkspaceEntityToDomain(row.Workspace)
+		workspaces[i].MyRole = workspace.Role(row.MyRole)
+	}
+	return workspaces, nil
+}
+
+func (r *workspaceRepo) AddMember(ctx context.Context, m *workspace.Member) error {
+	memberEntity := mappers.WorkspaceMemberDomainToEntity(m)
+	if err := r.db.WithContext(ctx).Create(memberEntity).Error; err != nil {
+		return err
+	}
+	m.JoinedAt = memberEntity.CreatedAt
+	return nil
+}
+
+func (r *workspaceRepo) GetMember(ctx context.Context, workspaceID, userID uuid.UUID) (*workspace.Member, error) {
+	var m entities.WorkspaceMember
+	err := r.db.WithContext(ctx).Preload("User").
+		First(&m, "workspace_id = ? AND user_id = ?", workspaceID, userID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, workspace.ErrMemberNotFound
+		}
+		return nil, err
+	}
+	member := mappers.WorkspaceMemberEntityToDomain(m)
+	return &member, nil
+}
+
+func (r *workspaceRepo) GetMembers(ctx context.Context, workspaceID uuid.UUID) ([]workspace.Member, error) {
+	var members []entities.WorkspaceMember
+	err := r.db.WithContext(ctx).Preload("User").
+		Joins("JOIN users ON users.id = workspace_members.user_id").
+		Where("workspace_members.workspace_id = ?", workspaceID).
+		Order("users.first_name ASC, users.last_name ASC").
+		Find(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	return mappers.BatchWorkspaceMemberEntitiesToDomain(members), nil
+}
+
+func (r *workspaceRepo) UpdateMemberRole(ctx context.Context, workspaceID, userID uuid.UUID, role workspace.Role) error {
+	result := r.db.WithContext(ctx).Model(&entities.WorkspaceMember{}).
+		Where("workspace_id = ? AND user_id = ?", workspaceID, userID).
+		Update("role", string(role))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return workspace.ErrMemberNotFound
+	}
+	return nil
+}
+
+func (r *workspaceRepo) RemoveMember(ctx context.Context, workspaceID, userID uuid.UUID) error {
+	result := r.db.WithContext(ctx).
+		Where("workspace_id = ? AND user_id = ?", workspaceID, userID).
+		Delete(&entities.WorkspaceMember{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return workspace.ErrMemberNotFound
+	}
+	return nil
+}
+
+func (r *workspaceRepo) CountAdmins(ctx context.Context, workspaceID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entities.WorkspaceMember{}).
+		Where("workspace_id = ? AND role = ?", workspaceID, string(workspace.RoleAdmin)).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *workspaceRepo) GetBoards(ctx context.Context, workspaceID uuid.UUID) ([]board.Board, error) {
+	var boards []entities.Board
+	if err := r.db.WithContext(ctx).Where("workspace_id = ?", workspaceID).Find(&boards).Error; err != nil {
+		return nil, err
+	}
+	return mappers.BatchBoardEntitiesToDomain(boards), nil
+}
+
+func (r *workspaceRepo) GetVisibleBoards(ctx context.Context, workspaceID, userID uuid.UUID) ([]board.Board, error) {
+	var boards []entities.Board
+	err := r.db.WithContext(ctx).
+		Where("workspace_id = ? AND archived_at IS NULL", workspaceID).
+		Where("type = ? OR id IN (?)", string(board.Public),
+			r.db.Table("user_board_roles").Select("board_id").Where("user_id = ? AND deleted_at IS NULL", userID)).
+		Order("created_at DESC").
+		Find(&boards).Error
+	if err != nil {
+		return nil, err
+	}
+	return mappers.BatchBoardEntitiesToDomain(boards), nil
+}
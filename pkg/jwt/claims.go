@@ -5,9 +5,28 @@ import (
 	"github.com/google/uuid"
 )
 
+type TokenType string
+
+const (
+	// TokenTypeAccess authorizes the requests to the api.
+	TokenTypeAccess TokenType = "access"
+	// TokenTypeRefresh is only exchanged for new tokens, its ID is the one its session expects next.
+	TokenTypeRefresh TokenType = "refresh"
+	// TokenTypeChallenge stands for a checked password while the two-factor code is still awaited, it is only
+	// exchanged for tokens along with the code.
+	TokenTypeChallenge TokenType = "challenge"
+	// TokenTypePersonal marks the claims of a request made with a personal access token, which is no JWT.
+	TokenTypePersonal TokenType = "personal"
+)
+
 type UserClaims struct {
 	jwt2.RegisteredClaims
-	UserID   uuid.UUID
-	Role     string
-	Sections []string
+	UserID    uuid.UUID
+	Role      string
+	Sections  []string
+	Type      TokenType
+	SessionID uuid.UUID
+	// the limits of a personal access token, never part of a signed token
+	ReadOnly bool       `json:"-"`
+	BoardID  *uuid.UUID `json:"-"`
 }
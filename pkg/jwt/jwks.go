@@ -0,0 +1,49 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"sort"
+	"time"
+)
+
+// JWK is the public part of a key as defined by RFC 7517.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`   // RSA modulus
+	E         string `json:"e,omitempty"`   // RSA exponent
+	Curve     string `json:"crv,omitempty"` // OKP curve
+	X         string `json:"x,omitempty"`   // OKP public key
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS publishes the public keys that are not retired, other services verify the tokens with them. Keys
+// meant to sign next are published as well, so verifiers know them before the first token they sign.
+func (ks *KeySet) JWKS() JWKS {
+	now := time.Now()
+	set := JWKS{Keys: []JWK{}}
+	for _, k := range ks.keys {
+		if k.retired(now) {
+			continue
+		}
+		enc := base64.RawURLEncoding
+		switch public := k.verifyKey.(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, JWK{KeyType: "RSA", KeyID: k.ID, Use: "sig", Algorithm: k.Algorithm,
+				N: enc.EncodeToString(public.N.Bytes()), E: enc.EncodeToString(big.NewInt(int64(public.E)).Bytes())})
+		case ed25519.PublicKey:
+			set.Keys = append(set.Keys, JWK{KeyType: "OKP", KeyID: k.ID, Use: "sig", Algorithm: k.Algorithm,
+				Curve: "Ed25519", X: enc.EncodeToString(public)})
+		}
+	}
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].KeyID < set.Keys[j].KeyID })
+	return set
+}
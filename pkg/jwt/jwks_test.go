@@ -0,0 +1,50 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJWKS(t *testing.T) {
+	rsaKey := newRSAKey(t, "rsa")
+	edKey := newEd25519Key(t, "ed")
+	past := time.Now().Add(-time.Minute)
+	retired := newEd25519Key(t, "retired")
+	retired.RetireAt = &past
+	ks := newKeySet(t, edKey, rsaKey, retired, NewSecretKey("", []byte("secret")))
+
+	set := ks.JWKS()
+	// the secret and the retired key are never published, the rest comes sorted by kid
+	if !assert.Len(t, set.Keys, 2) {
+		return
+	}
+
+	enc := base64.RawURLEncoding
+	rsaPublic := rsaKey.verifyKey.(*rsa.PublicKey)
+	edPublic := edKey.verifyKey.(ed25519.PublicKey)
+	expected := []JWK{
+		{KeyType: "OKP", KeyID: "ed", Use: "sig", Algorithm: AlgorithmEdDSA, Curve: "Ed25519", X: enc.EncodeToString(edPublic)},
+		{KeyType: "RSA", KeyID: "rsa", Use: "sig", Algorithm: AlgorithmRS256,
+			N: enc.EncodeToString(rsaPublic.N.Bytes()), E: "AQAB"},
+	}
+	assert.Equal(t, expected, set.Keys)
+
+	// the published values give back the keys
+	n, err := enc.DecodeString(set.Keys[1].N)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, new(big.Int).SetBytes(n).Cmp(rsaPublic.N))
+	x, err := enc.DecodeString(set.Keys[0].X)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(edPublic), x)
+}
+
+func TestJWKSWithoutPublicKeys(t *testing.T) {
+	ks := newKeySet(t, NewSecretKey("", []byte("secret")))
+	assert.Equal(t, JWKS{Keys: []JWK{}}, ks.JWKS())
+}
@@ -9,19 +9,12 @@ import (
 
 const UserClaimKey = "User-Claims"
 
-func CreateToken(secret []byte, claims *UserClaims) (string, error) {
-	claims.Role = "user"
-	return jwt2.NewWithClaims(jwt2.SigningMethodHS512, claims).SignedString(secret)
-}
-
-func ParseToken(tokenString string, secret []byte) (*UserClaims, error) {
+func parseToken(tokenString string, keyFunc jwt2.Keyfunc) (*UserClaims, error) {
 	// Check for valid JWT format
 	if strings.Count(tokenString, ".") != 2 {
 		return nil, errors.New("token contains an invalid number of segments")
 	}
-	token, err := jwt2.ParseWithClaims(tokenString, &UserClaims{}, func(t *jwt2.Token) (interface{}, error) {
-		return secret, nil
-	})
+	token, err := jwt2.ParseWithClaims(tokenString, &UserClaims{}, keyFunc)
 
 	var claim *UserClaims
 	if token.Claims != nil {
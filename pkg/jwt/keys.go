@@ -0,0 +1,137 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+
+	jwt2 "github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	AlgorithmHS512 = "HS512"
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+var (
+	ErrUnknownKey           = errors.New("token is signed with an unknown or retired key")
+	ErrUnsupportedAlgorithm = errors.New("unsupported signing algorithm, use RS256 or EdDSA")
+)
+
+// Key signs and verifies tokens, the tokens it signs carry its ID as their kid.
+type Key struct {
+	ID        string
+	Algorithm string
+	// RetireAt ends the overlap window of a replaced key: tokens signed with it are rejected and it is no
+	// longer published from then on. Nil keeps the key.
+	RetireAt  *time.Time
+	method    jwt2.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+// NewSecretKey makes an HS512 key of a shared secret. It can not be published, only the holders of the
+// secret can verify its tokens.
+func NewSecretKey(id string, secret []byte) *Key {
+	return &Key{
+		ID:        id,
+		Algorithm: AlgorithmHS512,
+		method:    jwt2.SigningMethodHS512,
+		signKey:   secret,
+		verifyKey: secret,
+	}
+}
+
+// NewPrivateKey makes an RS256 or EdDSA key of a PEM encoded private key, in PKCS #8 or, for RSA, PKCS #1.
+func NewPrivateKey(id, algorithm string, pemData []byte) (*Key, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, fmt.Errorf("key %s: no PEM data found", id)
+	}
+	var (
+		private interface{}
+		err     error
+	)
+	if block.Type == "RSA PRIVATE KEY" {
+		private, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	} else {
+		private, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("key %s: %w", id, err)
+	}
+
+	key := &Key{ID: id, Algorithm: algorithm, signKey: private}
+	switch algorithm {
+	case AlgorithmRS256:
+		rsaKey, ok := private.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("key %s: RS256 needs an RSA key", id)
+		}
+		key.method, key.verifyKey = jwt2.SigningMethodRS256, &rsaKey.PublicKey
+	case AlgorithmEdDSA:
+		edKey, ok := private.(ed25519.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("key %s: EdDSA needs an Ed25519 key", id)
+		}
+		key.method, key.verifyKey = jwt2.SigningMethodEdDSA, edKey.Public()
+	default:
+		return nil, fmt.Errorf("key %s: %w", id, ErrUnsupportedAlgorithm)
+	}
+	return key, nil
+}
+
+func (k *Key) retired(now time.Time) bool {
+	return k.RetireAt != nil && !now.Before(*k.RetireAt)
+}
+
+// KeySet signs new tokens with one key and verifies tokens with any key it holds that is not retired, so
+// keys can be rotated without logging anyone out.
+type KeySet struct {
+	signing *Key
+	keys    map[string]*Key
+}
+
+func NewKeySet(signing *Key, verifying ...*Key) (*KeySet, error) {
+	if signing.retired(time.Now()) {
+		return nil, fmt.Errorf("the signing key %s is retired", signing.ID)
+	}
+	ks := &KeySet{signing: signing, keys: map[string]*Key{signing.ID: signing}}
+	for _, k := range verifying {
+		if _, ok := ks.keys[k.ID]; ok {
+			return nil, fmt.Errorf("the key id %q is used twice", k.ID)
+		}
+		ks.keys[k.ID] = k
+	}
+	return ks, nil
+}
+
+func (ks *KeySet) Sign(claims *UserClaims) (string, error) {
+	token := jwt2.NewWithClaims(ks.signing.method, claims)
+	if ks.signing.ID != "" {
+		token.Header["kid"] = ks.signing.ID
+	}
+	return token.SignedString(ks.signing.signKey)
+}
+
+// Parse verifies a token with the key its kid names, tokens without a kid are the ones signed before keys
+// had ids.
+func (ks *KeySet) Parse(tokenString string) (*UserClaims, error) {
+	return parseToken(tokenString, func(t *jwt2.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		key, ok := ks.keys[kid]
+		if !ok || key.retired(time.Now()) {
+			return nil, ErrUnknownKey
+		}
+		// the algorithm of the token must be the one of the key, a public key must never be used as a secret
+		if t.Method.Alg() != key.method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method %s", t.Method.Alg())
+		}
+		return key.verifyKey, nil
+	})
+}
@@ -0,0 +1,195 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	jwt2 "github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRSAKey(t *testing.T, id string) *Key {
+	t.Helper()
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key: %v", err)
+	}
+	// PKCS #1, the form openssl genrsa writes
+	pemData := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(private)})
+	key, err := NewPrivateKey(id, AlgorithmRS256, pemData)
+	if err != nil {
+		t.Fatalf("Failed to load RSA key: %v", err)
+	}
+	return key
+}
+
+func newEd25519Key(t *testing.T, id string) *Key {
+	t.Helper()
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate Ed25519 key: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		t.Fatalf("Failed to encode Ed25519 key: %v", err)
+	}
+	key, err := NewPrivateKey(id, AlgorithmEdDSA, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	if err != nil {
+		t.Fatalf("Failed to load Ed25519 key: %v", err)
+	}
+	return key
+}
+
+func newKeySet(t *testing.T, signing *Key, verifying ...*Key) *KeySet {
+	t.Helper()
+	ks, err := NewKeySet(signing, verifying...)
+	if err != nil {
+		t.Fatalf("Failed to make key set: %v", err)
+	}
+	return ks
+}
+
+func testClaims(expiresAt time.Time) *UserClaims {
+	return &UserClaims{
+		RegisteredClaims: jwt2.RegisteredClaims{ExpiresAt: jwt2.NewNumericDate(expiresAt)},
+		UserID:           uuid.New(),
+		Role:             "user",
+		Type:             TokenTypeAccess,
+		SessionID:        uuid.New(),
+	}
+}
+
+func sign(t *testing.T, ks *KeySet, claims *UserClaims) string {
+	t.Helper()
+	token, err := ks.Sign(claims)
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+	return token
+}
+
+// forge signs a token with method and secret under the kid of another key, the way an attacker holding
+// only published keys would try.
+func forge(t *testing.T, method jwt2.SigningMethod, kid string, secret interface{}) string {
+	t.Helper()
+	token := jwt2.NewWithClaims(method, testClaims(time.Now().Add(time.Hour)))
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(secret)
+	if err != nil {
+		t.Fatalf("Failed to forge token: %v", err)
+	}
+	return signed
+}
+
+func TestNewPrivateKey(t *testing.T) {
+	_, edPrivate, _ := ed25519.GenerateKey(rand.Reader)
+	edDER, _ := x509.MarshalPKCS8PrivateKey(edPrivate)
+	edPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: edDER})
+
+	scenarios := []struct {
+		name      string
+		algorithm string
+		pemData   []byte
+		expectErr bool
+	}{
+		{name: "Ed25519", algorithm: AlgorithmEdDSA, pemData: edPEM},
+		{name: "NoPEM", algorithm: AlgorithmEdDSA, pemData: []byte("not a key"), expectErr: true},
+		{name: "WrongKeyType", algorithm: AlgorithmRS256, pemData: edPEM, expectErr: true},
+		{name: "UnsupportedAlgorithm", algorithm: AlgorithmHS512, pemData: edPEM, expectErr: true},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			key, err := NewPrivateKey("k", scenario.algorithm, scenario.pemData)
+			if scenario.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, scenario.algorithm, key.Algorithm)
+		})
+	}
+}
+
+func TestNewKeySet(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	retired := NewSecretKey("retired", []byte("secret"))
+	retired.RetireAt = &past
+
+	_, err := NewKeySet(retired)
+	assert.Error(t, err, "a retired key can not sign")
+
+	_, err = NewKeySet(NewSecretKey("a", []byte("one")), NewSecretKey("a", []byte("two")))
+	assert.Error(t, err, "key ids are unique")
+}
+
+func TestParse(t *testing.T) {
+	oldKey := newRSAKey(t, "2024-01")
+	newKey := newEd25519Key(t, "2024-06")
+	legacyKey := NewSecretKey("", []byte("the secret of the tokens signed before key ids"))
+	soon, past := time.Now().Add(time.Hour), time.Now().Add(-time.Minute)
+	retiringKey := *oldKey
+	retiringKey.RetireAt = &soon
+	retiredKey := *oldKey
+	retiredKey.RetireAt = &past
+
+	// the key set after a rotation, the old key verifies the tokens it signed until it retires
+	rotated := newKeySet(t, newKey, &retiringKey, legacyKey)
+	oldToken := sign(t, newKeySet(t, oldKey), testClaims(time.Now().Add(time.Hour)))
+	rsaPublic, _ := x509.MarshalPKIXPublicKey(oldKey.verifyKey)
+
+	scenarios := []struct {
+		name        string
+		keys        *KeySet
+		token       string
+		expectedErr error
+		expectErr   bool
+	}{
+		{name: "SigningKey", keys: rotated, token: sign(t, rotated, testClaims(time.Now().Add(time.Hour)))},
+		{name: "OldKeyBeforeRetirement", keys: rotated, token: oldToken},
+		{name: "OldKeyAfterRetirement", keys: newKeySet(t, newKey, &retiredKey, legacyKey), token: oldToken, expectedErr: ErrUnknownKey},
+		{name: "UnknownKid", keys: rotated, token: sign(t, newKeySet(t, newRSAKey(t, "elsewhere")), testClaims(time.Now().Add(time.Hour))), expectedErr: ErrUnknownKey},
+		{name: "LegacyTokenWithoutKid", keys: rotated, token: sign(t, newKeySet(t, legacyKey), testClaims(time.Now().Add(time.Hour)))},
+		{name: "LegacyKeyDropped", keys: newKeySet(t, newKey, &retiringKey), token: sign(t, newKeySet(t, legacyKey), testClaims(time.Now().Add(time.Hour))), expectedErr: ErrUnknownKey},
+		{name: "PublicKeyAsHMACSecret", keys: rotated, token: forge(t, jwt2.SigningMethodHS256, oldKey.ID, rsaPublic), expectErr: true},
+		{name: "AlgOfAnotherKey", keys: rotated, token: forge(t, jwt2.SigningMethodRS256, newKey.ID, oldKey.signKey), expectErr: true},
+		{name: "AlgNone", keys: rotated, token: forge(t, jwt2.SigningMethodNone, oldKey.ID, jwt2.UnsafeAllowNoneSignatureType), expectErr: true},
+		{name: "Expired", keys: rotated, token: sign(t, rotated, testClaims(time.Now().Add(-time.Minute))), expectedErr: jwt2.ErrTokenExpired},
+		{name: "Malformed", keys: rotated, token: "not-a-token", expectErr: true},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			claims, err := scenario.keys.Parse(scenario.token)
+			switch {
+			case scenario.expectedErr != nil:
+				assert.ErrorIs(t, err, scenario.expectedErr)
+			case scenario.expectErr:
+				assert.Error(t, err)
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, TokenTypeAccess, claims.Type)
+			}
+		})
+	}
+}
+
+func TestSignSetsKid(t *testing.T) {
+	key := newEd25519Key(t, "2024-06")
+	token := sign(t, newKeySet(t, key), testClaims(time.Now().Add(time.Hour)))
+	parsed, _, err := jwt2.NewParser().ParseUnverified(token, &UserClaims{})
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-06", parsed.Header["kid"])
+	assert.Equal(t, AlgorithmEdDSA, parsed.Header["alg"])
+
+	legacy := sign(t, newKeySet(t, NewSecretKey("", []byte("secret"))), testClaims(time.Now().Add(time.Hour)))
+	parsed, _, err = jwt2.NewParser().ParseUnverified(legacy, &UserClaims{})
+	assert.NoError(t, err)
+	assert.NotContains(t, parsed.Header, "kid")
+}
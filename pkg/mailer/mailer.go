@@ -0,0 +1,17 @@
+/*
+Package mailer is the port the services send emails through, the adapters live in pkg/adapters/mailer.
+*/
+
+package mailer
+
+import "context"
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string // plain text
+}
+
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
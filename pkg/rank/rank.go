@@ -0,0 +1,97 @@
+/*
+Package rank implements lexicographic ranks: strings whose byte order is the order of the items
+they are given to. An item is moved by giving it a rank between the ranks of its new neighbours,
+so no other item has to be touched.
+
+A rank is read as a base 36 fraction (digits 0-9 then a-z after an implicit "0."), "" standing
+for 0 as a lower bound and for 1 as an upper bound. Ranks never end in '0', which keeps room
+below every one of them.
+*/
+
+package rank
+
+import (
+	"strings"
+)
+
+const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+const base = len(digits)
+
+// MaxLength is the length above which the ranks of a list should be spread out again.
+const MaxLength = 32
+
+// Between returns a rank sorting after prev and before next. Either may be empty to leave that
+// side open; otherwise prev must sort before next.
+func Between(prev, next string) string {
+	if next != "" {
+		// keep the common prefix, padding prev with zeros
+		n := 0
+		for n < len(next) && digitAt(prev, n) == strings.IndexByte(digits, next[n]) {
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(prev) {
+				rest = prev[n:]
+			}
+			return next[:n] + Between(rest, next[n:])
+		}
+	}
+
+	low := digitAt(prev, 0)
+	high := base
+	if next != "" {
+		high = strings.IndexByte(digits, next[0])
+	}
+	if high-low > 1 {
+		return string(digits[(low+high)/2])
+	}
+
+	// the first digits are consecutive
+	if next != "" && len(next) > 1 {
+		return next[:1]
+	}
+	rest := ""
+	if len(prev) > 1 {
+		rest = prev[1:]
+	}
+	return string(digits[low]) + Between(rest, "")
+}
+
+// Spread returns n evenly spaced ranks in increasing order, used to rebalance a list.
+func Spread(n int) []string {
+	if n <= 0 {
+		return nil
+	}
+	width, space := 1, base
+	for space < (n+1)*base {
+		width++
+		space *= base
+	}
+	step := space / (n + 1)
+
+	ranks := make([]string, n)
+	buf := make([]byte, width)
+	for i := range ranks {
+		value := (i + 1) * step
+		for j := width - 1; j >= 0; j-- {
+			buf[j] = digits[value%base]
+			value /= base
+		}
+		ranks[i] = strings.TrimRight(string(buf), "0")
+	}
+	return ranks
+}
+
+// TooLong tells whether a rank has grown long enough that its list should be spread out again.
+func TooLong(r string) bool {
+	return len(r) > MaxLength
+}
+
+func digitAt(r string, i int) int {
+	if i >= len(r) {
+		return 0
+	}
+	return strings.IndexByte(digits, r[i])
+}
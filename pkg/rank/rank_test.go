@@ -0,0 +1,87 @@
+package rank
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func assertBetween(t *testing.T, prev, r, next string) {
+	t.Helper()
+	assert.NotEmpty(t, r)
+	assert.False(t, strings.HasSuffix(r, "0"), "rank %q ends in 0", r)
+	if prev != "" {
+		assert.Less(t, prev, r)
+	}
+	if next != "" {
+		assert.Less(t, r, next)
+	}
+}
+
+func TestBetween(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		prev     string
+		next     string
+		expected string
+	}{
+		{name: "OpenBothSides", prev: "", next: "", expected: "i"},
+		{name: "OpenAbove", prev: "i", next: "", expected: "r"},
+		{name: "OpenBelow", prev: "", next: "i", expected: "9"},
+		{name: "Gap", prev: "a", next: "e", expected: "c"},
+		{name: "ConsecutiveDigits", prev: "a", next: "b", expected: "ai"},
+		{name: "CommonPrefix", prev: "a1", next: "a3", expected: "a2"},
+		{name: "PrevIsPrefixOfNext", prev: "a", next: "a1", expected: "a0i"},
+		{name: "NextLongerThanOneDigit", prev: "a", next: "b5", expected: "b"},
+		{name: "LastDigit", prev: "z", next: "", expected: "zi"},
+		{name: "BelowFirstDigit", prev: "", next: "1", expected: "0i"},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			r := Between(scenario.prev, scenario.next)
+			assert.Equal(t, scenario.expected, r)
+			assertBetween(t, scenario.prev, r, scenario.next)
+		})
+	}
+}
+
+func TestBetweenRepeatedly(t *testing.T) {
+	// always inserting right after the same item makes the ranks grow, never collide
+	prev, next := "a", "b"
+	for i := 0; i < 200; i++ {
+		r := Between(prev, next)
+		assertBetween(t, prev, r, next)
+		next = r
+	}
+	assert.True(t, TooLong(next), "ranks should outgrow MaxLength and call for a spread")
+
+	prev, next = "a", "b"
+	for i := 0; i < 200; i++ {
+		r := Between(prev, next)
+		assertBetween(t, prev, r, next)
+		prev = r
+	}
+}
+
+func TestSpread(t *testing.T) {
+	assert.Nil(t, Spread(0))
+	assert.Nil(t, Spread(-1))
+	assert.Equal(t, []string{"i"}, Spread(1))
+
+	for _, n := range []int{2, 35, 36, 37, 1000} {
+		ranks := Spread(n)
+		assert.Len(t, ranks, n)
+		assert.True(t, sort.StringsAreSorted(ranks), "ranks of %d items are not sorted", n)
+		for i, r := range ranks {
+			assert.False(t, TooLong(r))
+			if i > 0 {
+				assertBetween(t, ranks[i-1], r, "")
+			} else {
+				assertBetween(t, "", r, "")
+			}
+		}
+	}
+}
@@ -5,17 +5,57 @@ This file provides utility functions for checking permissions:
 HasPermission: Checks if a given role has a specific permission.
 HasAllPermissions: Checks if a given role has all of the specified permissions.
 HasAnyPermission: Checks if a given role has any of the specified permissions.
+
+Roles that are not built in are resolved through the Store set with SetStore.
 */
 
 package rbac
 
-func IsAPossibleRole(role string) bool {
+import "context"
+
+// Store resolves the permissions of the roles that are not built in, within the request of ctx.
+type Store interface {
+	GetPermissions(ctx context.Context, role Role) (permissions []Permission, exists bool)
+}
+
+var store Store
+
+// SetStore sets the store used for the roles that are not in RolePermissions.
+func SetStore(s Store) {
+	store = s
+}
+
+func IsBuiltInRole(role string) bool {
 	_, exists := RolePermissions[Role(role)]
 	return exists
 }
 
-func HasPermission(role Role, permission Permission) bool {
-	permissions, exists := RolePermissions[role]
+func IsAPossibleRole(ctx context.Context, role string) bool {
+	_, exists := getPermissions(ctx, Role(role))
+	return exists
+}
+
+func IsAPermission(permission string) bool {
+	for _, p := range AllPermissions {
+		if string(p) == permission {
+			return true
+		}
+	}
+	return false
+}
+
+func getPermissions(ctx context.Context, role Role) ([]Permission, bool) {
+	if permissions, exists := RolePermissions[role]; exists {
+		return permissions, true
+	}
+	if store == nil {
+		return nil, false
+	}
+	return store.GetPermissions(ctx, role)
+}
+
+func HasPermission(ctx context.Context, role Role, permission Permission) bool {
+	permissions, exists := getPermissions(ctx, role)
 	if !exists {
 		return false
 	}
@@ -27,18 +67,18 @@ func HasPermission(role Role, permission Permission) bool {
 	return false
 }
 
-func HasAllPermissions(role Role, permissions ...Permission) bool {
+func HasAllPermissions(ctx context.Context, role Role, permissions ...Permission) bool {
 	for _, permission := range permissions {
-		if !HasPermission(role, permission) {
+		if !HasPermission(ctx, role, permission) {
 			return false
 		}
 	}
 	return true
 }
 
-func HasAnyPermission(role Role, permissions ...Permission) bool {
+func HasAnyPermission(ctx context.Context, role Role, permissions ...Permission) bool {
 	for _, permission := range permissions {
-		if HasPermission(role, permission) {
+		if HasPermission(ctx, role, permission) {
 			return true
 		}
 	}
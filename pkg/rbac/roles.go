@@ -3,7 +3,7 @@ This file defines the basic structure of our RBAC system. It includes:
 
 Permission type: Represents individual permissions in the system.
 Role type: Represents user roles.
-RolePermissions map: Maps each role to its allowed permissions.
+RolePermissions map: Maps each built-in role to its allowed permissions, custom roles are kept in a Store.
 
 */
 
@@ -24,18 +24,53 @@ const (
 	PermissionViewBoard      Permission = "view_board"
 	PermissionViewTask       Permission = "view_task"
 	PermissionCommentOwnTask Permission = "comment_own_task"
-	PermissionMoveOwnTask       Permission = "move_own_task"
+	PermissionMoveOwnTask    Permission = "move_own_task"
 	PermissionCreateTask     Permission = "create_task"
 	PermissionCreateSubtask  Permission = "create_subtask"
 	PermissionCommentAnyTask Permission = "comment_any_task"
-	PermissionMoveAnyTask       Permission = "move_any_task"
+	PermissionMoveAnyTask    Permission = "move_any_task"
 	PermissionManageColumns  Permission = "manage_columns"
 	PermissionInviteUsers    Permission = "invite_users"
 	PermissionRemoveBoard    Permission = "remove_board"
-	// PermissionSetRole TODO
-	// PermissionRemoveUser TODO
+	PermissionEditOwnTask    Permission = "edit_own_task"
+	PermissionEditAnyTask    Permission = "edit_any_task"
+	PermissionAssignTask     Permission = "assign_task"
+	PermissionDeleteTask     Permission = "delete_task"
+	PermissionSetRole        Permission = "set_role"
+	PermissionRemoveUser     Permission = "remove_user"
+	// PermissionTransferOwnership allows offering the ownership of a board to another member
+	PermissionTransferOwnership Permission = "transfer_ownership"
+	PermissionEditBoard         Permission = "edit_board"
+	PermissionArchiveBoard      Permission = "archive_board"
+	// PermissionManageRoles allows defining the custom roles of a board
+	PermissionManageRoles Permission = "manage_roles"
 )
 
+// AllPermissions lists every permission a custom role can be made of.
+var AllPermissions = []Permission{
+	PermissionViewBoard,
+	PermissionViewTask,
+	PermissionCommentOwnTask,
+	PermissionMoveOwnTask,
+	PermissionCreateTask,
+	PermissionCreateSubtask,
+	PermissionCommentAnyTask,
+	PermissionMoveAnyTask,
+	PermissionManageColumns,
+	PermissionInviteUsers,
+	PermissionRemoveBoard,
+	PermissionEditOwnTask,
+	PermissionEditAnyTask,
+	PermissionAssignTask,
+	PermissionDeleteTask,
+	PermissionSetRole,
+	PermissionRemoveUser,
+	PermissionTransferOwnership,
+	PermissionEditBoard,
+	PermissionArchiveBoard,
+	PermissionManageRoles,
+}
+
 var RolePermissions = map[Role][]Permission{
 	RoleViewer: {
 		PermissionViewBoard,
@@ -46,6 +81,7 @@ var RolePermissions = map[Role][]Permission{
 		PermissionViewTask,
 		PermissionCommentOwnTask,
 		PermissionMoveOwnTask,
+		PermissionEditOwnTask,
 	},
 	RoleMaintainer: {
 		PermissionViewBoard,
@@ -57,6 +93,10 @@ var RolePermissions = map[Role][]Permission{
 		PermissionCreateSubtask,
 		PermissionCommentAnyTask,
 		PermissionManageColumns,
+		PermissionEditOwnTask,
+		PermissionEditAnyTask,
+		PermissionAssignTask,
+		PermissionDeleteTask,
 	},
 	RoleOwner: {
 		PermissionViewBoard,
@@ -70,5 +110,15 @@ var RolePermissions = map[Role][]Permission{
 		PermissionManageColumns,
 		PermissionInviteUsers,
 		PermissionRemoveBoard,
+		PermissionEditOwnTask,
+		PermissionEditAnyTask,
+		PermissionAssignTask,
+		PermissionDeleteTask,
+		PermissionSetRole,
+		PermissionRemoveUser,
+		PermissionTransferOwnership,
+		PermissionEditBoard,
+		PermissionArchiveBoard,
+		PermissionManageRoles,
 	},
 }
@@ -0,0 +1,68 @@
+package totp
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// the SHA1 secret of the test vectors in RFC 6238 Appendix B
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCode(t *testing.T) {
+	// the RFC lists 8 digit codes, these are their last 6 digits
+	scenarios := []struct {
+		name     string
+		unix     int64
+		expected string
+	}{
+		{name: "59", unix: 59, expected: "287082"},
+		{name: "1111111109", unix: 1111111109, expected: "081804"},
+		{name: "1111111111", unix: 1111111111, expected: "050471"},
+		{name: "1234567890", unix: 1234567890, expected: "005924"},
+		{name: "2000000000", unix: 2000000000, expected: "279037"},
+		{name: "20000000000", unix: 20000000000, expected: "353130"},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			code, err := Code(rfcSecret, Step(time.Unix(scenario.unix, 0)))
+			assert.NoError(t, err)
+			assert.Equal(t, scenario.expected, code)
+		})
+	}
+}
+
+func TestCodeInvalidSecret(t *testing.T) {
+	_, err := Code("not base32!", 1)
+	assert.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	current := Step(now)
+
+	scenarios := []struct {
+		name         string
+		code         string
+		expectedStep int64
+		expectedOK   bool
+	}{
+		{name: "CurrentStep", code: "050471", expectedStep: current, expectedOK: true},
+		{name: "WithSpaces", code: "050 471", expectedStep: current, expectedOK: true},
+		{name: "PreviousStep", code: "081804", expectedStep: current - 1, expectedOK: true},
+		{name: "TooOld", code: "287082", expectedOK: false},
+		{name: "WrongLength", code: "14050471", expectedOK: false},
+		{name: "Wrong", code: "000000", expectedOK: false},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			step, ok := Validate(rfcSecret, scenario.code, now)
+			assert.Equal(t, scenario.expectedOK, ok)
+			assert.Equal(t, scenario.expectedStep, step)
+		})
+	}
+}
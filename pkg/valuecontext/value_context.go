@@ -4,6 +4,8 @@ import (
 	"context"
 	"log/slog"
 	"sync"
+
+	"github.com/google/uuid"
 )
 
 type ValueKeyType string
@@ -22,8 +24,26 @@ type Committer interface {
 }
 
 type ContextValue struct {
-	Tx     Committer
-	Logger *slog.Logger
+	Tx         Committer
+	Logger     *slog.Logger
+	BoardScope *BoardScope
+}
+
+// BoardScope limits what a request can do as UserID to the board BoardID, it is set for the requests made
+// with an access token limited to a board.
+type BoardScope struct {
+	UserID  uuid.UUID
+	BoardID uuid.UUID
+}
+
+// Limits tells whether the scope applies to what userID does, a nil scope limits nothing.
+func (s *BoardScope) Limits(userID uuid.UUID) bool {
+	return s != nil && s.UserID == userID
+}
+
+// Excludes tells whether the scope keeps userID from acting on boardID.
+func (s *BoardScope) Excludes(userID, boardID uuid.UUID) bool {
+	return s.Limits(userID) && s.BoardID != boardID
 }
 
 func NewValueContext(parent context.Context, val *ContextValue) context.Context {
@@ -85,3 +105,22 @@ func SetTx(ctx context.Context, tx Committer) {
 
 	val.Tx = tx
 }
+
+func SetBoardScope(ctx context.Context, scope *BoardScope) {
+	val, ok := tryGetValueFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	val.BoardScope = scope
+}
+
+// GetBoardScope returns the board scope of the request, nil when it has none.
+func GetBoardScope(ctx context.Context) *BoardScope {
+	val, ok := tryGetValueFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	return val.BoardScope
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"errors"
+	accesstoken "server/internal/access_token"
+	userboardrole "server/internal/user_board_role"
+
+	"github.com/google/uuid"
+)
+
+// AccessTokenService manages the personal access tokens a user creates for scripts and integrations.
+type AccessTokenService struct {
+	accessTokenOps   *accesstoken.Ops
+	userBoardRoleOps *userboardrole.Ops
+}
+
+func NewAccessTokenService(accessTokenOps *accesstoken.Ops, userBoardRoleOps *userboardrole.Ops) *AccessTokenService {
+	return &AccessTokenService{
+		accessTokenOps:   accessTokenOps,
+		userBoardRoleOps: userBoardRoleOps,
+	}
+}
+
+// Create stores a new token of the user and returns its secret, a token limited to a board needs the user to be
+// a member of it.
+func (s *AccessTokenService) Create(ctx context.Context, t *accesstoken.AccessToken, lifetimeDays uint) (string, error) {
+	if t.BoardID != nil {
+		if _, err := s.userBoardRoleOps.GetUserBoardRole(ctx, t.UserID, *t.BoardID); err != nil {
+			if errors.Is(err, userboardrole.ErrUserRoleNotFound) {
+				return "", accesstoken.ErrNotBoardMember
+			}
+			return "", err
+		}
+	}
+	return s.accessTokenOps.Create(ctx, t, lifetimeDays)
+}
+
+func (s *AccessTokenService) GetTokens(ctx context.Context, userID uuid.UUID) ([]accesstoken.AccessToken, error) {
+	return s.accessTokenOps.GetUserTokens(ctx, userID)
+}
+
+func (s *AccessTokenService) Revoke(ctx context.Context, userID, tokenID uuid.UUID) error {
+	return s.accessTokenOps.Revoke(ctx, userID, tokenID)
+}
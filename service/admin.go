@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"server/internal/board"
+	"server/internal/session"
+	"server/internal/stats"
+	u "server/internal/user"
+
+	"github.com/google/uuid"
+)
+
+// AdminService holds the operations of the global admins, the routes calling it are restricted to them.
+type AdminService struct {
+	boardService *BoardService
+	userOps      *u.Ops
+	boardOps     *board.Ops
+	statsOps     *stats.Ops
+	sessionOps   *session.Ops
+}
+
+func NewAdminService(boardService *BoardService, userOps *u.Ops, boardOps *board.Ops, statsOps *stats.Ops, sessionOps *session.Ops) *AdminService {
+	return &AdminService{
+		boardService: boardService,
+		userOps:      userOps,
+		boardOps:     boardOps,
+		statsOps:     statsOps,
+		sessionOps:   sessionOps,
+	}
+}
+
+// MakeAdmin gives the global admin role to the user registered with email, their tokens carry it from the next request on.
+func (s *AdminService) MakeAdmin(ctx context.Context, email string) (*u.User, error) {
+	return s.userOps.MakeAdmin(ctx, email)
+}
+
+func (s *AdminService) GetUsers(ctx context.Context, page, pageSize uint) ([]u.User, uint, error) {
+	return s.userOps.GetUsers(ctx, page, pageSize)
+}
+
+// SuspendUser keeps a user from logging in or refreshing their token until they are unsuspended, their sessions
+// end right away.
+func (s *AdminService) SuspendUser(ctx context.Context, adminID, userID uuid.UUID) error {
+	if adminID == userID {
+		return ErrCantManageYourself
+	}
+	if err := s.userOps.Suspend(ctx, userID); err != nil {
+		return err
+	}
+	return s.sessionOps.RevokeAll(ctx, userID, nil)
+}
+
+func (s *AdminService) UnsuspendUser(ctx context.Context, userID uuid.UUID) error {
+	return s.userOps.Unsuspend(ctx, userID)
+}
+
+func (s *AdminService) GetBoards(ctx context.Context, page, pageSize uint) ([]board.Board, uint, error) {
+	return s.boardOps.GetAllBoards(ctx, page, pageSize)
+}
+
+func (s *AdminService) ForceTransferOwnership(ctx context.Context, boardID, userID uuid.UUID) error {
+	return s.boardService.ForceTransferOwnership(ctx, boardID, userID)
+}
+
+func (s *AdminService) GetStats(ctx context.Context) (*stats.Stats, error) {
+	return s.statsOps.Get(ctx)
+}
@@ -2,17 +2,34 @@ package service
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
 	"log"
+	"os"
 	"server/config"
+	accesstoken "server/internal/access_token"
 	"server/internal/board"
+	boardtemplate "server/internal/board_template"
 	"server/internal/column"
 	"server/internal/comment"
+	customrole "server/internal/custom_role"
+	"server/internal/invitation"
 	"server/internal/notification"
+	"server/internal/session"
+	"server/internal/stats"
 	"server/internal/task"
+	twofactor "server/internal/two_factor"
 	"server/internal/user"
 	userboardrole "server/internal/user_board_role"
+	"server/internal/verification"
+	"server/internal/workspace"
+	mailadapter "server/pkg/adapters/mailer"
 	"server/pkg/adapters/storage"
+	"server/pkg/jwt"
+	"server/pkg/mailer"
+	"server/pkg/rbac"
 	"server/pkg/valuecontext"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -20,12 +37,21 @@ import (
 type AppContainer struct {
 	cfg                 config.Config
 	dbConn              *gorm.DB
+	mailer              mailer.Mailer
+	tokenKeys           *jwt.KeySet
 	authService         *AuthService
 	boardService        *BoardService
 	taskService         *TaskService
 	columnService       *ColumnService
 	notificationService *NotificationService
 	commentService      *CommentService
+	invitationService   *InvitationService
+	templateService     *TemplateService
+	workspaceService    *WorkspaceService
+	roleService         *RoleService
+	adminService        *AdminService
+	userService         *UserService
+	accessTokenService  *AccessTokenService
 }
 
 func NewAppContainer(cfg config.Config) (*AppContainer, error) {
@@ -34,6 +60,8 @@ func NewAppContainer(cfg config.Config) (*AppContainer, error) {
 	}
 
 	app.mustInitDB()
+	app.mustLoadTokenKeys()
+	app.setMailer()
 
 	app.setAuthService()
 	app.setBoardService()
@@ -41,6 +69,17 @@ func NewAppContainer(cfg config.Config) (*AppContainer, error) {
 	app.setNotificationService()
 	app.setColumnService()
 	app.setCommentService()
+	app.setInvitationService()
+	app.setTemplateService()
+	app.setWorkspaceService()
+	app.setRoleService()
+	app.setAdminService()
+	app.setUserService()
+	app.setAccessTokenService()
+
+	app.bootstrapAdmins()
+
+	app.startTrashPurger()
 
 	return app, nil
 }
@@ -70,18 +109,129 @@ func (a *AppContainer) mustInitDB() {
 	if err != nil {
 		log.Fatal("Migration failed: ", err)
 	}
+
+	rbac.SetStore(storage.NewRBACStore(a.dbConn))
+}
+
+// TokenKeys signs and verifies the tokens of the users.
+func (a *AppContainer) TokenKeys() *jwt.KeySet {
+	return a.tokenKeys
+}
+
+// mustLoadTokenKeys reads the signing keys of the config. Without a signing key id the tokens are signed
+// with the token secret, the keys listed meanwhile are published already so verifiers know them in advance.
+func (a *AppContainer) mustLoadTokenKeys() {
+	if a.tokenKeys != nil {
+		return
+	}
+	server := a.cfg.Server
+
+	secretKey := jwt.NewSecretKey("", []byte(server.TokenSecret))
+	var (
+		signing   *jwt.Key
+		verifying []*jwt.Key
+	)
+	for _, k := range server.SigningKeys {
+		pemData, err := os.ReadFile(k.PrivateKeyFile)
+		if err != nil {
+			log.Fatalf("Reading signing key %s failed: %v", k.ID, err)
+		}
+		key, err := jwt.NewPrivateKey(k.ID, k.Algorithm, pemData)
+		if err != nil {
+			log.Fatal("Loading signing key failed: ", err)
+		}
+		key.RetireAt = mustParseRetireAt(k.RetireAt)
+		if k.ID == server.SigningKeyID {
+			signing = key
+		} else {
+			verifying = append(verifying, key)
+		}
+	}
+
+	switch {
+	case server.SigningKeyID == "":
+		signing = secretKey
+	case signing == nil:
+		log.Fatalf("The signing key %s is not among the signing keys", server.SigningKeyID)
+	case server.TokenSecretRetireAt != "":
+		secretKey.RetireAt = mustParseRetireAt(server.TokenSecretRetireAt)
+		verifying = append(verifying, secretKey)
+	}
+
+	keys, err := jwt.NewKeySet(signing, verifying...)
+	if err != nil {
+		log.Fatal("Loading signing keys failed: ", err)
+	}
+	a.tokenKeys = keys
+}
+
+func mustParseRetireAt(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		log.Fatalf("Invalid retire time %q: %v", value, err)
+	}
+	return &t
+}
+
+func (a *AppContainer) setMailer() {
+	if a.mailer != nil {
+		return
+	}
+	mail := a.cfg.Mail
+	if mail.Driver == "smtp" {
+		a.mailer = mailadapter.NewSMTPMailer(mail.Host, mail.Port, mail.User, mail.Pass, mail.From)
+		return
+	}
+	a.mailer = mailadapter.NewLogMailer(mail.LogFile)
 }
 
 func (a *AppContainer) AuthService() *AuthService {
 	return a.authService
 }
 
+func (a *AppContainer) AuthServiceFromCtx(ctx context.Context) *AuthService {
+	tx, ok := valuecontext.TryGetTxFromContext(ctx)
+	if !ok {
+		return a.authService
+	}
+
+	gc, ok := tx.Tx().(*gorm.DB)
+	if !ok {
+		return a.authService
+	}
+
+	return a.newAuthService(gc)
+}
+
 func (a *AppContainer) setAuthService() {
 	if a.authService != nil {
 		return
 	}
 
-	a.authService = NewAuthService(user.NewOps(storage.NewUserRepo(a.dbConn)), []byte(a.cfg.Server.TokenSecret),
+	a.authService = a.newAuthService(a.dbConn)
+}
+
+func (a *AppContainer) newAuthService(db *gorm.DB) *AuthService {
+	return NewAuthService(user.NewOps(storage.NewUserRepo(db)),
+		invitation.NewOps(storage.NewInvitationRepo(db)),
+		verification.NewOps(storage.NewVerificationRepo(db)),
+		session.NewOps(storage.NewSessionRepo(db)),
+		accesstoken.NewOps(storage.NewAccessTokenRepo(db)),
+		twofactor.NewOps(storage.NewTwoFactorRepo(db)),
+		a.mailer,
+		MailLinks{
+			BaseURL:                 a.cfg.Mail.BaseURL,
+			VerificationExpiration:  time.Hour * time.Duration(a.cfg.Mail.VerificationExpireHours),
+			ResetPasswordExpiration: time.Minute * time.Duration(a.cfg.Mail.ResetPasswordExpireMinutes),
+		},
+		TwoFactorSettings{
+			Issuer:              a.cfg.Server.TwoFactorIssuer,
+			ChallengeExpiration: time.Minute * time.Duration(a.cfg.Server.ChallengeExpMinutes),
+		},
+		a.tokenKeys,
 		a.cfg.Server.TokenExpMinutes,
 		a.cfg.Server.RefreshTokenExpMinutes)
 }
@@ -107,6 +257,8 @@ func (a *AppContainer) BoardServiceFromCtx(ctx context.Context) *BoardService {
 		userboardrole.NewOps(storage.NewUserBoardRepo(gc)),
 		column.NewOps(storage.NewColumnRepo(gc)),
 		notification.NewOps(storage.NewNotificationRepo(gc)),
+		workspace.NewOps(storage.NewWorkspaceRepo(gc)),
+		customrole.NewOps(storage.NewCustomRoleRepo(gc)),
 	)
 }
 
@@ -136,7 +288,8 @@ func (a *AppContainer) setBoardService() {
 	if a.boardService != nil {
 		return
 	}
-	a.boardService = NewBoardService(user.NewOps(storage.NewUserRepo(a.dbConn)), board.NewOps(storage.NewBoardRepo(a.dbConn)), userboardrole.NewOps(storage.NewUserBoardRepo(a.dbConn)), column.NewOps(storage.NewColumnRepo(a.dbConn)), notification.NewOps(storage.NewNotificationRepo(a.dbConn)))
+	a.boardService = NewBoardService(user.NewOps(storage.NewUserRepo(a.dbConn)), board.NewOps(storage.NewBoardRepo(a.dbConn)), userboardrole.NewOps(storage.NewUserBoardRepo(a.dbConn)), column.NewOps(storage.NewColumnRepo(a.dbConn)), notification.NewOps(storage.NewNotificationRepo(a.dbConn)),
+		workspace.NewOps(storage.NewWorkspaceRepo(a.dbConn)), customrole.NewOps(storage.NewCustomRoleRepo(a.dbConn)))
 }
 
 func (a *AppContainer) setColumnService() {
@@ -180,6 +333,28 @@ func (a *AppContainer) setTaskService() {
 		column.NewOps(storage.NewColumnRepo(a.dbConn)), notification.NewOps(storage.NewNotificationRepo(a.dbConn)))
 }
 
+// startTrashPurger periodically hard-deletes the tasks that stayed in trash longer than the configured retention.
+func (a *AppContainer) startTrashPurger() {
+	if a.cfg.Trash.RetentionDays == 0 || a.cfg.Trash.PurgeIntervalMinutes == 0 {
+		return
+	}
+	retention := time.Duration(a.cfg.Trash.RetentionDays) * 24 * time.Hour
+	ticker := time.NewTicker(time.Duration(a.cfg.Trash.PurgeIntervalMinutes) * time.Minute)
+
+	go func() {
+		for range ticker.C {
+			purged, err := a.taskService.PurgeTrash(context.Background(), retention)
+			if err != nil {
+				log.Println("trash purge failed: ", err)
+				continue
+			}
+			if purged > 0 {
+				log.Printf("trash purge removed %d tasks", purged)
+			}
+		}
+	}()
+}
+
 func (a *AppContainer) NotificationService() *NotificationService {
 	return a.notificationService
 }
@@ -223,3 +398,263 @@ func (a *AppContainer) setCommentService() {
 		board.NewOps(storage.NewBoardRepo(a.dbConn)),
 	)
 }
+
+func (a *AppContainer) InvitationService() *InvitationService {
+	return a.invitationService
+}
+
+func (a *AppContainer) InvitationServiceFromCtx(ctx context.Context) *InvitationService {
+	tx, ok := valuecontext.TryGetTxFromContext(ctx)
+	if !ok {
+		return a.invitationService
+	}
+
+	gc, ok := tx.Tx().(*gorm.DB)
+	if !ok {
+		return a.invitationService
+	}
+
+	return NewInvitationService(
+		user.NewOps(storage.NewUserRepo(gc)),
+		board.NewOps(storage.NewBoardRepo(gc)),
+		userboardrole.NewOps(storage.NewUserBoardRepo(gc)),
+		invitation.NewOps(storage.NewInvitationRepo(gc)),
+		notification.NewOps(storage.NewNotificationRepo(gc)),
+		customrole.NewOps(storage.NewCustomRoleRepo(gc)),
+		a.inviteLinkKey(),
+		a.invitationExpiration(),
+	)
+}
+
+func (a *AppContainer) setInvitationService() {
+	if a.invitationService != nil {
+		return
+	}
+	a.invitationService = NewInvitationService(user.NewOps(storage.NewUserRepo(a.dbConn)),
+		board.NewOps(storage.NewBoardRepo(a.dbConn)),
+		userboardrole.NewOps(storage.NewUserBoardRepo(a.dbConn)),
+		invitation.NewOps(storage.NewInvitationRepo(a.dbConn)),
+		notification.NewOps(storage.NewNotificationRepo(a.dbConn)),
+		customrole.NewOps(storage.NewCustomRoleRepo(a.dbConn)),
+		a.inviteLinkKey(),
+		a.invitationExpiration(),
+	)
+}
+
+// inviteLinkKey derives the key invite links are signed with from the token secret, so a link signature is no
+// signature of a token and the other way around.
+func (a *AppContainer) inviteLinkKey() []byte {
+	mac := hmac.New(sha256.New, []byte(a.cfg.Server.TokenSecret))
+	mac.Write([]byte("invite-link"))
+	return mac.Sum(nil)
+}
+
+func (a *AppContainer) invitationExpiration() time.Duration {
+	return time.Duration(a.cfg.Invitation.ExpireHours) * time.Hour
+}
+
+func (a *AppContainer) TemplateService() *TemplateService {
+	return a.templateService
+}
+
+func (a *AppContainer) TemplateServiceFromCtx(ctx context.Context) *TemplateService {
+	tx, ok := valuecontext.TryGetTxFromContext(ctx)
+	if !ok {
+		return a.templateService
+	}
+
+	gc, ok := tx.Tx().(*gorm.DB)
+	if !ok {
+		return a.templateService
+	}
+
+	return NewTemplateService(
+		a.BoardServiceFromCtx(ctx),
+		board.NewOps(storage.NewBoardRepo(gc)),
+		userboardrole.NewOps(storage.NewUserBoardRepo(gc)),
+		column.NewOps(storage.NewColumnRepo(gc)),
+		task.NewOps(storage.NewTaskRepo(gc)),
+		boardtemplate.NewOps(storage.NewBoardTemplateRepo(gc)),
+		notification.NewOps(storage.NewNotificationRepo(gc)),
+		customrole.NewOps(storage.NewCustomRoleRepo(gc)),
+	)
+}
+
+func (a *AppContainer) setTemplateService() {
+	if a.templateService != nil {
+		return
+	}
+	a.templateService = NewTemplateService(a.boardService,
+		board.NewOps(storage.NewBoardRepo(a.dbConn)),
+		userboardrole.NewOps(storage.NewUserBoardRepo(a.dbConn)),
+		column.NewOps(storage.NewColumnRepo(a.dbConn)),
+		task.NewOps(storage.NewTaskRepo(a.dbConn)),
+		boardtemplate.NewOps(storage.NewBoardTemplateRepo(a.dbConn)),
+		notification.NewOps(storage.NewNotificationRepo(a.dbConn)),
+		customrole.NewOps(storage.NewCustomRoleRepo(a.dbConn)),
+	)
+}
+
+func (a *AppContainer) WorkspaceService() *WorkspaceService {
+	return a.workspaceService
+}
+
+func (a *AppContainer) WorkspaceServiceFromCtx(ctx context.Context) *WorkspaceService {
+	tx, ok := valuecontext.TryGetTxFromContext(ctx)
+	if !ok {
+		return a.workspaceService
+	}
+
+	gc, ok := tx.Tx().(*gorm.DB)
+	if !ok {
+		return a.workspaceService
+	}
+
+	return NewWorkspaceService(
+		a.BoardServiceFromCtx(ctx),
+		user.NewOps(storage.NewUserRepo(gc)),
+		workspace.NewOps(storage.NewWorkspaceRepo(gc)),
+	)
+}
+
+func (a *AppContainer) setWorkspaceService() {
+	if a.workspaceService != nil {
+		return
+	}
+	a.workspaceService = NewWorkspaceService(a.boardService,
+		user.NewOps(storage.NewUserRepo(a.dbConn)),
+		workspace.NewOps(storage.NewWorkspaceRepo(a.dbConn)),
+	)
+}
+
+func (a *AppContainer) RoleService() *RoleService {
+	return a.roleService
+}
+
+func (a *AppContainer) RoleServiceFromCtx(ctx context.Context) *RoleService {
+	tx, ok := valuecontext.TryGetTxFromContext(ctx)
+	if !ok {
+		return a.roleService
+	}
+
+	gc, ok := tx.Tx().(*gorm.DB)
+	if !ok {
+		return a.roleService
+	}
+
+	return NewRoleService(
+		board.NewOps(storage.NewBoardRepo(gc)),
+		userboardrole.NewOps(storage.NewUserBoardRepo(gc)),
+		workspace.NewOps(storage.NewWorkspaceRepo(gc)),
+		customrole.NewOps(storage.NewCustomRoleRepo(gc)),
+	)
+}
+
+func (a *AppContainer) setRoleService() {
+	if a.roleService != nil {
+		return
+	}
+	a.roleService = NewRoleService(
+		board.NewOps(storage.NewBoardRepo(a.dbConn)),
+		userboardrole.NewOps(storage.NewUserBoardRepo(a.dbConn)),
+		workspace.NewOps(storage.NewWorkspaceRepo(a.dbConn)),
+		customrole.NewOps(storage.NewCustomRoleRepo(a.dbConn)),
+	)
+}
+
+func (a *AppContainer) AdminService() *AdminService {
+	return a.adminService
+}
+
+func (a *AppContainer) AdminServiceFromCtx(ctx context.Context) *AdminService {
+	tx, ok := valuecontext.TryGetTxFromContext(ctx)
+	if !ok {
+		return a.adminService
+	}
+
+	gc, ok := tx.Tx().(*gorm.DB)
+	if !ok {
+		return a.adminService
+	}
+
+	return NewAdminService(
+		a.BoardServiceFromCtx(ctx),
+		user.NewOps(storage.NewUserRepo(gc)),
+		board.NewOps(storage.NewBoardRepo(gc)),
+		stats.NewOps(storage.NewStatsRepo(gc)),
+		session.NewOps(storage.NewSessionRepo(gc)),
+	)
+}
+
+func (a *AppContainer) setAdminService() {
+	if a.adminService != nil {
+		return
+	}
+	a.adminService = NewAdminService(a.boardService,
+		user.NewOps(storage.NewUserRepo(a.dbConn)),
+		board.NewOps(storage.NewBoardRepo(a.dbConn)),
+		stats.NewOps(storage.NewStatsRepo(a.dbConn)),
+		session.NewOps(storage.NewSessionRepo(a.dbConn)),
+	)
+}
+
+func (a *AppContainer) UserService() *UserService {
+	return a.userService
+}
+
+func (a *AppContainer) UserServiceFromCtx(ctx context.Context) *UserService {
+	tx, ok := valuecontext.TryGetTxFromContext(ctx)
+	if !ok {
+		return a.userService
+	}
+
+	gc, ok := tx.Tx().(*gorm.DB)
+	if !ok {
+		return a.userService
+	}
+
+	return NewUserService(
+		a.AuthServiceFromCtx(ctx),
+		a.BoardServiceFromCtx(ctx),
+		user.NewOps(storage.NewUserRepo(gc)),
+		board.NewOps(storage.NewBoardRepo(gc)),
+		userboardrole.NewOps(storage.NewUserBoardRepo(gc)),
+		workspace.NewOps(storage.NewWorkspaceRepo(gc)),
+		notification.NewOps(storage.NewNotificationRepo(gc)),
+	)
+}
+
+func (a *AppContainer) setUserService() {
+	if a.userService != nil {
+		return
+	}
+	a.userService = NewUserService(a.authService, a.boardService,
+		user.NewOps(storage.NewUserRepo(a.dbConn)),
+		board.NewOps(storage.NewBoardRepo(a.dbConn)),
+		userboardrole.NewOps(storage.NewUserBoardRepo(a.dbConn)),
+		workspace.NewOps(storage.NewWorkspaceRepo(a.dbConn)),
+		notification.NewOps(storage.NewNotificationRepo(a.dbConn)),
+	)
+}
+
+func (a *AppContainer) AccessTokenService() *AccessTokenService {
+	return a.accessTokenService
+}
+
+func (a *AppContainer) setAccessTokenService() {
+	if a.accessTokenService != nil {
+		return
+	}
+	a.accessTokenService = NewAccessTokenService(accesstoken.NewOps(storage.NewAccessTokenRepo(a.dbConn)),
+		userboardrole.NewOps(storage.NewUserBoardRepo(a.dbConn)),
+	)
+}
+
+// bootstrapAdmins gives the global admin role to the users listed in the admin config.
+func (a *AppContainer) bootstrapAdmins() {
+	for _, email := range a.cfg.Admin.Emails {
+		if _, err := a.adminService.MakeAdmin(context.Background(), email); err != nil {
+			log.Printf("making %s an admin failed: %v", email, err)
+		}
+	}
+}
@@ -2,25 +2,74 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	accesstoken "server/internal/access_token"
+	"server/internal/invitation"
+	"server/internal/session"
+	twofactor "server/internal/two_factor"
 	"server/internal/user"
+	"server/internal/verification"
 	"server/pkg/jwt"
+	"server/pkg/mailer"
+	"server/pkg/totp"
 	"time"
 
 	jwt2 "github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrEmailAlreadyVerified = errors.New("the email is already verified")
+	ErrNotRefreshToken      = errors.New("a refresh token is expected")
+	ErrNotAccessToken       = errors.New("an access token is expected")
+	ErrInvalidChallenge     = errors.New("the challenge token is invalid or expired, log in again")
 )
 
 type AuthService struct {
 	userOps                *user.Ops
-	secret                 []byte
+	invitationOps          *invitation.Ops
+	verificationOps        *verification.Ops
+	sessionOps             *session.Ops
+	accessTokenOps         *accesstoken.Ops
+	twoFactorOps           *twofactor.Ops
+	mailer                 mailer.Mailer
+	links                  MailLinks
+	twoFactor              TwoFactorSettings
+	keys                   *jwt.KeySet
 	tokenExpiration        uint
 	refreshTokenExpiration uint
 }
 
-func NewAuthService(userOps *user.Ops, secret []byte,
-	tokenExpiration uint, refreshTokenExpiration uint) *AuthService {
+// MailLinks tells where the links mailed to users point to and how long they work.
+type MailLinks struct {
+	BaseURL                 string
+	VerificationExpiration  time.Duration
+	ResetPasswordExpiration time.Duration
+}
+
+// TwoFactorSettings tells how the service shows in authenticator apps and how long a login waits for a code.
+type TwoFactorSettings struct {
+	Issuer              string
+	ChallengeExpiration time.Duration
+}
+
+func NewAuthService(userOps *user.Ops, invitationOps *invitation.Ops, verificationOps *verification.Ops,
+	sessionOps *session.Ops, accessTokenOps *accesstoken.Ops, twoFactorOps *twofactor.Ops, mailer mailer.Mailer,
+	links MailLinks, twoFactor TwoFactorSettings, keys *jwt.KeySet, tokenExpiration uint, refreshTokenExpiration uint) *AuthService {
 	return &AuthService{
 		userOps:                userOps,
-		secret:                 secret,
+		invitationOps:          invitationOps,
+		verificationOps:        verificationOps,
+		sessionOps:             sessionOps,
+		accessTokenOps:         accessTokenOps,
+		twoFactorOps:           twoFactorOps,
+		mailer:                 mailer,
+		links:                  links,
+		twoFactor:              twoFactor,
+		keys:                   keys,
 		tokenExpiration:        tokenExpiration,
 		refreshTokenExpiration: refreshTokenExpiration,
 	}
@@ -30,46 +79,260 @@ type UserToken struct {
 	AuthorizationToken string
 	RefreshToken       string
 	ExpiresAt          int64
+	// ChallengeToken alone is set when the user has two-factor authentication, it is exchanged for the other
+	// tokens along with a code
+	ChallengeToken string
+}
+
+// TwoFactorEnrollment is the pending secret of a user and the URI to show as a QR code for it.
+type TwoFactorEnrollment struct {
+	Secret          string
+	ProvisioningURI string
 }
 
+// CreateUser registers user, hands the invitations already sent to their email over to them and mails them
+// the link to verify their email. A failing mail does not fail the registration, the link can be resent. It
+// is meant to run in a transaction, so the user is not kept when the invitations could not be handed over.
 func (s *AuthService) CreateUser(ctx context.Context, user *user.User) (*user.User, error) {
-	return s.userOps.Create(ctx, user)
+	createdUser, err := s.userOps.Create(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.invitationOps.ClaimByEmail(ctx, createdUser.Email, createdUser.ID); err != nil {
+		return nil, err
+	}
+	if err := s.SendVerification(ctx, createdUser); err != nil {
+		log.Printf("sending the verification mail to %s failed: %v", createdUser.Email, err)
+	}
+	return createdUser, nil
+}
+
+// SendVerification mails u a link to verify their email, the links mailed before stop working.
+func (s *AuthService) SendVerification(ctx context.Context, u *user.User) error {
+	token, err := s.verificationOps.Issue(ctx, u.ID, verification.PurposeVerifyEmail, s.links.VerificationExpiration)
+	if err != nil {
+		return err
+	}
+	body := fmt.Sprintf("Hi %s,\n\nPlease verify your email by opening the link below:\n%s\n\nThe link expires in %s.\n",
+		u.FirstName, s.link("/verify-email", token), humanDuration(s.links.VerificationExpiration))
+	return s.mailer.Send(ctx, mailer.Message{To: u.Email, Subject: "Verify your email", Body: body})
+}
+
+// ResendVerification mails the current user a new verification link.
+func (s *AuthService) ResendVerification(ctx context.Context, userID uuid.UUID) error {
+	u, err := s.userOps.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if u == nil {
+		return user.ErrUserNotFound
+	}
+	if u.IsVerified() {
+		return ErrEmailAlreadyVerified
+	}
+	return s.SendVerification(ctx, u)
+}
+
+func (s *AuthService) VerifyEmail(ctx context.Context, token string) error {
+	t, err := s.verificationOps.Consume(ctx, token, verification.PurposeVerifyEmail)
+	if err != nil {
+		return err
+	}
+	return s.userOps.MarkVerified(ctx, t.UserID)
+}
+
+// RequestPasswordReset mails a link to reset the password to email. Nothing tells the caller whether an
+// account uses email, so the endpoint can not be used to look accounts up.
+func (s *AuthService) RequestPasswordReset(ctx context.Context, email string) error {
+	u, err := s.userOps.GetUserByEmail(ctx, email)
+	if errors.Is(err, user.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if u.IsSuspended() {
+		return nil
+	}
+
+	token, err := s.verificationOps.Issue(ctx, u.ID, verification.PurposeResetPassword, s.links.ResetPasswordExpiration)
+	if err != nil {
+		return err
+	}
+	body := fmt.Sprintf("Hi %s,\n\nSomeone asked to reset the password of your account. If it was you, open the link below:\n%s\n\n"+
+		"The link expires in %s. If you did not ask for it, you can ignore this mail.\n",
+		u.FirstName, s.link("/reset-password", token), humanDuration(s.links.ResetPasswordExpiration))
+	if err := s.mailer.Send(ctx, mailer.Message{To: u.Email, Subject: "Reset your password", Body: body}); err != nil {
+		log.Printf("sending the password reset mail to %s failed: %v", u.Email, err)
+	}
+	return nil
+}
+
+// ResetPassword sets the new password of the user the token was mailed to and ends their sessions. Following
+// the link proves they own the email, so it is verified as well.
+func (s *AuthService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	if err := user.ValidatePasswordWithFeedback(newPassword); err != nil {
+		return err
+	}
+	t, err := s.verificationOps.Consume(ctx, token, verification.PurposeResetPassword)
+	if err != nil {
+		return err
+	}
+	if err := s.userOps.ResetPassword(ctx, t.UserID, newPassword); err != nil {
+		return err
+	}
+	if err := s.sessionOps.RevokeAll(ctx, t.UserID, nil); err != nil {
+		return err
+	}
+	return s.userOps.MarkVerified(ctx, t.UserID)
+}
+
+func (s *AuthService) link(path, token string) string {
+	return s.links.BaseURL + path + "?token=" + url.QueryEscape(token)
+}
+
+func humanDuration(d time.Duration) string {
+	if d >= time.Hour && d%time.Hour == 0 {
+		return fmt.Sprintf("%d hours", d/time.Hour)
+	}
+	return fmt.Sprintf("%d minutes", d/time.Minute)
 }
 
-func (s *AuthService) Login(ctx context.Context, email, pass string) (*UserToken, error) {
+// Login opens a session of the user on device and returns its first pair of tokens. A user with two-factor
+// authentication only gets a challenge token, LoginWithCode opens the session once they send a code.
+func (s *AuthService) Login(ctx context.Context, email, pass, device, ip string) (*UserToken, error) {
 	fetchedUser, err := s.userOps.GetUserByEmailAndPassword(ctx, email, pass)
 	if err != nil {
 		return nil, err
 	}
 
-	// calc expiration time values
-	var (
-		authExp    = time.Now().Add(time.Minute * time.Duration(s.tokenExpiration))
-		refreshExp = time.Now().Add(time.Minute * time.Duration(s.refreshTokenExpiration))
-	)
+	enabled, err := s.twoFactorOps.IsEnabled(ctx, fetchedUser.ID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		challengeID, err := s.twoFactorOps.StartChallenge(ctx, fetchedUser.ID, s.twoFactor.ChallengeExpiration)
+		if err != nil {
+			return nil, err
+		}
+		challenge, err := s.keys.Sign(s.userClaims(fetchedUser, jwt.TokenTypeChallenge, uuid.Nil, challengeID.String(),
+			time.Now().Add(s.twoFactor.ChallengeExpiration)))
+		if err != nil {
+			return nil, err
+		}
+		return &UserToken{ChallengeToken: challenge}, nil
+	}
 
-	authToken, err := jwt.CreateToken(s.secret, s.userClaims(fetchedUser, authExp))
+	sess, err := s.sessionOps.Start(ctx, fetchedUser.ID, device, ip, s.refreshTTL())
 	if err != nil {
-		return nil, err // todo
+		return nil, err
 	}
+	return s.issueTokens(fetchedUser, sess)
+}
 
-	refreshToken, err := jwt.CreateToken(s.secret, s.userClaims(fetchedUser, refreshExp))
+// LoginWithCode finishes the login of a user with two-factor authentication, code is one of their authenticator
+// app or a recovery code.
+func (s *AuthService) LoginWithCode(ctx context.Context, challenge, code, device, ip string) (*UserToken, error) {
+	claim, err := s.keys.Parse(challenge)
+	if err != nil || claim.Type != jwt.TokenTypeChallenge {
+		return nil, ErrInvalidChallenge
+	}
+	challengeID, err := uuid.Parse(claim.ID)
 	if err != nil {
-		return nil, err // todo
+		return nil, ErrInvalidChallenge
 	}
 
-	return &UserToken{
-		AuthorizationToken: authToken,
-		RefreshToken:       refreshToken,
-		ExpiresAt:          authExp.Unix(),
+	u, err := s.userOps.GetUserByID(ctx, claim.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, user.ErrUserNotFound
+	}
+	if u.IsSuspended() {
+		return nil, user.ErrUserSuspended
+	}
+
+	if err := s.twoFactorOps.VerifyChallenge(ctx, u.ID, challengeID, code); err != nil {
+		return nil, err
+	}
+
+	sess, err := s.sessionOps.Start(ctx, u.ID, device, ip, s.refreshTTL())
+	if err != nil {
+		return nil, err
+	}
+	return s.issueTokens(u, sess)
+}
+
+// EnrollTwoFactor gives the user a new secret for their authenticator app, two-factor authentication is only on
+// once EnableTwoFactor confirmed a first code.
+func (s *AuthService) EnrollTwoFactor(ctx context.Context, userID uuid.UUID) (*TwoFactorEnrollment, error) {
+	u, err := s.userOps.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, user.ErrUserNotFound
+	}
+	tf, err := s.twoFactorOps.Enroll(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &TwoFactorEnrollment{
+		Secret:          tf.Secret,
+		ProvisioningURI: totp.ProvisioningURI(s.twoFactor.Issuer, u.Email, tf.Secret),
 	}, nil
 }
 
-func (s *AuthService) RefreshAuth(ctx context.Context, refreshToken string) (*UserToken, error) {
-	claim, err := jwt.ParseToken(refreshToken, s.secret)
+// EnableTwoFactor turns two-factor authentication on with the first code of the app and returns the recovery codes.
+func (s *AuthService) EnableTwoFactor(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	return s.twoFactorOps.Enable(ctx, userID, code)
+}
+
+// DisableTwoFactor turns two-factor authentication off, it takes the password and a code of the user.
+func (s *AuthService) DisableTwoFactor(ctx context.Context, userID uuid.UUID, password, code string) error {
+	if _, err := s.userOps.CheckPassword(ctx, userID, password); err != nil {
+		return err
+	}
+	if err := s.twoFactorOps.Verify(ctx, userID, code); err != nil {
+		return err
+	}
+	return s.twoFactorOps.Disable(ctx, userID)
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the user once their password is confirmed.
+func (s *AuthService) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, password string) ([]string, error) {
+	if _, err := s.userOps.CheckPassword(ctx, userID, password); err != nil {
+		return nil, err
+	}
+	enabled, err := s.twoFactorOps.IsEnabled(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return nil, twofactor.ErrNotEnabled
+	}
+	return s.twoFactorOps.RegenerateRecoveryCodes(ctx, userID)
+}
+
+func (s *AuthService) GetTwoFactorStatus(ctx context.Context, userID uuid.UUID) (*twofactor.Status, error) {
+	return s.twoFactorOps.GetStatus(ctx, userID)
+}
+
+// RefreshAuth exchanges a refresh token for a new pair of tokens. The refresh token works once, using it
+// again revokes its session.
+func (s *AuthService) RefreshAuth(ctx context.Context, refreshToken, ip string) (*UserToken, error) {
+	claim, err := s.keys.Parse(refreshToken)
 	if err != nil {
 		return nil, err
 	}
+	if claim.Type != jwt.TokenTypeRefresh {
+		return nil, ErrNotRefreshToken
+	}
+	tokenID, err := uuid.Parse(claim.ID)
+	if err != nil {
+		return nil, ErrNotRefreshToken
+	}
 
 	u, err := s.userOps.GetUserByID(ctx, claim.UserID)
 	if err != nil {
@@ -80,31 +343,129 @@ func (s *AuthService) RefreshAuth(ctx context.Context, refreshToken string) (*Us
 		return nil, user.ErrUserNotFound
 	}
 
-	// calc expiration time values
-	var (
-		authExp = time.Now().Add(time.Minute * time.Duration(s.tokenExpiration))
-	)
+	if u.IsSuspended() {
+		return nil, user.ErrUserSuspended
+	}
 
-	authToken, err := jwt.CreateToken(s.secret, s.userClaims(u, authExp))
+	sess, err := s.sessionOps.Rotate(ctx, claim.SessionID, tokenID, ip, s.refreshTTL())
 	if err != nil {
-		return nil, err // todo
+		return nil, err
+	}
+	return s.issueTokens(u, sess)
+}
+
+// VerifyToken checks the bearer token of a request, a JWT access token or a personal access token, and returns
+// the claims the request is made with.
+func (s *AuthService) VerifyToken(ctx context.Context, token string) (*jwt.UserClaims, error) {
+	if accesstoken.IsSecret(token) {
+		return s.verifyAccessToken(ctx, token)
+	}
+	claims, err := s.keys.Parse(token)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Type != jwt.TokenTypeAccess {
+		return nil, ErrNotAccessToken
+	}
+	// a logout, a password change or a reused refresh token ends the session before its tokens expire
+	if err := s.sessionOps.CheckActive(ctx, claims.SessionID); err != nil {
+		return nil, err
+	}
+	// the role in the token is the one of login time, suspensions and role changes count from the next request
+	u, err := s.userOps.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, user.ErrUserNotFound
+	}
+	if u.IsSuspended() {
+		return nil, user.ErrUserSuspended
+	}
+	claims.Role = u.Role.String()
+	return claims, nil
+}
+
+func (s *AuthService) verifyAccessToken(ctx context.Context, secret string) (*jwt.UserClaims, error) {
+	t, err := s.accessTokenOps.Authenticate(ctx, secret)
+	if err != nil {
+		return nil, err
+	}
+	u, err := s.userOps.GetUserByID(ctx, t.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, accesstoken.ErrInvalidToken
+	}
+	if u.IsSuspended() {
+		return nil, user.ErrUserSuspended
+	}
+	return &jwt.UserClaims{
+		UserID:   u.ID,
+		Role:     u.Role.String(),
+		Type:     jwt.TokenTypePersonal,
+		ReadOnly: !t.CanWrite(),
+		BoardID:  t.BoardID,
+	}, nil
+}
+
+// Logout ends the session the access token of the user belongs to.
+func (s *AuthService) Logout(ctx context.Context, userID, sessionID uuid.UUID) error {
+	return s.sessionOps.Revoke(ctx, userID, sessionID)
+}
+
+// LogoutAll ends every session of the user.
+func (s *AuthService) LogoutAll(ctx context.Context, userID uuid.UUID) error {
+	return s.sessionOps.RevokeAll(ctx, userID, nil)
+}
+
+// LogoutOthers ends the sessions of the user but the current one.
+func (s *AuthService) LogoutOthers(ctx context.Context, userID, sessionID uuid.UUID) error {
+	return s.sessionOps.RevokeAll(ctx, userID, &sessionID)
+}
+
+func (s *AuthService) GetSessions(ctx context.Context, userID uuid.UUID) ([]session.Session, error) {
+	return s.sessionOps.GetActive(ctx, userID)
+}
+
+// issueTokens signs an access token and the refresh token sess expects next. The access token works until it
+// expires or sess ends, whichever comes first.
+func (s *AuthService) issueTokens(u *user.User, sess *session.Session) (*UserToken, error) {
+	authExp := time.Now().Add(time.Minute * time.Duration(s.tokenExpiration))
+
+	authToken, err := s.keys.Sign(s.userClaims(u, jwt.TokenTypeAccess, sess.ID, "", authExp))
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := s.keys.Sign(s.userClaims(u, jwt.TokenTypeRefresh, sess.ID, sess.TokenID.String(), sess.ExpiresAt))
+	if err != nil {
+		return nil, err
 	}
 
 	return &UserToken{
 		AuthorizationToken: authToken,
 		RefreshToken:       refreshToken,
-		ExpiresAt:          authExp.UnixMilli(),
+		ExpiresAt:          authExp.Unix(),
 	}, nil
 }
 
-func (s *AuthService) userClaims(user *user.User, exp time.Time) *jwt.UserClaims {
+func (s *AuthService) refreshTTL() time.Duration {
+	return time.Minute * time.Duration(s.refreshTokenExpiration)
+}
+
+func (s *AuthService) userClaims(user *user.User, tokenType jwt.TokenType, sessionID uuid.UUID, tokenID string, exp time.Time) *jwt.UserClaims {
 	return &jwt.UserClaims{
 		RegisteredClaims: jwt2.RegisteredClaims{
+			ID: tokenID,
 			ExpiresAt: &jwt2.NumericDate{
 				Time: exp,
 			},
 		},
-		UserID: user.ID,
-		Role:   user.Role.String(),
+		UserID:    user.ID,
+		Role:      user.Role.String(),
+		Type:      tokenType,
+		SessionID: sessionID,
 	}
 }
@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"server/internal/board"
 	"server/internal/column"
+	customrole "server/internal/custom_role"
 	"server/internal/notification"
 	u "server/internal/user"
 	userboardrole "server/internal/user_board_role"
+	"server/internal/workspace"
 	"server/pkg/rbac"
+	"strings"
 
 	"github.com/google/uuid"
 )
@@ -23,6 +26,12 @@ var (
 	ErrPermissionDeniedToInvite = errors.New("permission denied: cannot invite users")
 	ErrAMember                  = errors.New("user already is a member")
 	ErrPermissionDeniedToDelete = errors.New("permission denied: can not delete the board")
+	ErrCantRemoveOwner          = errors.New("an owner can not be removed from the board, change their role first")
+	ErrLastOwner                = errors.New("a board must keep at least one owner, transfer the ownership first")
+	ErrNotTransferTarget        = errors.New("the ownership transfer is not addressed to you")
+	ErrCantManageYourself       = errors.New("you can not change your own membership, use leave instead")
+	ErrUserNotMember            = errors.New("user is not a member of this board")
+	ErrInviteeNotVerified       = errors.New("the invited user has not verified their email yet")
 )
 
 // BoardService handles board-related operations
@@ -32,17 +41,22 @@ type BoardService struct {
 	userBoardRoleOps *userboardrole.Ops
 	columnOps        *column.Ops
 	notificatinOps   *notification.Ops
+	workspaceOps     *workspace.Ops
+	customRoleOps    *customrole.Ops
 }
 
 // NewBoardService creates a new BoardService
 func NewBoardService(userOps *u.Ops, boardOps *board.Ops,
 	userBoardOps *userboardrole.Ops,
-	columnOps *column.Ops, notificatinOps *notification.Ops) *BoardService {
+	columnOps *column.Ops, notificatinOps *notification.Ops, workspaceOps *workspace.Ops,
+	customRoleOps *customrole.Ops) *BoardService {
 	return &BoardService{userOps: userOps,
 		boardOps:         boardOps,
 		userBoardRoleOps: userBoardOps,
 		columnOps:        columnOps,
-		notificatinOps:   notificatinOps}
+		notificatinOps:   notificatinOps,
+		workspaceOps:     workspaceOps,
+		customRoleOps:    customRoleOps}
 }
 
 func (s *BoardService) GetFullBoardByID(ctx context.Context, userID uuid.UUID, boardID uuid.UUID) (*board.Board, error) {
@@ -50,20 +64,20 @@ func (s *BoardService) GetFullBoardByID(ctx context.Context, userID uuid.UUID, b
 	if err != nil {
 		return nil, err
 	}
-	if b.Type == "private" {
+	if !b.IsPublic() {
 		fetcherRole, err := s.userBoardRoleOps.GetUserBoardRole(ctx, userID, boardID)
 		if err != nil {
 			return nil, ErrPermissionDeniedToInvite
 		}
 
-		if !rbac.HasPermission(fetcherRole, rbac.PermissionViewBoard) {
+		if !rbac.HasPermission(ctx, fetcherRole, rbac.PermissionViewBoard) {
 			return nil, ErrPermissionDeniedToInvite
 		}
 	}
 	return b, err
 }
 
-func (s *BoardService) GetUserBoards(ctx context.Context, userID uuid.UUID, page, pageSize uint) ([]board.Board, uint, error) {
+func (s *BoardService) GetUserBoards(ctx context.Context, userID uuid.UUID, page, pageSize uint, withArchived bool) ([]board.Board, uint, error) {
 	user, err := s.userOps.GetUserByID(ctx, userID)
 	if err != nil {
 		return nil, 0, err
@@ -73,10 +87,10 @@ func (s *BoardService) GetUserBoards(ctx context.Context, userID uuid.UUID, page
 		return nil, 0, u.ErrUserNotFound
 	}
 
-	return s.boardOps.GetUserBoards(ctx, userID, page, pageSize)
+	return s.boardOps.GetUserBoards(ctx, userID, page, pageSize, withArchived)
 }
 
-func (s *BoardService) GetPublicBoards(ctx context.Context, userID uuid.UUID, page, pageSize uint) ([]board.Board, uint, error) {
+func (s *BoardService) GetPublicBoards(ctx context.Context, userID uuid.UUID, page, pageSize uint, withArchived bool) ([]board.Board, uint, error) {
 	user, err := s.userOps.GetUserByID(ctx, userID)
 	if err != nil {
 		return nil, 0, err
@@ -86,10 +100,15 @@ func (s *BoardService) GetPublicBoards(ctx context.Context, userID uuid.UUID, pa
 		return nil, 0, u.ErrUserNotFound
 	}
 
-	return s.boardOps.GetPublicBoards(ctx, userID, page, pageSize)
+	return s.boardOps.GetPublicBoards(ctx, userID, page, pageSize, withArchived)
 }
 
 func (s *BoardService) CreateBoard(ctx context.Context, b *board.Board, ub *userboardrole.UserBoardRole) error {
+	return s.CreateBoardWithColumns(ctx, b, ub, nil)
+}
+
+// CreateBoardWithColumns creates a board with the given columns, in their order, instead of the default done column.
+func (s *BoardService) CreateBoardWithColumns(ctx context.Context, b *board.Board, ub *userboardrole.UserBoardRole, columns []column.Column) error {
 	user, err := s.userOps.GetUserByID(ctx, ub.UserID)
 	if err != nil {
 		return err
@@ -98,6 +117,19 @@ func (s *BoardService) CreateBoard(ctx context.Context, b *board.Board, ub *user
 	if user == nil {
 		return u.ErrUserNotFound
 	}
+	// guests of a workspace only work on the boards they are invited to
+	if b.WorkspaceID != nil {
+		m, err := s.workspaceOps.GetMember(ctx, *b.WorkspaceID, ub.UserID)
+		if errors.Is(err, workspace.ErrMemberNotFound) {
+			return ErrPermissionDenied
+		}
+		if err != nil {
+			return err
+		}
+		if m.Role == workspace.RoleGuest {
+			return ErrPermissionDenied
+		}
+	}
 
 	err = s.boardOps.Create(ctx, b)
 	if err != nil {
@@ -110,6 +142,21 @@ func (s *BoardService) CreateBoard(ctx context.Context, b *board.Board, ub *user
 	if err != nil {
 		return err
 	}
+	if err := s.syncWorkspaceBoard(ctx, b); err != nil {
+		return err
+	}
+	if len(columns) > 0 {
+		for i := range columns {
+			columns[i].BoardID = b.ID
+			columns[i].OrderNum = uint(i + 1)
+		}
+		created, err := s.columnOps.CreateColumns(ctx, columns)
+		if err != nil {
+			return err
+		}
+		b.Columns = append(b.Columns, created...)
+		return nil
+	}
 	// set first "done" default column
 
 	col, err := s.columnOps.SetDoneAsDefault(ctx, ub.BoardID)
@@ -120,97 +167,523 @@ func (s *BoardService) CreateBoard(ctx context.Context, b *board.Board, ub *user
 	return nil
 }
 
-// InviteUser invites a user to the board
-func (s *BoardService) InviteUser(ctx context.Context, inviterID uuid.UUID, inviteeEmail string, userBoardRole *userboardrole.UserBoardRole) error {
+func (s *BoardService) DeleteBoardByID(ctx context.Context, ub *userboardrole.UserBoardRole) error {
+	// check board exists
+	b, err := s.boardOps.GetBoardByID(ctx, ub.BoardID)
+
+	if err != nil {
+		return err
+	}
+	if b == nil {
+		return board.ErrBoardNotFound
+	}
 
-	if userBoardRole.Role == string(rbac.RoleOwner) {
-		return ErrOwnerExists
+	// check user exists
+	user, err := s.userOps.GetUserByID(ctx, ub.UserID)
+	if err != nil {
+		return err
 	}
-	isPossibleRole := rbac.IsAPossibleRole(userBoardRole.Role)
 
-	if !isPossibleRole {
-		return ErrUndefinedRole
+	if user == nil {
+		return u.ErrUserNotFound
 	}
 
-	inviterRole, err := s.userBoardRoleOps.GetUserBoardRole(ctx, inviterID, userBoardRole.BoardID)
+	// check user has permission of removing
+	role, err := s.userBoardRoleOps.GetUserBoardRole(ctx, ub.UserID, ub.BoardID)
 	if err != nil {
 		return ErrPermissionDeniedToInvite
 	}
 
-	if !rbac.HasPermission(inviterRole, rbac.PermissionInviteUsers) {
-		return ErrPermissionDeniedToInvite
+	if !rbac.HasPermission(ctx, role, rbac.PermissionRemoveBoard) {
+		return ErrPermissionDeniedToDelete
 	}
 
-	invitedUser, err := s.userOps.GetUserByEmail(ctx, inviteeEmail)
+	err = s.boardOps.Delete(ctx, ub.BoardID)
 	if err != nil {
 		return err
 	}
-	if invitedUser == nil {
-		return u.ErrUserNotFound
+
+	return nil
+}
+
+// GetBoardMembers lists the members of a board with their roles, private boards only to their members.
+func (s *BoardService) GetBoardMembers(ctx context.Context, userID, boardID uuid.UUID) ([]userboardrole.UserBoardRole, error) {
+	b, err := s.boardOps.GetBoardByID(ctx, boardID)
+	if err != nil {
+		return nil, err
 	}
-	userBoardRole.UserID = invitedUser.ID
-	b, err := s.boardOps.GetBoardByID(ctx, userBoardRole.BoardID)
+	if b == nil {
+		return nil, board.ErrBoardNotFound
+	}
+	if !b.IsPublic() {
+		role, err := s.userBoardRoleOps.GetUserBoardRole(ctx, userID, boardID)
+		if err != nil || !rbac.HasPermission(ctx, role, rbac.PermissionViewBoard) {
+			return nil, ErrPermissionDenied
+		}
+	}
+	return s.userBoardRoleOps.GetBoardMembers(ctx, boardID)
+}
+
+// checkMemberManagement loads the board and the membership of memberID after checking that actorID
+// holds permission on the board and is not targeting themselves.
+func (s *BoardService) checkMemberManagement(ctx context.Context, actorID, boardID, memberID uuid.UUID, permission rbac.Permission) (*board.Board, *userboardrole.UserBoardRole, error) {
+	actorRole, err := s.userBoardRoleOps.GetUserBoardRole(ctx, actorID, boardID)
+	if err != nil || !rbac.HasPermission(ctx, actorRole, permission) {
+		return nil, nil, ErrPermissionDenied
+	}
+	if actorID == memberID {
+		return nil, nil, ErrCantManageYourself
+	}
+	b, err := s.boardOps.GetBoardByID(ctx, boardID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if b == nil {
+		return nil, nil, board.ErrBoardNotFound
+	}
+	member, err := s.userBoardRoleOps.GetUserBoardRoleObj(ctx, memberID, boardID)
 	if err != nil {
+		return nil, nil, ErrUserNotMember
+	}
+	return b, member, nil
+}
+
+// ChangeMemberRole gives a member of the board a new non-owner role and notifies them. Co-owners can
+// be demoted as long as the board keeps another owner.
+func (s *BoardService) ChangeMemberRole(ctx context.Context, actorID, boardID, memberID uuid.UUID, role string) (*userboardrole.UserBoardRole, error) {
+	if role == string(rbac.RoleOwner) {
+		return nil, ErrOwnerExists
+	}
+	if !rbac.IsAPossibleRole(ctx, role) {
+		return nil, ErrUndefinedRole
+	}
+	b, member, err := s.checkMemberManagement(ctx, actorID, boardID, memberID, rbac.PermissionSetRole)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.customRoleOps.ValidateForBoard(ctx, role, b); err != nil {
+		return nil, ErrUndefinedRole
+	}
+	if member.Role == role {
+		return member, nil
+	}
+	if member.Role == string(rbac.RoleOwner) {
+		if err := s.checkNotLastOwner(ctx, boardID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.userBoardRoleOps.UpdateRole(ctx, memberID, boardID, rbac.Role(role)); err != nil {
+		return nil, err
+	}
+	member.Role = role
+
+	description := fmt.Sprintf("Your role on the Board '%s' is now '%s'", b.Name, role)
+	notif := notification.NewNotification(description, notification.RoleChanged, member.ID)
+	if err := s.notificatinOps.CreateNotification(ctx, notif); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// RemoveMember takes a non-owner member off the board. Their tasks are unassigned and their comments
+// move to the member commentsTo when given, otherwise they keep their author.
+func (s *BoardService) RemoveMember(ctx context.Context, actorID, boardID, memberID uuid.UUID, commentsTo *uuid.UUID) error {
+	b, member, err := s.checkMemberManagement(ctx, actorID, boardID, memberID, rbac.PermissionRemoveUser)
+	if err != nil {
+		return err
+	}
+	if member.Role == string(rbac.RoleOwner) {
+		return ErrCantRemoveOwner
+	}
+	if commentsTo != nil {
+		if *commentsTo == memberID {
+			return ErrUserNotMember
+		}
+		if _, err := s.userBoardRoleOps.GetUserBoardRole(ctx, *commentsTo, boardID); err != nil {
+			return ErrUserNotMember
+		}
+	}
+
+	// the notification has to be created while the membership still exists
+	description := fmt.Sprintf("You were removed from the Board '%s'", b.Name)
+	notif := notification.NewNotification(description, notification.MemberRemoved, member.ID)
+	if err := s.notificatinOps.CreateNotification(ctx, notif); err != nil {
 		return err
 	}
 
-	role, err := s.userBoardRoleOps.GetUserBoardRole(ctx, invitedUser.ID, b.ID)
-	if role != "" && err == nil {
-		return ErrAMember
+	if err := s.userBoardRoleOps.RemoveUserBoardRole(ctx, memberID, boardID, commentsTo); err != nil {
+		return err
 	}
 
-	err = s.userBoardRoleOps.SetUserBoardRole(ctx, userBoardRole)
+	description = fmt.Sprintf("'%s' was removed from the Board '%s'", member.User.Email, b.Name)
+	notif = notification.NewNotification(description, notification.MemberRemoved, uuid.Nil)
+	return s.notificatinOps.NotifBoardManagers(ctx, notif, boardID, actorID)
+}
+
+// LeaveBoard lets a member leave the board, their comments stay on the board. An owner can only leave
+// while the board has another owner.
+func (s *BoardService) LeaveBoard(ctx context.Context, userID, boardID uuid.UUID) error {
+	member, err := s.userBoardRoleOps.GetUserBoardRoleObj(ctx, userID, boardID)
+	if err != nil {
+		return ErrUserNotMember
+	}
+	if member.Role == string(rbac.RoleOwner) {
+		if err := s.checkNotLastOwner(ctx, boardID); err != nil {
+			return err
+		}
+	}
+	b, err := s.boardOps.GetBoardByID(ctx, boardID)
 	if err != nil {
 		return err
 	}
-	invitedByuser, err := s.userOps.GetUserByID(ctx, inviterID)
-	if err!=nil{
+	if b == nil {
+		return board.ErrBoardNotFound
+	}
+
+	if err := s.userBoardRoleOps.RemoveUserBoardRole(ctx, userID, boardID, nil); err != nil {
 		return err
 	}
-	description := fmt.Sprintf("Welcome to the Board '%s' you were invited By '%s'", b.Name, invitedByuser.FirstName)
-	notif := notification.NewNotification(description, notification.UserInvited, userBoardRole.ID)
-	err = s.notificatinOps.CreateNotification(ctx, notif)
+
+	description := fmt.Sprintf("'%s' left the Board '%s'", member.User.Email, b.Name)
+	notif := notification.NewNotification(description, notification.MemberLeft, uuid.Nil)
+	return s.notificatinOps.NotifBoardManagers(ctx, notif, boardID, userID)
+}
+
+// checkNotLastOwner fails when the board has a single owner, who would be the one losing the role.
+func (s *BoardService) checkNotLastOwner(ctx context.Context, boardID uuid.UUID) error {
+	owners, err := s.userBoardRoleOps.CountOwners(ctx, boardID)
 	if err != nil {
 		return err
 	}
+	if owners <= 1 {
+		return ErrLastOwner
+	}
 	return nil
 }
 
-func (s *BoardService) DeleteBoardByID(ctx context.Context, ub *userboardrole.UserBoardRole) error {
-	// check board exists
-	b, err := s.boardOps.GetBoardByID(ctx, ub.BoardID)
+// GetSoleOwnedBoardIDs lists the boards that would be left without an owner if userID went away.
+// Deleting an account has to hand these boards over or delete them first.
+func (s *BoardService) GetSoleOwnedBoardIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	return s.userBoardRoleOps.GetSoleOwnedBoardIDs(ctx, userID)
+}
+
+// GetOwnershipTransfer returns the pending ownership transfer of the board to its members.
+func (s *BoardService) GetOwnershipTransfer(ctx context.Context, userID, boardID uuid.UUID) (*userboardrole.OwnershipTransfer, error) {
+	role, err := s.userBoardRoleOps.GetUserBoardRole(ctx, userID, boardID)
+	if err != nil || !rbac.HasPermission(ctx, role, rbac.PermissionViewBoard) {
+		return nil, ErrPermissionDenied
+	}
+	return s.userBoardRoleOps.GetOwnershipTransfer(ctx, boardID)
+}
 
+// ProposeOwnershipTransfer offers the ownership of the board to another member, who has to accept it.
+// With keepOwnership the proposer stays an owner and the target becomes a co-owner.
+func (s *BoardService) ProposeOwnershipTransfer(ctx context.Context, ownerID, boardID, targetID uuid.UUID, keepOwnership bool) (*userboardrole.OwnershipTransfer, error) {
+	b, target, err := s.checkMemberManagement(ctx, ownerID, boardID, targetID, rbac.PermissionTransferOwnership)
+	if err != nil {
+		return nil, err
+	}
+	if target.Role == string(rbac.RoleOwner) {
+		return nil, ErrOwnerExists
+	}
+
+	transfer := &userboardrole.OwnershipTransfer{
+		BoardID:       boardID,
+		FromUserID:    ownerID,
+		ToUserID:      targetID,
+		KeepOwnership: keepOwnership,
+	}
+	if err := s.userBoardRoleOps.SaveOwnershipTransfer(ctx, transfer); err != nil {
+		return nil, err
+	}
+
+	description := fmt.Sprintf("You were offered the ownership of the Board '%s'", b.Name)
+	notif := notification.NewNotification(description, notification.OwnershipNotif, target.ID)
+	if err := s.notificatinOps.CreateNotification(ctx, notif); err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// AcceptOwnershipTransfer makes the target of the pending transfer an owner of the board. Unless the
+// proposer chose to keep the ownership they become a maintainer. It reports false when the offer was void, it
+// is dropped all the same and nothing else changes.
+func (s *BoardService) AcceptOwnershipTransfer(ctx context.Context, userID, boardID uuid.UUID) (bool, error) {
+	transfer, err := s.userBoardRoleOps.GetOwnershipTransfer(ctx, boardID)
+	if err != nil {
+		return false, err
+	}
+	if transfer.ToUserID != userID {
+		return false, ErrNotTransferTarget
+	}
+	if err := s.userBoardRoleOps.DeleteOwnershipTransfer(ctx, boardID); err != nil {
+		return false, err
+	}
+
+	// the offer is void once the proposer is no owner anymore or the target left the board, no error so the
+	// deletion above is kept
+	proposer, err := s.userBoardRoleOps.GetUserBoardRoleObj(ctx, transfer.FromUserID, boardID)
+	if err != nil || proposer.Role != string(rbac.RoleOwner) {
+		return false, nil
+	}
+	if _, err := s.userBoardRoleOps.GetUserBoardRole(ctx, userID, boardID); err != nil {
+		return false, nil
+	}
+	b, err := s.boardOps.GetBoardByID(ctx, boardID)
+	if err != nil {
+		return false, err
+	}
+	if b == nil {
+		return false, board.ErrBoardNotFound
+	}
+
+	if err := s.userBoardRoleOps.UpdateRole(ctx, userID, boardID, rbac.RoleOwner); err != nil {
+		return false, err
+	}
+	if !transfer.KeepOwnership {
+		if err := s.userBoardRoleOps.UpdateRole(ctx, transfer.FromUserID, boardID, rbac.RoleMaintainer); err != nil {
+			return false, err
+		}
+	}
+
+	description := fmt.Sprintf("Your ownership offer of the Board '%s' was accepted", b.Name)
+	notif := notification.NewNotification(description, notification.OwnershipNotif, proposer.ID)
+	if err := s.notificatinOps.CreateNotification(ctx, notif); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ForceTransferOwnership makes userID the only owner of the board, adding them to it when needed, and turns
+// the former owners into maintainers. It is meant for global admins and does not check any board role.
+func (s *BoardService) ForceTransferOwnership(ctx context.Context, boardID, userID uuid.UUID) error {
+	b, err := s.boardOps.GetBoardByID(ctx, boardID)
+	if err != nil {
+		return err
+	}
+	newOwner, err := s.userOps.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if newOwner == nil {
+		return u.ErrUserNotFound
+	}
+	members, err := s.userBoardRoleOps.GetBoardMembers(ctx, boardID)
+	if err != nil {
+		return err
+	}
+
+	isMember := false
+	description := fmt.Sprintf("An administrator transferred the ownership of the Board '%s' to %s", b.Name, newOwner.FirstName)
+	for _, m := range members {
+		if m.UserID == userID {
+			isMember = true
+			continue
+		}
+		if m.Role != string(rbac.RoleOwner) {
+			continue
+		}
+		if err := s.userBoardRoleOps.UpdateRole(ctx, m.UserID, boardID, rbac.RoleMaintainer); err != nil {
+			return err
+		}
+		notif := notification.NewNotification(description, notification.OwnershipNotif, m.ID)
+		if err := s.notificatinOps.CreateNotification(ctx, notif); err != nil {
+			return err
+		}
+	}
+
+	ub := &userboardrole.UserBoardRole{UserID: userID, BoardID: boardID, Role: string(rbac.RoleOwner)}
+	if isMember {
+		err = s.userBoardRoleOps.UpdateRole(ctx, userID, boardID, rbac.RoleOwner)
+		if err == nil {
+			ub, err = s.userBoardRoleOps.GetUserBoardRoleObj(ctx, userID, boardID)
+		}
+	} else {
+		err = s.userBoardRoleOps.SetUserBoardRole(ctx, ub)
+	}
+	if err != nil {
+		return err
+	}
+	if err := s.userBoardRoleOps.DeleteOwnershipTransfer(ctx, boardID); err != nil {
+		return err
+	}
+
+	description = fmt.Sprintf("An administrator made you the owner of the Board '%s'", b.Name)
+	notif := notification.NewNotification(description, notification.OwnershipNotif, ub.ID)
+	return s.notificatinOps.CreateNotification(ctx, notif)
+}
+
+// CancelOwnershipTransfer drops the pending transfer of the board. Its target declines it this way and
+// owners withdraw it.
+func (s *BoardService) CancelOwnershipTransfer(ctx context.Context, userID, boardID uuid.UUID) error {
+	transfer, err := s.userBoardRoleOps.GetOwnershipTransfer(ctx, boardID)
+	if err != nil {
+		return err
+	}
+	if transfer.ToUserID != userID {
+		role, err := s.userBoardRoleOps.GetUserBoardRole(ctx, userID, boardID)
+		if err != nil || !rbac.HasPermission(ctx, role, rbac.PermissionTransferOwnership) {
+			return ErrPermissionDenied
+		}
+	}
+	if err := s.userBoardRoleOps.DeleteOwnershipTransfer(ctx, boardID); err != nil {
+		return err
+	}
+	if transfer.ToUserID != userID {
+		return nil
+	}
+
+	proposer, err := s.userBoardRoleOps.GetUserBoardRoleObj(ctx, transfer.FromUserID, boardID)
+	if err != nil {
+		// the proposer left the board, nobody is waiting for an answer
+		return nil
+	}
+	b, err := s.boardOps.GetBoardByID(ctx, boardID)
 	if err != nil {
 		return err
 	}
 	if b == nil {
 		return board.ErrBoardNotFound
 	}
+	description := fmt.Sprintf("Your ownership offer of the Board '%s' was declined", b.Name)
+	notif := notification.NewNotification(description, notification.OwnershipNotif, proposer.ID)
+	return s.notificatinOps.CreateNotification(ctx, notif)
+}
 
-	// check user exists
-	user, err := s.userOps.GetUserByID(ctx, ub.UserID)
+// UpdateBoard changes the given fields of a board and lets the other members know.
+func (s *BoardService) UpdateBoard(ctx context.Context, userID, boardID uuid.UUID, fields *board.UpdateFields) (*board.Board, error) {
+	role, err := s.userBoardRoleOps.GetUserBoardRole(ctx, userID, boardID)
+	if err != nil || !rbac.HasPermission(ctx, role, rbac.PermissionEditBoard) {
+		return nil, ErrPermissionDenied
+	}
+	if err := s.boardOps.CheckWritable(ctx, boardID); err != nil {
+		return nil, err
+	}
+
+	b, err := s.boardOps.Update(ctx, boardID, fields)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if fields.Type != nil {
+		if err := s.syncWorkspaceBoard(ctx, b); err != nil {
+			return nil, err
+		}
 	}
 
-	if user == nil {
-		return u.ErrUserNotFound
+	var changes []string
+	if fields.Name != nil {
+		changes = append(changes, "name")
 	}
+	if fields.Description != nil {
+		changes = append(changes, "description")
+	}
+	if fields.Type != nil {
+		changes = append(changes, "visibility")
+	}
+	if fields.WIPPolicy != nil {
+		changes = append(changes, "wip policy")
+	}
+	description := fmt.Sprintf("The %s of the Board '%s' changed", strings.Join(changes, ", "), b.Name)
+	notif := notification.NewNotification(description, notification.BoardUpdated, uuid.Nil)
+	if err := s.notificatinOps.NotifBoardMembers(ctx, notif, boardID, userID); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
 
-	// check user has permission of removing
-	role, err := s.userBoardRoleOps.GetUserBoardRole(ctx, ub.UserID, ub.BoardID)
+// ArchiveBoard makes a board read-only for every role and hides it from the board lists.
+func (s *BoardService) ArchiveBoard(ctx context.Context, userID, boardID uuid.UUID) (*board.Board, error) {
+	return s.setArchived(ctx, userID, boardID, true)
+}
+
+func (s *BoardService) UnarchiveBoard(ctx context.Context, userID, boardID uuid.UUID) (*board.Board, error) {
+	return s.setArchived(ctx, userID, boardID, false)
+}
+
+func (s *BoardService) setArchived(ctx context.Context, userID, boardID uuid.UUID, archive bool) (*board.Board, error) {
+	b, err := s.boardOps.GetBoardByID(ctx, boardID)
 	if err != nil {
-		return ErrPermissionDeniedToInvite
+		return nil, err
+	}
+	role, err := s.userBoardRoleOps.GetUserBoardRole(ctx, userID, boardID)
+	if err != nil || !rbac.HasPermission(ctx, role, rbac.PermissionArchiveBoard) {
+		return nil, ErrPermissionDenied
 	}
 
-	if !rbac.HasPermission(role, rbac.PermissionRemoveBoard) {
-		return ErrPermissionDeniedToDelete
+	action := "archived"
+	if archive {
+		err = s.boardOps.Archive(ctx, b)
+	} else {
+		action = "unarchived"
+		err = s.boardOps.Unarchive(ctx, b)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	err = s.boardOps.Delete(ctx, ub.BoardID)
+	notif := notification.NewNotification(fmt.Sprintf("The Board '%s' was %s", b.Name, action), notification.BoardArchived, uuid.Nil)
+	if err := s.notificatinOps.NotifBoardMembers(ctx, notif, boardID, userID); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// SyncWorkspaceMember brings the inherited board roles of a workspace member in line with their
+// workspace role on every board of the workspace. A member without a role loses all of them.
+func (s *BoardService) SyncWorkspaceMember(ctx context.Context, m *workspace.Member) error {
+	boards, err := s.workspaceOps.GetBoards(ctx, m.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	for i := range boards {
+		if err := s.applyWorkspaceRole(ctx, m, &boards[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncWorkspaceBoard gives the members of the workspace of b the roles their workspace role gives on it.
+func (s *BoardService) syncWorkspaceBoard(ctx context.Context, b *board.Board) error {
+	if b.WorkspaceID == nil {
+		return nil
+	}
+	members, err := s.workspaceOps.GetMembers(ctx, *b.WorkspaceID)
 	if err != nil {
 		return err
 	}
+	for i := range members {
+		if err := s.applyWorkspaceRole(ctx, &members[i], b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyWorkspaceRole sets the inherited role of m on b. An explicit role on the board is kept as it is.
+func (s *BoardService) applyWorkspaceRole(ctx context.Context, m *workspace.Member, b *board.Board) error {
+	current, err := s.userBoardRoleOps.GetUserBoardRoleObj(ctx, m.UserID, b.ID)
+	if err != nil && !errors.Is(err, userboardrole.ErrUserRoleNotFound) {
+		return err
+	}
+	if current != nil && !current.Inherited {
+		return nil
+	}
 
+	role, ok := workspace.DefaultBoardRole(m.Role, b.Type)
+	switch {
+	case current == nil && ok:
+		return s.userBoardRoleOps.SetUserBoardRole(ctx, &userboardrole.UserBoardRole{
+			UserID:    m.UserID,
+			BoardID:   b.ID,
+			Role:      string(role),
+			Inherited: true,
+		})
+	case current != nil && !ok:
+		return s.userBoardRoleOps.RemoveUserBoardRole(ctx, m.UserID, b.ID, nil)
+	case current != nil && current.Role != string(role):
+		return s.userBoardRoleOps.UpdateInheritedRole(ctx, m.UserID, b.ID, role)
+	}
 	return nil
 }
@@ -44,9 +44,12 @@ func (s *ColumnService) CreateColumn(ctx context.Context, name string, boardID,
 		return nil, ErrPermissionDeniedToCreateColumn
 	}
 
-	if !rbac.HasPermission(role, rbac.PermissionManageColumns) {
+	if !rbac.HasPermission(ctx, role, rbac.PermissionManageColumns) {
 		return nil, ErrPermissionDeniedToCreateColumn
 	}
+	if err := s.boardOps.CheckWritable(ctx, col.BoardID); err != nil {
+		return nil, err
+	}
 
 	if err := s.colOps.Create(ctx, col); err != nil {
 		return nil, err
@@ -57,6 +60,7 @@ func (s *ColumnService) CreateColumn(ctx context.Context, name string, boardID,
 		Name:     col.Name,
 		BoardID:  col.BoardID,
 		OrderNum: col.OrderNum,
+		Kind:     string(col.Kind),
 	}, nil
 }
 
@@ -70,6 +74,7 @@ func (s *ColumnService) GetColumnByID(ctx context.Context, id uuid.UUID) (*entit
 		Name:     col.Name,
 		BoardID:  col.BoardID,
 		OrderNum: col.OrderNum,
+		Kind:     string(col.Kind),
 	}, nil
 }
 
@@ -86,6 +91,7 @@ func (s *ColumnService) GetMinOrderColumn(ctx context.Context, boardID uuid.UUID
 		Name:     c.Name,
 		BoardID:  c.BoardID,
 		OrderNum: c.OrderNum,
+		Kind:     string(c.Kind),
 	}, nil
 }
 
@@ -97,6 +103,7 @@ func (s *ColumnService) CreateColumns(ctx context.Context, columns []entities.Co
 			Name:     col.Name,
 			BoardID:  col.BoardID,
 			OrderNum: col.OrderNum,
+			Kind:     column.Kind(col.Kind),
 		}
 	}
 	//check to see board exists?
@@ -114,9 +121,12 @@ func (s *ColumnService) CreateColumns(ctx context.Context, columns []entities.Co
 		return nil, ErrPermissionDeniedToCreateColumn
 	}
 
-	if !rbac.HasPermission(role, rbac.PermissionManageColumns) {
+	if !rbac.HasPermission(ctx, role, rbac.PermissionManageColumns) {
 		return nil, ErrPermissionDeniedToCreateColumn
 	}
+	if b.IsArchived() {
+		return nil, board.ErrBoardArchived
+	}
 
 	createdCols, err := s.colOps.CreateColumns(ctx, colModels)
 	if err != nil {
@@ -130,6 +140,8 @@ func (s *ColumnService) CreateColumns(ctx context.Context, columns []entities.Co
 			Name:     col.Name,
 			BoardID:  col.BoardID,
 			OrderNum: col.OrderNum,
+			Rank:     col.Rank,
+			Kind:     string(col.Kind),
 		}
 	}
 	return createdEntities, nil
@@ -152,9 +164,12 @@ func (s *ColumnService) DeleteColumn(ctx context.Context, columnID, userID uuid.
 		return ErrPermissionDeniedToDelete
 	}
 
-	if !rbac.HasPermission(role, rbac.PermissionManageColumns) {
+	if !rbac.HasPermission(ctx, role, rbac.PermissionManageColumns) {
 		return ErrPermissionDeniedToDelete
 	}
+	if err := s.boardOps.CheckWritable(ctx, col.BoardID); err != nil {
+		return err
+	}
 	return s.colOps.Delete(ctx, columnID)
 }
 
@@ -165,9 +180,12 @@ func (s *ColumnService) ReorderColumns(ctx context.Context, userID, boardID uuid
 		return nil, ErrPermissionDenied
 	}
 
-	if !rbac.HasPermission(role, rbac.PermissionManageColumns) {
+	if !rbac.HasPermission(ctx, role, rbac.PermissionManageColumns) {
 		return nil, ErrPermissionDenied
 	}
+	if err := s.boardOps.CheckWritable(ctx, boardID); err != nil {
+		return nil, err
+	}
 	err = s.colOps.ReorderColumns(ctx, boardID, newOrder)
 	if err != nil {
 		return nil, err
@@ -175,3 +193,118 @@ func (s *ColumnService) ReorderColumns(ctx context.Context, userID, boardID uuid
 
 	return s.colOps.GetColumns(ctx, boardID)
 }
+
+// UpdateColumnKind marks a column as backlog, in progress or done. A board may have any number
+// of done columns; moving a task into one of them completes it.
+func (s *ColumnService) UpdateColumnKind(ctx context.Context, userID, columnID uuid.UUID, kind column.Kind) (*column.Column, error) {
+	col, err := s.colOps.GetColumnByID(ctx, columnID)
+	if err != nil {
+		return nil, err
+	}
+	if col == nil {
+		return nil, column.ErrColumnNotFound
+	}
+
+	role, err := s.userBoardRoleOps.GetUserBoardRole(ctx, userID, col.BoardID)
+	if err != nil {
+		return nil, ErrPermissionDenied
+	}
+
+	if !rbac.HasPermission(ctx, role, rbac.PermissionManageColumns) {
+		return nil, ErrPermissionDenied
+	}
+	if err := s.boardOps.CheckWritable(ctx, col.BoardID); err != nil {
+		return nil, err
+	}
+
+	if err := s.colOps.UpdateKind(ctx, columnID, kind); err != nil {
+		return nil, err
+	}
+	col.Kind = kind
+	return col, nil
+}
+
+func (s *ColumnService) UpdateColumn(ctx context.Context, userID, columnID uuid.UUID, fields *column.UpdateFields) (*column.Column, error) {
+	col, err := s.colOps.GetColumnByID(ctx, columnID)
+	if err != nil {
+		return nil, err
+	}
+	if col == nil {
+		return nil, column.ErrColumnNotFound
+	}
+
+	role, err := s.userBoardRoleOps.GetUserBoardRole(ctx, userID, col.BoardID)
+	if err != nil {
+		return nil, ErrPermissionDenied
+	}
+
+	if !rbac.HasPermission(ctx, role, rbac.PermissionManageColumns) {
+		return nil, ErrPermissionDenied
+	}
+	if err := s.boardOps.CheckWritable(ctx, col.BoardID); err != nil {
+		return nil, err
+	}
+
+	return s.colOps.Update(ctx, columnID, fields)
+}
+
+func (s *ColumnService) GetTransitions(ctx context.Context, userID, boardID uuid.UUID) ([]column.Transition, error) {
+	role, err := s.userBoardRoleOps.GetUserBoardRole(ctx, userID, boardID)
+	if err != nil {
+		return nil, ErrPermissionDenied
+	}
+
+	if !rbac.HasPermission(ctx, role, rbac.PermissionViewBoard) {
+		return nil, ErrPermissionDenied
+	}
+
+	return s.colOps.GetTransitions(ctx, boardID)
+}
+
+func (s *ColumnService) SetTransitions(ctx context.Context, userID, boardID uuid.UUID, transitions []column.Transition) ([]column.Transition, error) {
+	role, err := s.userBoardRoleOps.GetUserBoardRole(ctx, userID, boardID)
+	if err != nil {
+		return nil, ErrPermissionDenied
+	}
+
+	if !rbac.HasPermission(ctx, role, rbac.PermissionManageColumns) {
+		return nil, ErrPermissionDenied
+	}
+	if err := s.boardOps.CheckWritable(ctx, boardID); err != nil {
+		return nil, err
+	}
+
+	for _, t := range transitions {
+		for _, r := range t.Roles {
+			if !rbac.IsAPossibleRole(ctx, r) {
+				return nil, ErrUndefinedRole
+			}
+		}
+	}
+
+	return s.colOps.SetTransitions(ctx, boardID, transitions)
+}
+
+func (s *ColumnService) MoveColumn(ctx context.Context, userID, columnID uuid.UUID, afterID, beforeID *uuid.UUID) (*column.Column, error) {
+	col, err := s.colOps.GetColumnByID(ctx, columnID)
+	if err != nil {
+		return nil, err
+	}
+	if col == nil {
+		return nil, column.ErrColumnNotFound
+	}
+
+	role, err := s.userBoardRoleOps.GetUserBoardRole(ctx, userID, col.BoardID)
+	if err != nil {
+		return nil, ErrPermissionDenied
+	}
+
+	if !rbac.HasPermission(ctx, role, rbac.PermissionManageColumns) {
+		return nil, ErrPermissionDenied
+	}
+	if err := s.boardOps.CheckWritable(ctx, col.BoardID); err != nil {
+		return nil, err
+	}
+
+	return s.colOps.Move(ctx, columnID, afterID, beforeID)
+}
@@ -48,11 +48,14 @@ func (s *CommentService) CreateComment(ctx context.Context, c *comment.Comment,
 	if err != nil {
 		return ErrPermissionDenied
 	}
-	if userBoardRoleObj.ID != *task.UserBoardRoleID && userBoardRoleObj.Role == string(rbac.RoleEditor) {
+	role := rbac.Role(userBoardRoleObj.Role)
+	ownTask := task.UserBoardRoleID != nil && *task.UserBoardRoleID == userBoardRoleObj.ID
+	if !rbac.HasPermission(ctx, role, rbac.PermissionCommentAnyTask) &&
+		!(ownTask && rbac.HasPermission(ctx, role, rbac.PermissionCommentOwnTask)) {
 		return ErrPermissionDenied
 	}
-	if !rbac.HasPermission(rbac.Role(userBoardRoleObj.Role), rbac.PermissionCommentOwnTask) {
-		return ErrPermissionDenied
+	if err := s.boardOps.CheckWritable(ctx, task.BoardID); err != nil {
+		return err
 	}
 	c.UserBoardRoleID = userBoardRoleObj.ID
 	err = s.commentOps.Insert(ctx, c)
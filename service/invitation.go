@@ -0,0 +1,331 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"server/internal/board"
+	customrole "server/internal/custom_role"
+	"server/internal/invitation"
+	"server/internal/notification"
+	u "server/internal/user"
+	userboardrole "server/internal/user_board_role"
+	"server/pkg/rbac"
+	"server/pkg/signature"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InvitationService handles invitations to boards and invite links
+type InvitationService struct {
+	userOps          *u.Ops
+	boardOps         *board.Ops
+	userBoardRoleOps *userboardrole.Ops
+	invitationOps    *invitation.Ops
+	notificationOps  *notification.Ops
+	customRoleOps    *customrole.Ops
+	secret           []byte
+	expiration       time.Duration
+}
+
+// NewInvitationService creates a new InvitationService, secret signs the invite links and
+// invitations expire after expiration unless it is zero
+func NewInvitationService(userOps *u.Ops, boardOps *board.Ops,
+	userBoardRoleOps *userboardrole.Ops, invitationOps *invitation.Ops,
+	notificationOps *notification.Ops, customRoleOps *customrole.Ops, secret []byte, expiration time.Duration) *InvitationService {
+	return &InvitationService{
+		userOps:          userOps,
+		boardOps:         boardOps,
+		userBoardRoleOps: userBoardRoleOps,
+		invitationOps:    invitationOps,
+		notificationOps:  notificationOps,
+		customRoleOps:    customRoleOps,
+		secret:           secret,
+		expiration:       expiration,
+	}
+}
+
+// checkInviter makes sure userID may invite people to the board and that role can be given by an invitation.
+func (s *InvitationService) checkInviter(ctx context.Context, userID, boardID uuid.UUID, role string) (*board.Board, error) {
+	if role == string(rbac.RoleOwner) {
+		return nil, ErrOwnerExists
+	}
+	if role != "" && !rbac.IsAPossibleRole(ctx, role) {
+		return nil, ErrUndefinedRole
+	}
+
+	inviterRole, err := s.userBoardRoleOps.GetUserBoardRole(ctx, userID, boardID)
+	if err != nil || !rbac.HasPermission(ctx, inviterRole, rbac.PermissionInviteUsers) {
+		return nil, ErrPermissionDeniedToInvite
+	}
+
+	b, err := s.boardOps.GetBoardByID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, board.ErrBoardNotFound
+	}
+	if role != "" {
+		if err := s.customRoleOps.ValidateForBoard(ctx, role, b); err != nil {
+			return nil, ErrUndefinedRole
+		}
+	}
+	return b, nil
+}
+
+// InviteUser creates a pending invitation of email to the board. The email does not need an account yet,
+// the invitation is claimed when it registers.
+func (s *InvitationService) InviteUser(ctx context.Context, inviterID uuid.UUID, inv *invitation.Invitation) error {
+	if inv.Role == "" {
+		return ErrUndefinedRole
+	}
+	if _, err := s.checkInviter(ctx, inviterID, inv.BoardID, inv.Role); err != nil {
+		return err
+	}
+
+	invitedUser, err := s.userOps.GetUserByEmail(ctx, inv.Email)
+	if err != nil && !errors.Is(err, u.ErrUserNotFound) {
+		return err
+	}
+	if invitedUser != nil {
+		if !invitedUser.IsVerified() {
+			return ErrInviteeNotVerified
+		}
+		if s.isMember(ctx, invitedUser.ID, inv.BoardID) {
+			return ErrAMember
+		}
+		inv.InviteeUserID = &invitedUser.ID
+	}
+
+	inv.InviterUserID = inviterID
+	if s.expiration > 0 {
+		expiresAt := time.Now().Add(s.expiration)
+		inv.ExpiresAt = &expiresAt
+	}
+	return s.invitationOps.Create(ctx, inv)
+}
+
+// GetBoardInvitations lists the open invitations of a board to the members who can invite.
+func (s *InvitationService) GetBoardInvitations(ctx context.Context, userID, boardID uuid.UUID) ([]invitation.Invitation, error) {
+	if _, err := s.checkInviter(ctx, userID, boardID, ""); err != nil {
+		return nil, err
+	}
+	return s.invitationOps.GetPendingByBoardID(ctx, boardID)
+}
+
+// RevokeInvitation withdraws a pending invitation of the board.
+func (s *InvitationService) RevokeInvitation(ctx context.Context, userID, boardID, invitationID uuid.UUID) error {
+	if _, err := s.checkInviter(ctx, userID, boardID, ""); err != nil {
+		return err
+	}
+	inv, err := s.invitationOps.GetByID(ctx, invitationID)
+	if err != nil {
+		return err
+	}
+	if inv.BoardID != boardID {
+		return invitation.ErrInvitationNotFound
+	}
+	if inv.Status != invitation.StatusPending {
+		return invitation.ErrInvitationNotPending
+	}
+	return s.invitationOps.UpdateStatus(ctx, inv.ID, invitation.StatusRevoked)
+}
+
+// GetUserInvitations lists the open invitations sent to userID.
+func (s *InvitationService) GetUserInvitations(ctx context.Context, userID uuid.UUID) ([]invitation.Invitation, error) {
+	return s.invitationOps.GetPendingByUserID(ctx, userID)
+}
+
+// getOwnInvitation loads an open invitation addressed to userID.
+func (s *InvitationService) getOwnInvitation(ctx context.Context, userID, invitationID uuid.UUID) (*invitation.Invitation, error) {
+	inv, err := s.invitationOps.GetByID(ctx, invitationID)
+	if err != nil {
+		return nil, err
+	}
+	// someone else's invitation is reported as missing so ids can not be probed
+	if inv.InviteeUserID == nil || *inv.InviteeUserID != userID {
+		return nil, invitation.ErrInvitationNotFound
+	}
+	if err := inv.CheckOpen(time.Now()); err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+// AcceptInvitation makes userID a member of the board of the invitation with its role.
+func (s *InvitationService) AcceptInvitation(ctx context.Context, userID, invitationID uuid.UUID) (*userboardrole.UserBoardRole, error) {
+	inv, err := s.getOwnInvitation(ctx, userID, invitationID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.invitationOps.UpdateStatus(ctx, inv.ID, invitation.StatusAccepted); err != nil {
+		return nil, err
+	}
+	ubr, err := s.join(ctx, userID, inv.BoardID, inv.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	inviter, err := s.userOps.GetUserByID(ctx, inv.InviterUserID)
+	if err != nil {
+		return nil, err
+	}
+	description := fmt.Sprintf("Welcome to the Board '%s'", inv.BoardName)
+	if inviter != nil {
+		description = fmt.Sprintf("Welcome to the Board '%s' you were invited By '%s'", inv.BoardName, inviter.FirstName)
+	}
+	notif := notification.NewNotification(description, notification.UserInvited, ubr.ID)
+	if err := s.notificationOps.CreateNotification(ctx, notif); err != nil {
+		return nil, err
+	}
+	return ubr, nil
+}
+
+// DeclineInvitation refuses an invitation sent to userID.
+func (s *InvitationService) DeclineInvitation(ctx context.Context, userID, invitationID uuid.UUID) error {
+	inv, err := s.getOwnInvitation(ctx, userID, invitationID)
+	if err != nil {
+		return err
+	}
+	return s.invitationOps.UpdateStatus(ctx, inv.ID, invitation.StatusDeclined)
+}
+
+// isMember tells whether userID has an explicit role on the board, a role inherited from the
+// workspace can still be replaced by an invitation.
+func (s *InvitationService) isMember(ctx context.Context, userID, boardID uuid.UUID) bool {
+	ubr, err := s.userBoardRoleOps.GetUserBoardRoleObj(ctx, userID, boardID)
+	return err == nil && !ubr.Inherited
+}
+
+// join adds userID to the board with role, only users with a verified email can join.
+func (s *InvitationService) join(ctx context.Context, userID, boardID uuid.UUID, role string) (*userboardrole.UserBoardRole, error) {
+	user, err := s.userOps.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, u.ErrUserNotFound
+	}
+	if !user.IsVerified() {
+		return nil, u.ErrEmailNotVerified
+	}
+
+	current, err := s.userBoardRoleOps.GetUserBoardRoleObj(ctx, userID, boardID)
+	if err == nil {
+		if !current.Inherited {
+			return nil, ErrAMember
+		}
+		if err := s.userBoardRoleOps.UpdateRole(ctx, userID, boardID, rbac.Role(role)); err != nil {
+			return nil, err
+		}
+		current.Role = role
+		current.Inherited = false
+		return current, nil
+	}
+	ubr := &userboardrole.UserBoardRole{
+		UserID:  userID,
+		BoardID: boardID,
+		Role:    role,
+	}
+	if err := s.userBoardRoleOps.SetUserBoardRole(ctx, ubr); err != nil {
+		return nil, err
+	}
+	return ubr, nil
+}
+
+// CreateInviteLink creates a link to join the board with role, usable maxUses times (0 for no limit)
+// until validFor has passed (0 for no expiry).
+func (s *InvitationService) CreateInviteLink(ctx context.Context, userID, boardID uuid.UUID, role string, maxUses uint, validFor time.Duration) (*invitation.Link, error) {
+	if role == "" {
+		return nil, ErrUndefinedRole
+	}
+	if _, err := s.checkInviter(ctx, userID, boardID, role); err != nil {
+		return nil, err
+	}
+	link := &invitation.Link{
+		BoardID:         boardID,
+		Role:            role,
+		CreatedByUserID: userID,
+		MaxUses:         maxUses,
+	}
+	if validFor > 0 {
+		expiresAt := time.Now().Add(validFor)
+		link.ExpiresAt = &expiresAt
+	}
+	if err := s.invitationOps.CreateLink(ctx, link); err != nil {
+		return nil, err
+	}
+	link.Token = signature.Sign(s.secret, link.ID.String())
+	return link, nil
+}
+
+// GetInviteLinks lists every link of the board, revoked and used up ones included.
+func (s *InvitationService) GetInviteLinks(ctx context.Context, userID, boardID uuid.UUID) ([]invitation.Link, error) {
+	if _, err := s.checkInviter(ctx, userID, boardID, ""); err != nil {
+		return nil, err
+	}
+	links, err := s.invitationOps.GetLinksByBoardID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range links {
+		links[i].Token = signature.Sign(s.secret, links[i].ID.String())
+	}
+	return links, nil
+}
+
+// RevokeInviteLink stops a link of the board from being used.
+func (s *InvitationService) RevokeInviteLink(ctx context.Context, userID, boardID, linkID uuid.UUID) error {
+	if _, err := s.checkInviter(ctx, userID, boardID, ""); err != nil {
+		return err
+	}
+	link, err := s.invitationOps.GetLinkByID(ctx, linkID)
+	if err != nil {
+		return err
+	}
+	if link.BoardID != boardID {
+		return invitation.ErrLinkNotFound
+	}
+	return s.invitationOps.RevokeLink(ctx, link.ID)
+}
+
+// JoinByLink makes userID a member of the board of the signed link token.
+func (s *InvitationService) JoinByLink(ctx context.Context, userID uuid.UUID, token string) (*userboardrole.UserBoardRole, error) {
+	payload, err := signature.Verify(s.secret, token)
+	if err != nil {
+		return nil, invitation.ErrLinkNotFound
+	}
+	linkID, err := uuid.Parse(payload)
+	if err != nil {
+		return nil, invitation.ErrLinkNotFound
+	}
+	link, err := s.invitationOps.GetLinkByID(ctx, linkID)
+	if err != nil {
+		return nil, err
+	}
+	if s.isMember(ctx, userID, link.BoardID) {
+		return nil, ErrAMember
+	}
+	if err := s.invitationOps.UseLink(ctx, link); err != nil {
+		return nil, err
+	}
+	ubr, err := s.join(ctx, userID, link.BoardID, link.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := s.boardOps.GetBoardByID(ctx, link.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, board.ErrBoardNotFound
+	}
+	notif := notification.NewNotification(fmt.Sprintf("Welcome to the Board '%s'", b.Name), notification.UserInvited, ubr.ID)
+	if err := s.notificationOps.CreateNotification(ctx, notif); err != nil {
+		return nil, err
+	}
+	return ubr, nil
+}
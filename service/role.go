@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"server/internal/board"
+	customrole "server/internal/custom_role"
+	userboardrole "server/internal/user_board_role"
+	"server/internal/workspace"
+	"server/pkg/rbac"
+
+	"github.com/google/uuid"
+)
+
+// RoleService manages the custom roles of boards and workspaces.
+type RoleService struct {
+	boardOps         *board.Ops
+	userBoardRoleOps *userboardrole.Ops
+	workspaceOps     *workspace.Ops
+	customRoleOps    *customrole.Ops
+}
+
+func NewRoleService(boardOps *board.Ops, userBoardRoleOps *userboardrole.Ops, workspaceOps *workspace.Ops,
+	customRoleOps *customrole.Ops) *RoleService {
+	return &RoleService{
+		boardOps:         boardOps,
+		userBoardRoleOps: userBoardRoleOps,
+		workspaceOps:     workspaceOps,
+		customRoleOps:    customRoleOps,
+	}
+}
+
+// GetBoardRoles lists the custom roles that can be given on a board, its own and the ones of its workspace.
+func (s *RoleService) GetBoardRoles(ctx context.Context, userID, boardID uuid.UUID) ([]customrole.CustomRole, error) {
+	b, err := s.boardOps.GetBoardByID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	if !b.IsPublic() {
+		role, err := s.userBoardRoleOps.GetUserBoardRole(ctx, userID, boardID)
+		if err != nil || !rbac.HasPermission(ctx, role, rbac.PermissionViewBoard) {
+			return nil, ErrPermissionDenied
+		}
+	}
+	return s.customRoleOps.GetBoardRoles(ctx, b)
+}
+
+// CreateBoardRole defines a role of a board, it needs manage_roles on the board.
+func (s *RoleService) CreateBoardRole(ctx context.Context, userID, boardID uuid.UUID, r *customrole.CustomRole) error {
+	if err := s.checkManageRoles(ctx, userID, boardID); err != nil {
+		return err
+	}
+	r.BoardID = &boardID
+	r.WorkspaceID = nil
+	return s.customRoleOps.Create(ctx, r)
+}
+
+// UpdateBoardRole renames a role of a board or changes its permissions, members holding it get
+// the new permissions right away.
+func (s *RoleService) UpdateBoardRole(ctx context.Context, userID, boardID, roleID uuid.UUID, fields *customrole.UpdateFields) (*customrole.CustomRole, error) {
+	if _, err := s.boardRole(ctx, userID, boardID, roleID); err != nil {
+		return nil, err
+	}
+	return s.customRoleOps.Update(ctx, roleID, fields)
+}
+
+// DeleteBoardRole removes a role of a board once no member holds it.
+func (s *RoleService) DeleteBoardRole(ctx context.Context, userID, boardID, roleID uuid.UUID) error {
+	r, err := s.boardRole(ctx, userID, boardID, roleID)
+	if err != nil {
+		return err
+	}
+	return s.customRoleOps.Delete(ctx, r)
+}
+
+// GetWorkspaceRoles lists the roles shared by the boards of a workspace to its members.
+func (s *RoleService) GetWorkspaceRoles(ctx context.Context, userID, workspaceID uuid.UUID) ([]customrole.CustomRole, error) {
+	if _, err := s.workspaceMember(ctx, userID, workspaceID); err != nil {
+		return nil, err
+	}
+	return s.customRoleOps.GetWorkspaceRoles(ctx, workspaceID)
+}
+
+// CreateWorkspaceRole lets an admin define a role for every board of the workspace.
+func (s *RoleService) CreateWorkspaceRole(ctx context.Context, userID, workspaceID uuid.UUID, r *customrole.CustomRole) error {
+	if err := s.checkWorkspaceAdmin(ctx, userID, workspaceID); err != nil {
+		return err
+	}
+	r.WorkspaceID = &workspaceID
+	r.BoardID = nil
+	return s.customRoleOps.Create(ctx, r)
+}
+
+func (s *RoleService) UpdateWorkspaceRole(ctx context.Context, userID, workspaceID, roleID uuid.UUID, fields *customrole.UpdateFields) (*customrole.CustomRole, error) {
+	if _, err := s.workspaceRole(ctx, userID, workspaceID, roleID); err != nil {
+		return nil, err
+	}
+	return s.customRoleOps.Update(ctx, roleID, fields)
+}
+
+func (s *RoleService) DeleteWorkspaceRole(ctx context.Context, userID, workspaceID, roleID uuid.UUID) error {
+	r, err := s.workspaceRole(ctx, userID, workspaceID, roleID)
+	if err != nil {
+		return err
+	}
+	return s.customRoleOps.Delete(ctx, r)
+}
+
+func (s *RoleService) checkManageRoles(ctx context.Context, userID, boardID uuid.UUID) error {
+	role, err := s.userBoardRoleOps.GetUserBoardRole(ctx, userID, boardID)
+	if err != nil || !rbac.HasPermission(ctx, role, rbac.PermissionManageRoles) {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+// boardRole loads a role defined on the board after checking that userID can manage the roles of the board.
+func (s *RoleService) boardRole(ctx context.Context, userID, boardID, roleID uuid.UUID) (*customrole.CustomRole, error) {
+	if err := s.checkManageRoles(ctx, userID, boardID); err != nil {
+		return nil, err
+	}
+	r, err := s.customRoleOps.GetByID(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+	if r.BoardID == nil || *r.BoardID != boardID {
+		return nil, customrole.ErrRoleNotFound
+	}
+	return r, nil
+}
+
+func (s *RoleService) workspaceMember(ctx context.Context, userID, workspaceID uuid.UUID) (*workspace.Member, error) {
+	if _, err := s.workspaceOps.GetByID(ctx, workspaceID); err != nil {
+		return nil, err
+	}
+	m, err := s.workspaceOps.GetMember(ctx, workspaceID, userID)
+	if errors.Is(err, workspace.ErrMemberNotFound) {
+		return nil, ErrPermissionDenied
+	}
+	return m, err
+}
+
+func (s *RoleService) checkWorkspaceAdmin(ctx context.Context, userID, workspaceID uuid.UUID) error {
+	m, err := s.workspaceMember(ctx, userID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if m.Role != workspace.RoleAdmin {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+func (s *RoleService) workspaceRole(ctx context.Context, userID, workspaceID, roleID uuid.UUID) (*customrole.CustomRole, error) {
+	if err := s.checkWorkspaceAdmin(ctx, userID, workspaceID); err != nil {
+		return nil, err
+	}
+	r, err := s.customRoleOps.GetByID(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+	if r.WorkspaceID == nil || *r.WorkspaceID != workspaceID {
+		return nil, customrole.ErrRoleNotFound
+	}
+	return r, nil
+}
@@ -10,6 +10,7 @@ import (
 	u "server/internal/user"
 	userboardrole "server/internal/user_board_role"
 	"server/pkg/rbac"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -39,80 +40,122 @@ func (s *BoardService) GetUserTasks(ctx context.Context, userID uuid.UUID, page,
 	return nil, 0, nil
 }
 
-func (s *TaskService) CreateTask(ctx context.Context, task *t.Task) error {
+// CreateTask puts the new task in the first column of its board. When that column is over its
+// WIP limit and the board only warns about it, the task is created and the warning returned.
+func (s *TaskService) CreateTask(ctx context.Context, task *t.Task) (*column.WIPLimitExceededError, error) {
 	// check if the creator exists
 	user, err := s.userOps.GetUserByID(ctx, task.CreatedByUserID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if user == nil {
-		return u.ErrUserNotFound
+		return nil, u.ErrUserNotFound
 	}
 
 	// check if the board exists
 	board, err := s.boardOps.GetBoardByID(ctx, task.BoardID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if board == nil {
-		return b.ErrBoardNotFound
+		return nil, b.ErrBoardNotFound
 	}
 
 	//check if parent id is not null and the parent task exists for sub tasks
 	if task.ParentID != nil {
 		_, err := s.taskOps.GetTaskByID(ctx, *task.ParentID)
 		if err != nil {
-			return t.ErrParentTaskNotFound
+			return nil, t.ErrParentTaskNotFound
 		}
 	}
 
 	// check if assignee exists in this board
 	if task.AssigneeUserID != nil {
-
-		// check membership if assignee is not empty
-		role, err := s.userBoardRoleOps.GetUserBoardRole(ctx, *task.AssigneeUserID, board.ID)
+		ubrID, err := s.assigneeUserBoardRoleID(ctx, *task.AssigneeUserID, board.ID)
 		if err != nil {
-			return err
-		}
-
-		if role == "" {
-			return ErrNotMember
-		}
-		// assignee can not be viewer
-		if !rbac.HasPermission(role, rbac.PermissionMoveOwnTask) {
-			return ErrCantAssigned
+			return nil, err
 		}
-		ubrObj, err := s.userBoardRoleOps.GetUserBoardRoleObj(ctx, *task.AssigneeUserID, board.ID)
-		if err != nil {
-			return err
-		}
-		task.UserBoardRoleID = &ubrObj.ID
+		task.UserBoardRoleID = ubrID
 	}
 
 	// check permission for creator
 	role, err := s.userBoardRoleOps.GetUserBoardRole(ctx, user.ID, board.ID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if !rbac.HasPermission(role, rbac.PermissionCreateTask) {
-		return ErrPermissionDenied
+	if !rbac.HasPermission(ctx, role, rbac.PermissionCreateTask) {
+		return nil, ErrPermissionDenied
+	}
+	if board.IsArchived() {
+		return nil, b.ErrBoardArchived
 	}
 
 	col, err := s.columnOps.GetMinOrderColumn(ctx, task.BoardID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	var warning *column.WIPLimitExceededError
+	if task.ParentID == nil {
+		warning, err = s.checkWIPLimit(ctx, board, col)
+		if err != nil {
+			return nil, err
+		}
 	}
 	task.ColumnID = col.ID
 	err = s.taskOps.Create(ctx, task)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// notif to owner and maintainer!!! TO Do
-	return nil
+	return warning, nil
+}
+
+// checkWIPLimit checks whether one more task fits in col. Over the limit it returns an error on
+// boards that enforce limits and a warning on boards that only warn about them.
+func (s *TaskService) checkWIPLimit(ctx context.Context, board *b.Board, col *column.Column) (*column.WIPLimitExceededError, error) {
+	if col.WIPLimit == nil {
+		return nil, nil
+	}
+	count, err := s.taskOps.CountColumnTasks(ctx, col.ID)
+	if err != nil {
+		return nil, err
+	}
+	if count < int64(*col.WIPLimit) {
+		return nil, nil
+	}
+
+	exceeded := &column.WIPLimitExceededError{Column: col.Name, Limit: *col.WIPLimit, Count: count + 1}
+	if board.WIPPolicy == b.WIPPolicyWarn {
+		return exceeded, nil
+	}
+	return nil, exceeded
+}
+
+// assigneeUserBoardRoleID checks that the assignee is a member of the board who can hold tasks
+// and returns the id of their user board role.
+func (s *TaskService) assigneeUserBoardRoleID(ctx context.Context, assigneeUserID, boardID uuid.UUID) (*uuid.UUID, error) {
+	// check membership if assignee is not empty
+	role, err := s.userBoardRoleOps.GetUserBoardRole(ctx, assigneeUserID, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if role == "" {
+		return nil, ErrNotMember
+	}
+	// assignee can not be viewer
+	if !rbac.HasPermission(ctx, role, rbac.PermissionMoveOwnTask) {
+		return nil, ErrCantAssigned
+	}
+	ubrObj, err := s.userBoardRoleOps.GetUserBoardRoleObj(ctx, assigneeUserID, boardID)
+	if err != nil {
+		return nil, err
+	}
+	return &ubrObj.ID, nil
 }
 
 func (s *TaskService) AddDependency(ctx context.Context, task *t.Task) error {
@@ -127,9 +170,12 @@ func (s *TaskService) AddDependency(ctx context.Context, task *t.Task) error {
 		return ErrPermissionDenied
 	}
 
-	if !rbac.HasPermission(role, rbac.PermissionCreateTask) {
+	if !rbac.HasPermission(ctx, role, rbac.PermissionCreateTask) {
 		return ErrPermissionDenied
 	}
+	if err := s.boardOps.CheckWritable(ctx, existedTask.BoardID); err != nil {
+		return err
+	}
 
 	return s.taskOps.AddDependency(ctx, task)
 }
@@ -144,47 +190,69 @@ func (s *TaskService) GetFullTaskByID(ctx context.Context, userID uuid.UUID, tas
 		return nil, ErrPermissionDenied
 	}
 
-	if !rbac.HasPermission(fetcherRole, rbac.PermissionViewTask) {
+	if !rbac.HasPermission(ctx, fetcherRole, rbac.PermissionViewTask) {
 		return nil, ErrPermissionDenied
 	}
 
 	return task, err
 }
 
-func (s *TaskService) UpdateTaskColumnByID(ctx context.Context, userID uuid.UUID, taskID uuid.UUID, colID uuid.UUID) (*t.Task, error) {
+// UpdateTaskColumnByID moves a task to another column of its board. Like CreateTask it returns a
+// warning when the move goes over the WIP limit of a board that does not enforce it.
+func (s *TaskService) UpdateTaskColumnByID(ctx context.Context, userID uuid.UUID, taskID uuid.UUID, colID uuid.UUID) (*t.Task, *column.WIPLimitExceededError, error) {
 	task, err := s.taskOps.GetTaskByID(ctx, taskID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	fetcherRole, err := s.userBoardRoleOps.GetUserBoardRole(ctx, userID, task.BoardID)
 	if err != nil {
-		return nil, ErrPermissionDenied
+		return nil, nil, ErrPermissionDenied
 	}
 
-	if !rbac.HasPermission(fetcherRole, rbac.PermissionMoveOwnTask) {
-		return nil, ErrPermissionDenied
+	if !rbac.HasPermission(ctx, fetcherRole, rbac.PermissionMoveOwnTask) {
+		return nil, nil, ErrPermissionDenied
 	}
-
-	updatedTask, err := s.taskOps.UpdateTaskColumnByID(ctx, taskID, colID)
-	if err != nil {
-		return nil, err
+	if err := s.boardOps.CheckWritable(ctx, task.BoardID); err != nil {
+		return nil, nil, err
 	}
 
 	b, err := s.boardOps.GetBoardByID(ctx, task.BoardID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	updater, err := s.userOps.GetUserByID(ctx, userID)
+	newColumn, err := s.columnOps.GetColumnByID(ctx, colID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	newColumn, err := s.columnOps.GetColumnByID(ctx, updatedTask.ColumnID)
+	if newColumn == nil || newColumn.BoardID != task.BoardID {
+		return nil, nil, t.ErrColumnNotFound
+	}
+	if task.ColumnID != colID {
+		if err := s.columnOps.CheckTransition(ctx, task.BoardID, task.ColumnID, colID, string(fetcherRole)); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var warning *column.WIPLimitExceededError
+	if task.ParentID == nil && task.ColumnID != colID {
+		warning, err = s.checkWIPLimit(ctx, b, newColumn)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	updatedTask, err := s.taskOps.UpdateTaskColumnByID(ctx, taskID, colID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	updater, err := s.userOps.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, nil, err
 	}
 	userBoardRoleObj, err := s.userBoardRoleOps.GetUserBoardRoleObj(ctx, userID, task.BoardID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	description := fmt.Sprintf("Task %s from Board %s Moved to Column %s By %s", task.Title, b.Name, newColumn.Name, updater.FirstName)
 
@@ -192,9 +260,44 @@ func (s *TaskService) UpdateTaskColumnByID(ctx context.Context, userID uuid.UUID
 
 	err = s.notificaionOps.NotifBroadCasting(ctx, newNotification, task.BoardID, userID, task)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	return updatedTask, warning, nil
+}
+
+// MoveTask puts a task between two tasks of a column, moving it to that column first when it is
+// another one. The column change goes through UpdateTaskColumnByID and its checks.
+func (s *TaskService) MoveTask(ctx context.Context, userID, taskID uuid.UUID, colID, afterID, beforeID *uuid.UUID) (*t.Task, *column.WIPLimitExceededError, error) {
+	task, err := s.taskOps.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	role, err := s.userBoardRoleOps.GetUserBoardRole(ctx, userID, task.BoardID)
+	if err != nil {
+		return nil, nil, ErrPermissionDenied
+	}
+
+	if !rbac.HasPermission(ctx, role, rbac.PermissionMoveOwnTask) {
+		return nil, nil, ErrPermissionDenied
+	}
+	if err := s.boardOps.CheckWritable(ctx, task.BoardID); err != nil {
+		return nil, nil, err
 	}
-	return updatedTask, err
+
+	var warning *column.WIPLimitExceededError
+	targetColumnID := task.ColumnID
+	if colID != nil && *colID != task.ColumnID {
+		if _, warning, err = s.UpdateTaskColumnByID(ctx, userID, taskID, *colID); err != nil {
+			return nil, nil, err
+		}
+		targetColumnID = *colID
+	}
+
+	movedTask, err := s.taskOps.Move(ctx, taskID, targetColumnID, afterID, beforeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return movedTask, warning, nil
 }
 
 func (s *TaskService) ReorderTasks(ctx context.Context, userID, colID uuid.UUID, newOrder map[uuid.UUID]uint) ([]t.Task, error) {
@@ -207,9 +310,12 @@ func (s *TaskService) ReorderTasks(ctx context.Context, userID, colID uuid.UUID,
 		return nil, ErrPermissionDenied
 	}
 
-	if !rbac.HasPermission(role, rbac.PermissionViewTask) {
+	if !rbac.HasPermission(ctx, role, rbac.PermissionViewTask) {
 		return nil, ErrPermissionDenied
 	}
+	if err := s.boardOps.CheckWritable(ctx, col.BoardID); err != nil {
+		return nil, err
+	}
 	tasks, err := s.taskOps.ReorderTasks(ctx, colID, newOrder)
 	if err != nil {
 		return nil, err
@@ -217,3 +323,235 @@ func (s *TaskService) ReorderTasks(ctx context.Context, userID, colID uuid.UUID,
 
 	return tasks, nil
 }
+
+func (s *TaskService) UpdateTask(ctx context.Context, userID, taskID uuid.UUID, fields *t.UpdateFields) (*t.Task, error) {
+	task, err := s.taskOps.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	updaterUBR, err := s.userBoardRoleOps.GetUserBoardRoleObj(ctx, userID, task.BoardID)
+	if err != nil {
+		return nil, ErrPermissionDenied
+	}
+	updaterRole := rbac.Role(updaterUBR.Role)
+
+	// title, description, dates and story point: assignees may edit their own tasks
+	if fields.HasDetailChanges() {
+		isOwnTask := task.UserBoardRoleID != nil && *task.UserBoardRoleID == updaterUBR.ID
+		if !rbac.HasPermission(ctx, updaterRole, rbac.PermissionEditAnyTask) &&
+			!(isOwnTask && rbac.HasPermission(ctx, updaterRole, rbac.PermissionEditOwnTask)) {
+			return nil, ErrPermissionDenied
+		}
+	}
+
+	// assignee: needs assign permission and the same membership checks as task creation
+	if fields.AssigneeUserID != nil {
+		if !rbac.HasPermission(ctx, updaterRole, rbac.PermissionAssignTask) {
+			return nil, ErrPermissionDenied
+		}
+		ubrID, err := s.assigneeUserBoardRoleID(ctx, *fields.AssigneeUserID, task.BoardID)
+		if err != nil {
+			return nil, err
+		}
+		fields.UserBoardRoleID = ubrID
+	}
+	if err := s.boardOps.CheckWritable(ctx, task.BoardID); err != nil {
+		return nil, err
+	}
+
+	updatedTask, err := s.taskOps.Update(ctx, taskID, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := s.boardOps.GetBoardByID(ctx, task.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	description := fmt.Sprintf("Task %s from Board %s Updated By %s", task.Title, b.Name, updaterUBR.User.FirstName)
+	newNotification := notification.NewNotification(description, notification.TaskUpdateNotif, updaterUBR.ID)
+
+	err = s.notificaionOps.NotifBroadCasting(ctx, newNotification, task.BoardID, userID, updatedTask)
+	if err != nil {
+		return nil, err
+	}
+
+	// let the previous assignee know the task was taken from them
+	if fields.UserBoardRoleID != nil && task.UserBoardRoleID != nil &&
+		*task.UserBoardRoleID != *fields.UserBoardRoleID && *task.UserBoardRoleID != updaterUBR.ID {
+		prevAssigneeNotif := notification.NewNotification(description, notification.TaskUpdateNotif, *task.UserBoardRoleID)
+		if err := s.notificaionOps.CreateNotification(ctx, prevAssigneeNotif); err != nil {
+			return nil, err
+		}
+	}
+	return updatedTask, nil
+}
+
+func (s *TaskService) DeleteTask(ctx context.Context, userID, taskID uuid.UUID) error {
+	task, err := s.taskOps.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	role, err := s.userBoardRoleOps.GetUserBoardRole(ctx, userID, task.BoardID)
+	if err != nil {
+		return ErrPermissionDenied
+	}
+
+	if !rbac.HasPermission(ctx, role, rbac.PermissionDeleteTask) {
+		return ErrPermissionDenied
+	}
+	if err := s.boardOps.CheckWritable(ctx, task.BoardID); err != nil {
+		return err
+	}
+
+	return s.taskOps.Delete(ctx, taskID)
+}
+
+func (s *TaskService) GetBoardTrash(ctx context.Context, userID, boardID uuid.UUID, page, pageSize uint) ([]t.Task, uint, error) {
+	role, err := s.userBoardRoleOps.GetUserBoardRole(ctx, userID, boardID)
+	if err != nil {
+		return nil, 0, ErrPermissionDenied
+	}
+
+	if !rbac.HasPermission(ctx, role, rbac.PermissionDeleteTask) {
+		return nil, 0, ErrPermissionDenied
+	}
+
+	return s.taskOps.GetDeletedTasks(ctx, boardID, page, pageSize)
+}
+
+func (s *TaskService) RestoreTask(ctx context.Context, userID, taskID uuid.UUID) (*t.Task, error) {
+	task, err := s.taskOps.GetDeletedTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	role, err := s.userBoardRoleOps.GetUserBoardRole(ctx, userID, task.BoardID)
+	if err != nil {
+		return nil, ErrPermissionDenied
+	}
+
+	if !rbac.HasPermission(ctx, role, rbac.PermissionDeleteTask) {
+		return nil, ErrPermissionDenied
+	}
+	if err := s.boardOps.CheckWritable(ctx, task.BoardID); err != nil {
+		return nil, err
+	}
+
+	if task.ParentID != nil {
+		if _, err := s.taskOps.GetTaskByID(ctx, *task.ParentID); err != nil {
+			return nil, t.ErrParentTaskDeleted
+		}
+	}
+
+	// the column may have been removed while the task was in trash, deleting a column only looks at live tasks
+	col, err := s.columnOps.GetColumnByID(ctx, task.ColumnID)
+	if err != nil {
+		return nil, err
+	}
+	if col == nil {
+		col, err = s.columnOps.GetMinOrderColumn(ctx, task.BoardID)
+		if err != nil {
+			return nil, err
+		}
+		task.ColumnID = col.ID
+	}
+
+	if err := s.taskOps.Restore(ctx, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// PurgeTrash permanently removes the tasks that have been in trash for longer than retention.
+func (s *TaskService) PurgeTrash(ctx context.Context, retention time.Duration) (int64, error) {
+	return s.taskOps.PurgeDeleted(ctx, retention)
+}
+
+func (s *TaskService) GetTaskTree(ctx context.Context, userID, taskID uuid.UUID) (*t.TreeNode, error) {
+	task, err := s.taskOps.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	role, err := s.userBoardRoleOps.GetUserBoardRole(ctx, userID, task.BoardID)
+	if err != nil {
+		return nil, ErrPermissionDenied
+	}
+
+	if !rbac.HasPermission(ctx, role, rbac.PermissionViewTask) {
+		return nil, ErrPermissionDenied
+	}
+
+	return s.taskOps.GetTaskTree(ctx, taskID)
+}
+
+func (s *TaskService) RemoveDependency(ctx context.Context, task *t.Task) error {
+	existedTask, err := s.taskOps.GetTaskByID(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	role, err := s.userBoardRoleOps.GetUserBoardRole(ctx, task.CreatedByUserID, existedTask.BoardID)
+	if err != nil {
+		return ErrPermissionDenied
+	}
+
+	if !rbac.HasPermission(ctx, role, rbac.PermissionCreateTask) {
+		return ErrPermissionDenied
+	}
+	if err := s.boardOps.CheckWritable(ctx, existedTask.BoardID); err != nil {
+		return err
+	}
+
+	return s.taskOps.RemoveDependency(ctx, task)
+}
+
+func (s *TaskService) GetDependencies(ctx context.Context, userID, taskID uuid.UUID, transitive bool) (*t.Task, error) {
+	task, err := s.taskOps.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	role, err := s.userBoardRoleOps.GetUserBoardRole(ctx, userID, task.BoardID)
+	if err != nil {
+		return nil, ErrPermissionDenied
+	}
+
+	if !rbac.HasPermission(ctx, role, rbac.PermissionViewTask) {
+		return nil, ErrPermissionDenied
+	}
+
+	return s.taskOps.GetDependencies(ctx, taskID, transitive)
+}
+
+// checkBoardView allows anyone on public boards and members with view permission on private ones.
+func (s *TaskService) checkBoardView(ctx context.Context, userID, boardID uuid.UUID) error {
+	board, err := s.boardOps.GetBoardByID(ctx, boardID)
+	if err != nil {
+		return err
+	}
+	if !board.IsPublic() {
+		role, err := s.userBoardRoleOps.GetUserBoardRole(ctx, userID, boardID)
+		if err != nil {
+			return ErrPermissionDenied
+		}
+
+		if !rbac.HasPermission(ctx, role, rbac.PermissionViewBoard) {
+			return ErrPermissionDenied
+		}
+	}
+	return nil
+}
+
+func (s *TaskService) GetBoardDependencyGraph(ctx context.Context, userID, boardID uuid.UUID) (*t.DependencyGraph, error) {
+	if err := s.checkBoardView(ctx, userID, boardID); err != nil {
+		return nil, err
+	}
+
+	return s.taskOps.GetBoardDependencyGraph(ctx, boardID)
+}
+
+func (s *TaskService) GetBoardSchedule(ctx context.Context, userID, boardID uuid.UUID) (*t.Schedule, error) {
+	if err := s.checkBoardView(ctx, userID, boardID); err != nil {
+		return nil, err
+	}
+
+	return s.taskOps.GetBoardSchedule(ctx, boardID)
+}
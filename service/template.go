@@ -0,0 +1,361 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"server/internal/board"
+	boardtemplate "server/internal/board_template"
+	"server/internal/column"
+	customrole "server/internal/custom_role"
+	"server/internal/notification"
+	"server/internal/task"
+	userboardrole "server/internal/user_board_role"
+	"server/pkg/rbac"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrDependenciesNeedTasks = errors.New("dependencies can only be cloned along with the tasks")
+)
+
+// CloneOptions tells what is copied along with the columns and settings of a board.
+type CloneOptions struct {
+	Tasks        bool
+	Members      bool
+	Dependencies bool
+}
+
+// TemplateService saves boards as templates and creates boards from templates or other boards.
+type TemplateService struct {
+	boardService     *BoardService
+	boardOps         *board.Ops
+	userBoardRoleOps *userboardrole.Ops
+	columnOps        *column.Ops
+	taskOps          *task.Ops
+	templateOps      *boardtemplate.Ops
+	notificationOps  *notification.Ops
+	customRoleOps    *customrole.Ops
+}
+
+func NewTemplateService(boardService *BoardService, boardOps *board.Ops,
+	userBoardRoleOps *userboardrole.Ops, columnOps *column.Ops, taskOps *task.Ops,
+	templateOps *boardtemplate.Ops, notificationOps *notification.Ops, customRoleOps *customrole.Ops) *TemplateService {
+	return &TemplateService{
+		boardService:     boardService,
+		boardOps:         boardOps,
+		userBoardRoleOps: userBoardRoleOps,
+		columnOps:        columnOps,
+		taskOps:          taskOps,
+		templateOps:      templateOps,
+		notificationOps:  notificationOps,
+		customRoleOps:    customRoleOps,
+	}
+}
+
+// SaveAsTemplate stores the layout of a board the user can see as a template of theirs.
+// Only top-level tasks are kept as sample tasks.
+func (s *TemplateService) SaveAsTemplate(ctx context.Context, userID, boardID uuid.UUID, t *boardtemplate.Template, withTasks bool) error {
+	b, err := s.viewableBoard(ctx, userID, boardID)
+	if err != nil {
+		return err
+	}
+
+	columns, err := s.columnOps.GetColumnsByBoardID(ctx, boardID)
+	if err != nil {
+		return err
+	}
+	positions := make(map[uuid.UUID]uint, len(columns))
+	t.Columns = make([]boardtemplate.Column, len(columns))
+	for i, c := range columns {
+		positions[c.ID] = uint(i)
+		t.Columns[i] = boardtemplate.Column{Name: c.Name, Kind: c.Kind, WIPLimit: c.WIPLimit}
+	}
+
+	if withTasks {
+		graph, err := s.taskOps.GetBoardDependencyGraph(ctx, boardID)
+		if err != nil {
+			return err
+		}
+		tasks := graph.Nodes
+		sort.Slice(tasks, func(i, j int) bool {
+			if positions[tasks[i].ColumnID] != positions[tasks[j].ColumnID] {
+				return positions[tasks[i].ColumnID] < positions[tasks[j].ColumnID]
+			}
+			return tasks[i].Rank < tasks[j].Rank
+		})
+		for _, bt := range tasks {
+			colPosition, ok := positions[bt.ColumnID]
+			if bt.ParentID != nil || !ok {
+				continue
+			}
+			t.Tasks = append(t.Tasks, boardtemplate.Task{
+				Position:       uint(len(t.Tasks)),
+				ColumnPosition: colPosition,
+				Title:          bt.Title,
+				Description:    bt.Description,
+				StoryPoint:     bt.StoryPoint,
+			})
+		}
+	}
+
+	if t.Description == "" {
+		t.Description = b.Description
+	}
+	t.Type = b.Type
+	t.WIPPolicy = b.WIPPolicy
+	t.CreatedByUserID = userID
+	return s.templateOps.Create(ctx, t)
+}
+
+func (s *TemplateService) GetUserTemplates(ctx context.Context, userID uuid.UUID) ([]boardtemplate.Template, error) {
+	return s.templateOps.GetUserTemplates(ctx, userID)
+}
+
+func (s *TemplateService) GetTemplate(ctx context.Context, userID, templateID uuid.UUID) (*boardtemplate.Template, error) {
+	t, err := s.templateOps.GetByID(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if t.CreatedByUserID != userID {
+		return nil, ErrPermissionDenied
+	}
+	return t, nil
+}
+
+func (s *TemplateService) DeleteTemplate(ctx context.Context, userID, templateID uuid.UUID) error {
+	if _, err := s.GetTemplate(ctx, userID, templateID); err != nil {
+		return err
+	}
+	return s.templateOps.Delete(ctx, templateID)
+}
+
+// CreateBoardFromTemplate creates b, owned by the user, with the columns, settings and sample tasks of a template.
+// The type of b is kept when given.
+func (s *TemplateService) CreateBoardFromTemplate(ctx context.Context, userID, templateID uuid.UUID, b *board.Board) error {
+	t, err := s.GetTemplate(ctx, userID, templateID)
+	if err != nil {
+		return err
+	}
+
+	if b.Type == "" {
+		b.Type = t.Type
+	}
+	b.Description = t.Description
+	b.WIPPolicy = t.WIPPolicy
+	b.CreatedAt = time.Now()
+	columns := make([]column.Column, len(t.Columns))
+	for i, c := range t.Columns {
+		columns[i] = column.Column{Name: c.Name, Kind: c.Kind, WIPLimit: c.WIPLimit, CreatedAt: b.CreatedAt}
+	}
+	if err := s.boardService.CreateBoardWithColumns(ctx, b, &userboardrole.UserBoardRole{UserID: userID}, columns); err != nil {
+		return err
+	}
+
+	tasks := make([]task.Task, len(t.Tasks))
+	for i, tt := range t.Tasks {
+		tasks[i] = task.Task{
+			ID:          uuid.New(),
+			Title:       tt.Title,
+			Description: tt.Description,
+			StoryPoint:  tt.StoryPoint,
+			Order:       tt.Position,
+			ColumnID:    b.Columns[tt.ColumnPosition].ID,
+			BoardID:     b.ID,
+		}
+	}
+	return s.taskOps.CreateBatch(ctx, tasks)
+}
+
+// CloneBoard creates b, owned by the user, as a copy of the columns, workflow and settings of another board.
+// Copying the members needs the permission to invite users to the source board.
+func (s *TemplateService) CloneBoard(ctx context.Context, userID, sourceID uuid.UUID, b *board.Board, opts CloneOptions) error {
+	if opts.Dependencies && !opts.Tasks {
+		return ErrDependenciesNeedTasks
+	}
+	source, err := s.viewableBoard(ctx, userID, sourceID)
+	if err != nil {
+		return err
+	}
+	if opts.Members {
+		role, err := s.userBoardRoleOps.GetUserBoardRole(ctx, userID, sourceID)
+		if err != nil || !rbac.HasPermission(ctx, role, rbac.PermissionInviteUsers) {
+			return ErrPermissionDenied
+		}
+	}
+
+	sourceColumns, err := s.columnOps.GetColumnsByBoardID(ctx, sourceID)
+	if err != nil {
+		return err
+	}
+	// a clone stays in the workspace of its source
+	b.WorkspaceID = source.WorkspaceID
+	if b.Type == "" {
+		b.Type = source.Type
+	}
+	b.Description = source.Description
+	b.WIPPolicy = source.WIPPolicy
+	b.CreatedAt = time.Now()
+	columns := make([]column.Column, len(sourceColumns))
+	for i, c := range sourceColumns {
+		columns[i] = column.Column{Name: c.Name, Kind: c.Kind, WIPLimit: c.WIPLimit, CreatedAt: b.CreatedAt}
+	}
+	owner := &userboardrole.UserBoardRole{UserID: userID}
+	if err := s.boardService.CreateBoardWithColumns(ctx, b, owner, columns); err != nil {
+		return err
+	}
+	columnIDs := make(map[uuid.UUID]uuid.UUID, len(sourceColumns))
+	for i, c := range sourceColumns {
+		columnIDs[c.ID] = b.Columns[i].ID
+	}
+
+	transitions, err := s.columnOps.GetTransitions(ctx, sourceID)
+	if err != nil {
+		return err
+	}
+	if len(transitions) > 0 {
+		for i := range transitions {
+			transitions[i].ID = uuid.Nil
+			transitions[i].FromColumnID = columnIDs[transitions[i].FromColumnID]
+			transitions[i].ToColumnID = columnIDs[transitions[i].ToColumnID]
+		}
+		if _, err := s.columnOps.SetTransitions(ctx, b.ID, transitions); err != nil {
+			return err
+		}
+	}
+
+	memberIDs, err := s.cloneMembers(ctx, source, b, owner, opts.Members)
+	if err != nil {
+		return err
+	}
+
+	if !opts.Tasks {
+		return nil
+	}
+	return s.cloneTasks(ctx, sourceID, b.ID, columnIDs, memberIDs, opts.Dependencies)
+}
+
+// cloneMembers gives the members of source the same roles on b when copyRoles is set and maps
+// their user board roles to the new ones, the cloning user being the owner of b. Members holding a
+// custom role of source that does not apply to b become viewers.
+func (s *TemplateService) cloneMembers(ctx context.Context, source, b *board.Board, owner *userboardrole.UserBoardRole, copyRoles bool) (map[uuid.UUID]uuid.UUID, error) {
+	members, err := s.userBoardRoleOps.GetBoardMembers(ctx, source.ID)
+	if err != nil {
+		return nil, err
+	}
+	memberIDs := make(map[uuid.UUID]uuid.UUID, len(members))
+	for _, m := range members {
+		if m.UserID == owner.UserID {
+			memberIDs[m.ID] = owner.ID
+			continue
+		}
+		if !copyRoles {
+			continue
+		}
+		role := m.Role
+		if err := s.customRoleOps.ValidateForBoard(ctx, role, b); err != nil {
+			if !errors.Is(err, customrole.ErrRoleNotFound) {
+				return nil, err
+			}
+			role = string(rbac.RoleViewer)
+		}
+		ubr := &userboardrole.UserBoardRole{UserID: m.UserID, BoardID: b.ID, Role: role}
+		if err := s.userBoardRoleOps.SetUserBoardRole(ctx, ubr); err != nil {
+			return nil, err
+		}
+		memberIDs[m.ID] = ubr.ID
+
+		notif := notification.NewNotification(fmt.Sprintf("You were added to the Board '%s', a copy of '%s'", b.Name, source.Name),
+			notification.UserInvited, ubr.ID)
+		if err := s.notificationOps.CreateNotification(ctx, notif); err != nil {
+			return nil, err
+		}
+	}
+	return memberIDs, nil
+}
+
+// cloneTasks copies the tasks of a board with their subtasks, assignees still on the new board and,
+// when asked, their dependencies.
+func (s *TemplateService) cloneTasks(ctx context.Context, sourceID, boardID uuid.UUID, columnIDs, memberIDs map[uuid.UUID]uuid.UUID, withDependencies bool) error {
+	graph, err := s.taskOps.GetBoardDependencyGraph(ctx, sourceID)
+	if err != nil {
+		return err
+	}
+
+	taskIDs := make(map[uuid.UUID]uuid.UUID, len(graph.Nodes))
+	for _, t := range graph.Nodes {
+		if _, ok := columnIDs[t.ColumnID]; ok {
+			taskIDs[t.ID] = uuid.New()
+		}
+	}
+
+	tasks := make([]task.Task, 0, len(taskIDs))
+	for _, t := range graph.Nodes {
+		newID, ok := taskIDs[t.ID]
+		if !ok {
+			continue
+		}
+		copied := task.Task{
+			ID:          newID,
+			Title:       t.Title,
+			Description: t.Description,
+			Order:       t.Order,
+			Rank:        t.Rank,
+			StartAt:     t.StartAt,
+			EndAt:       t.EndAt,
+			StoryPoint:  t.StoryPoint,
+			ColumnID:    columnIDs[t.ColumnID],
+			BoardID:     boardID,
+		}
+		if t.ParentID != nil {
+			if parentID, ok := taskIDs[*t.ParentID]; ok {
+				copied.ParentID = &parentID
+			}
+		}
+		if t.UserBoardRoleID != nil {
+			if assigneeID, ok := memberIDs[*t.UserBoardRoleID]; ok {
+				copied.UserBoardRoleID = &assigneeID
+			}
+		}
+		tasks = append(tasks, copied)
+	}
+	if err := s.taskOps.CreateBatch(ctx, tasks); err != nil {
+		return err
+	}
+
+	if !withDependencies {
+		return nil
+	}
+	var dependencies []task.TaskDependency
+	for _, e := range graph.Edges {
+		dependentID, ok := taskIDs[e.DependentTaskID]
+		if !ok {
+			continue
+		}
+		dependencyID, ok := taskIDs[e.DependencyTaskID]
+		if !ok {
+			continue
+		}
+		dependencies = append(dependencies, task.TaskDependency{DependentTaskID: dependentID, DependencyTaskID: dependencyID})
+	}
+	return s.taskOps.CopyDependencies(ctx, dependencies)
+}
+
+// viewableBoard returns a board that is public or that the user may view.
+func (s *TemplateService) viewableBoard(ctx context.Context, userID, boardID uuid.UUID) (*board.Board, error) {
+	b, err := s.boardOps.GetBoardByID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	if b.IsPublic() {
+		return b, nil
+	}
+	role, err := s.userBoardRoleOps.GetUserBoardRole(ctx, userID, boardID)
+	if err != nil || !rbac.HasPermission(ctx, role, rbac.PermissionViewBoard) {
+		return nil, ErrPermissionDenied
+	}
+	return b, nil
+}
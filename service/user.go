@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"server/internal/board"
+	"server/internal/notification"
+	u "server/internal/user"
+	userboardrole "server/internal/user_board_role"
+	"server/internal/workspace"
+	"server/pkg/rbac"
+
+	"github.com/google/uuid"
+)
+
+// UserService manages the profile of the current user and the deletion of their account.
+type UserService struct {
+	authService      *AuthService
+	boardService     *BoardService
+	userOps          *u.Ops
+	boardOps         *board.Ops
+	userBoardRoleOps *userboardrole.Ops
+	workspaceOps     *workspace.Ops
+	notificationOps  *notification.Ops
+}
+
+func NewUserService(authService *AuthService, boardService *BoardService, userOps *u.Ops, boardOps *board.Ops, userBoardRoleOps *userboardrole.Ops,
+	workspaceOps *workspace.Ops, notificationOps *notification.Ops) *UserService {
+	return &UserService{
+		authService:      authService,
+		boardService:     boardService,
+		userOps:          userOps,
+		boardOps:         boardOps,
+		userBoardRoleOps: userBoardRoleOps,
+		workspaceOps:     workspaceOps,
+		notificationOps:  notificationOps,
+	}
+}
+
+func (s *UserService) GetProfile(ctx context.Context, userID uuid.UUID) (*u.User, error) {
+	user, err := s.userOps.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, u.ErrUserNotFound
+	}
+	return user, nil
+}
+
+// UpdateProfile changes the profile of a user, a new email is mailed a link to verify it.
+func (s *UserService) UpdateProfile(ctx context.Context, userID uuid.UUID, fields *u.UpdateFields) (*u.User, error) {
+	user, err := s.userOps.UpdateProfile(ctx, userID, fields)
+	if err != nil {
+		return nil, err
+	}
+	if fields.Email != nil && !user.IsVerified() {
+		if err := s.authService.SendVerification(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+	return user, nil
+}
+
+// SetAvatar stores the url of the new avatar of a user and returns the url of the former one.
+func (s *UserService) SetAvatar(ctx context.Context, userID uuid.UUID, avatarURL string) (string, error) {
+	user, err := s.GetProfile(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if _, err := s.userOps.SetAvatar(ctx, userID, avatarURL); err != nil {
+		return "", err
+	}
+	return user.AvatarURL, nil
+}
+
+// ChangePassword sets a new password and ends the other sessions of the user, the one of sessionID stays.
+func (s *UserService) ChangePassword(ctx context.Context, userID, sessionID uuid.UUID, oldPassword, newPassword string) error {
+	if err := s.userOps.ChangePassword(ctx, userID, oldPassword, newPassword); err != nil {
+		return err
+	}
+	return s.authService.LogoutOthers(ctx, userID, sessionID)
+}
+
+// DeleteAccount deletes the account of a user once their password is confirmed. A workspace they were the last
+// admin of and a board they were the only owner of are handed over to another member, boards without any other
+// member are deleted. Their board roles are removed and their comments stay under an anonymized name.
+func (s *UserService) DeleteAccount(ctx context.Context, userID uuid.UUID, password string) error {
+	user, err := s.userOps.CheckPassword(ctx, userID, password)
+	if err != nil {
+		return err
+	}
+
+	// workspaces go first, a new admin becomes an owner of the boards of the workspace
+	workspaces, err := s.workspaceOps.GetUserWorkspaces(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, w := range workspaces {
+		m := &workspace.Member{WorkspaceID: w.ID, UserID: userID, Role: w.MyRole}
+		successor, err := s.workspaceOps.RemoveDeletedMember(ctx, m)
+		if err != nil {
+			return err
+		}
+		if successor != nil {
+			if err := s.boardService.SyncWorkspaceMember(ctx, successor); err != nil {
+				return err
+			}
+		}
+	}
+
+	soleOwned, err := s.userBoardRoleOps.GetSoleOwnedBoardIDs(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, boardID := range soleOwned {
+		if err := s.handOverBoard(ctx, boardID, user); err != nil {
+			return err
+		}
+	}
+
+	boardIDs, err := s.userBoardRoleOps.GetUserBoardIDs(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, boardID := range boardIDs {
+		if err := s.userBoardRoleOps.RemoveUserBoardRole(ctx, userID, boardID, nil); err != nil {
+			return err
+		}
+	}
+	if err := s.authService.LogoutAll(ctx, userID); err != nil {
+		return err
+	}
+	return s.userOps.Delete(ctx, userID)
+}
+
+// handOverBoard makes the highest ranked other member the owner of a board the leaving user solely owns, the
+// board is deleted when nobody else is on it.
+func (s *UserService) handOverBoard(ctx context.Context, boardID uuid.UUID, leaving *u.User) error {
+	members, err := s.userBoardRoleOps.GetBoardMembers(ctx, boardID)
+	if err != nil {
+		return err
+	}
+	var successor *userboardrole.UserBoardRole
+	for i := range members {
+		m := &members[i]
+		if m.UserID == leaving.ID {
+			continue
+		}
+		if successor == nil || successorRank(m.Role) > successorRank(successor.Role) {
+			successor = m
+		}
+	}
+	if successor == nil {
+		return s.boardOps.Delete(ctx, boardID)
+	}
+
+	if err := s.userBoardRoleOps.UpdateRole(ctx, successor.UserID, boardID, rbac.RoleOwner); err != nil {
+		return err
+	}
+	b, err := s.boardOps.GetBoardByID(ctx, boardID)
+	if err != nil {
+		return err
+	}
+	description := fmt.Sprintf("%s deleted their account, you are now the owner of the Board '%s'", leaving.FirstName, b.Name)
+	notif := notification.NewNotification(description, notification.OwnershipNotif, successor.ID)
+	return s.notificationOps.CreateNotification(ctx, notif)
+}
+
+// successorRank orders the members that can take over a board, members are listed oldest first so the
+// earliest of the highest ranked ones is chosen.
+func successorRank(role string) int {
+	switch rbac.Role(role) {
+	case rbac.RoleMaintainer:
+		return 3
+	case rbac.RoleEditor:
+		return 2
+	case rbac.RoleViewer:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"server/internal/board"
+	u "server/internal/user"
+	"server/internal/workspace"
+
+	"github.com/google/uuid"
+)
+
+// WorkspaceService manages workspaces, their members and the boards they own.
+type WorkspaceService struct {
+	boardService *BoardService
+	userOps      *u.Ops
+	workspaceOps *workspace.Ops
+}
+
+func NewWorkspaceService(boardService *BoardService, userOps *u.Ops, workspaceOps *workspace.Ops) *WorkspaceService {
+	return &WorkspaceService{
+		boardService: boardService,
+		userOps:      userOps,
+		workspaceOps: workspaceOps,
+	}
+}
+
+// CreateWorkspace creates a workspace with userID as its first admin.
+func (s *WorkspaceService) CreateWorkspace(ctx context.Context, userID uuid.UUID, w *workspace.Workspace) error {
+	w.CreatedByUserID = userID
+	return s.workspaceOps.Create(ctx, w)
+}
+
+func (s *WorkspaceService) GetUserWorkspaces(ctx context.Context, userID uuid.UUID) ([]workspace.Workspace, error) {
+	return s.workspaceOps.GetUserWorkspaces(ctx, userID)
+}
+
+// GetWorkspace shows a workspace to its members.
+func (s *WorkspaceService) GetWorkspace(ctx context.Context, userID, workspaceID uuid.UUID) (*workspace.Workspace, error) {
+	m, err := s.member(ctx, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	w, err := s.workspaceOps.GetByID(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	w.MyRole = m.Role
+	return w, nil
+}
+
+// GetMembers is the member directory of a workspace, open to all of its members.
+func (s *WorkspaceService) GetMembers(ctx context.Context, userID, workspaceID uuid.UUID) ([]workspace.Member, error) {
+	if _, err := s.member(ctx, userID, workspaceID); err != nil {
+		return nil, err
+	}
+	return s.workspaceOps.GetMembers(ctx, workspaceID)
+}
+
+// GetBoards lists the boards of a workspace the user can see.
+func (s *WorkspaceService) GetBoards(ctx context.Context, userID, workspaceID uuid.UUID) ([]board.Board, error) {
+	if _, err := s.member(ctx, userID, workspaceID); err != nil {
+		return nil, err
+	}
+	return s.workspaceOps.GetVisibleBoards(ctx, workspaceID, userID)
+}
+
+// AddMember lets an admin add the user with the given email to the workspace, they get the
+// default roles of their workspace role on its boards.
+func (s *WorkspaceService) AddMember(ctx context.Context, adminID, workspaceID uuid.UUID, email string, role workspace.Role) (*workspace.Member, error) {
+	if _, err := s.admin(ctx, adminID, workspaceID); err != nil {
+		return nil, err
+	}
+	user, err := s.userOps.GetUserByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsVerified() {
+		return nil, ErrInviteeNotVerified
+	}
+	m := &workspace.Member{WorkspaceID: workspaceID, UserID: user.ID, User: user, Role: role}
+	if err := s.workspaceOps.AddMember(ctx, m); err != nil {
+		return nil, err
+	}
+	if err := s.boardService.SyncWorkspaceMember(ctx, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ChangeMemberRole lets an admin change the workspace role of a member, the inherited board roles follow it.
+func (s *WorkspaceService) ChangeMemberRole(ctx context.Context, adminID, workspaceID, memberID uuid.UUID, role workspace.Role) (*workspace.Member, error) {
+	if _, err := s.admin(ctx, adminID, workspaceID); err != nil {
+		return nil, err
+	}
+	m, err := s.workspaceOps.GetMember(ctx, workspaceID, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.workspaceOps.UpdateMemberRole(ctx, m, role); err != nil {
+		return nil, err
+	}
+	if err := s.boardService.SyncWorkspaceMember(ctx, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// RemoveMember takes a member out of the workspace, by an admin or by the member themselves. The
+// inherited board roles go with it, roles given on the boards themselves are kept.
+func (s *WorkspaceService) RemoveMember(ctx context.Context, actorID, workspaceID, memberID uuid.UUID) error {
+	if actorID != memberID {
+		if _, err := s.admin(ctx, actorID, workspaceID); err != nil {
+			return err
+		}
+	}
+	m, err := s.workspaceOps.GetMember(ctx, workspaceID, memberID)
+	if err != nil {
+		return err
+	}
+	if err := s.workspaceOps.RemoveMember(ctx, m); err != nil {
+		return err
+	}
+	m.Role = ""
+	return s.boardService.SyncWorkspaceMember(ctx, m)
+}
+
+func (s *WorkspaceService) member(ctx context.Context, userID, workspaceID uuid.UUID) (*workspace.Member, error) {
+	if _, err := s.workspaceOps.GetByID(ctx, workspaceID); err != nil {
+		return nil, err
+	}
+	m, err := s.workspaceOps.GetMember(ctx, workspaceID, userID)
+	if errors.Is(err, workspace.ErrMemberNotFound) {
+		return nil, ErrPermissionDenied
+	}
+	return m, err
+}
+
+func (s *WorkspaceService) admin(ctx context.Context, userID, workspaceID uuid.UUID) (*workspace.Member, error) {
+	m, err := s.member(ctx, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if m.Role != workspace.RoleAdmin {
+		return nil, ErrPermissionDenied
+	}
+	return m, nil
+}
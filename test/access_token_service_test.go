@@ -0,0 +1,89 @@
+package test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessTokens(t *testing.T) {
+	user := MockUser{FirstName: "pat", LastName: "user", Email: "patuser@gmail.com", Password: "12@Amir###90"}
+	if result := CreateUser(user); result.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to create user. Status code: %d, Response message: %s", result.StatusCode, result.Message)
+	}
+	token, err := LoginAndGetToken(t, MockUserLogin{Email: user.Email, Password: user.Password})
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	boardURLs := make([]string, 2)
+	boardIDs := make([]string, 2)
+	for i := range boardURLs {
+		resp, boardData, err := CreateBoard(token, MockBoard{Name: fmt.Sprintf("PAT Board %d", i), Type: "private"})
+		if err != nil || resp.StatusCode != http.StatusCreated {
+			t.Fatalf("Failed to create board: %v", err)
+		}
+		boardIDs[i] = boardData.BoardID
+		boardURLs[i] = fmt.Sprintf("%s%s/%s", ServerURL, BoardPost, boardData.BoardID)
+	}
+
+	tokensURL := ServerURL + "/access-tokens"
+	createToken := func(payload map[string]interface{}) (string, string) {
+		status, data := doJSONRequest(t, token, "POST", tokensURL, payload)
+		if status != http.StatusCreated {
+			t.Fatalf("Failed to create access token. Status code: %d", status)
+		}
+		secret, _ := data["token"].(string)
+		id, _ := data["token_id"].(string)
+		return secret, id
+	}
+	writeToken, writeID := createToken(map[string]interface{}{"name": "ci", "scopes": []string{"read", "write"}, "expires_in_days": 30})
+	readToken, _ := createToken(map[string]interface{}{"name": "dashboard", "scopes": []string{"read"}, "expires_in_days": 30})
+	boardToken, _ := createToken(map[string]interface{}{"name": "board bot", "scopes": []string{"read"}, "board_id": boardIDs[0], "expires_in_days": 30})
+	boardWriteToken, _ := createToken(map[string]interface{}{"name": "board writer", "scopes": []string{"read", "write"}, "board_id": boardIDs[0], "expires_in_days": 30})
+	unknownID := uuid.NewString()
+
+	mockScenarios := []struct {
+		name               string
+		token              string
+		method             string
+		url                string
+		payload            interface{}
+		expectedStatusCode int
+	}{
+		{"InvalidScope", token, "POST", tokensURL, map[string]interface{}{"name": "x", "scopes": []string{"admin"}, "expires_in_days": 30}, http.StatusBadRequest},
+		{"InvalidExpiry", token, "POST", tokensURL, map[string]interface{}{"name": "x", "scopes": []string{"read"}, "expires_in_days": 0}, http.StatusBadRequest},
+		{"TokenCanRead", writeToken, "GET", boardURLs[1], nil, http.StatusOK},
+		{"TokenCanWrite", writeToken, "POST", ServerURL + BoardPost, map[string]string{"name": "PAT made board", "type": "private"}, http.StatusCreated},
+		{"ReadOnlyTokenCantWrite", readToken, "POST", ServerURL + BoardPost, map[string]string{"name": "denied board", "type": "private"}, http.StatusForbidden},
+		{"ReadOnlyTokenCanRead", readToken, "GET", boardURLs[1], nil, http.StatusOK},
+		{"BoardTokenCanReadItsBoard", boardToken, "GET", boardURLs[0] + "/members", nil, http.StatusOK},
+		{"BoardTokenCantReadOtherBoard", boardToken, "GET", boardURLs[1] + "/members", nil, http.StatusForbidden},
+		{"BoardTokenCanWriteItsBoard", boardWriteToken, "POST", ServerURL + ColumnPost, map[string]interface{}{"board_id": boardIDs[0], "columns": []map[string]string{{"name": "bot"}}}, http.StatusCreated},
+		{"TokenCanListBoards", writeToken, "GET", ServerURL + BoardPost + "/my-boards", nil, http.StatusOK},
+		{"BoardTokenCantListBoards", boardToken, "GET", ServerURL + BoardPost + "/my-boards", nil, http.StatusForbidden},
+		{"BoardTokenCantListPublicBoards", boardToken, "GET", ServerURL + BoardPost + "/publics", nil, http.StatusForbidden},
+		{"BoardTokenCantReadNotifications", boardToken, "GET", ServerURL + "/notifications", nil, http.StatusForbidden},
+		{"BoardTokenCantCreateBoards", boardWriteToken, "POST", ServerURL + BoardPost, map[string]string{"name": "scoped board", "type": "private"}, http.StatusForbidden},
+		{"BoardTokenCantCloneItsBoard", boardWriteToken, "POST", boardURLs[0] + "/clone", map[string]string{"name": "scoped clone"}, http.StatusForbidden},
+		{"BoardTokenCantCreateBoardsFromTemplates", boardWriteToken, "POST", ServerURL + "/templates/" + unknownID + "/boards", map[string]string{"name": "scoped board"}, http.StatusForbidden},
+		{"BoardTokenCantAcceptInvitations", boardWriteToken, "POST", ServerURL + "/invitations/" + unknownID + "/accept", nil, http.StatusForbidden},
+		{"BoardTokenCantJoinByInviteLink", boardWriteToken, "POST", ServerURL + "/invite-links/" + unknownID + "/join", nil, http.StatusForbidden},
+		{"BoardTokenCantCreateWorkspaces", boardWriteToken, "POST", ServerURL + "/workspaces", map[string]string{"name": "scoped workspace"}, http.StatusForbidden},
+		{"TokenCantReachProfile", writeToken, "GET", ServerURL + "/me", nil, http.StatusForbidden},
+		{"TokenCantCreateTokens", writeToken, "POST", tokensURL, map[string]interface{}{"name": "x", "scopes": []string{"read"}, "expires_in_days": 30}, http.StatusForbidden},
+		{"RevokeToken", token, "DELETE", tokensURL + "/" + writeID, nil, http.StatusNoContent},
+		{"RevokedTokenIsRefused", writeToken, "GET", boardURLs[1], nil, http.StatusUnauthorized},
+		{"UnknownTokenIsRefused", "hfp_unknown", "GET", boardURLs[1], nil, http.StatusUnauthorized},
+	}
+
+	for _, scenario := range mockScenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			status := DoRequest(t, scenario.token, scenario.method, scenario.url, scenario.payload)
+			assert.Equal(t, scenario.expectedStatusCode, status)
+		})
+	}
+}
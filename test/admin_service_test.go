@@ -0,0 +1,88 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"server/internal/user"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdmin(t *testing.T) {
+	users := map[string]MockUser{
+		"admin": {FirstName: "global", LastName: "admin", Email: "globaladmin@gmail.com", Password: "12@Amir###90"},
+		"owner": {FirstName: "board", LastName: "owner", Email: "adminboardowner@gmail.com", Password: "12@Amir###90"},
+		"heir":  {FirstName: "board", LastName: "heir", Email: "adminboardheir@gmail.com", Password: "12@Amir###90"},
+	}
+	ids := make(map[string]string, len(users))
+	for name, user := range users {
+		result, data, err := CreateUserWithResp(user)
+		if err != nil || result.StatusCode != http.StatusCreated {
+			t.Fatalf("Failed to create %s. Status code: %d, Response message: %s", name, result.StatusCode, result.Message)
+		}
+		ids[name] = data.UserID
+	}
+	if _, err := TestApp.AdminService().MakeAdmin(context.Background(), users["admin"].Email); err != nil {
+		t.Fatalf("Failed to make admin: %v", err)
+	}
+	tokens := make(map[string]string, len(users))
+	for name, user := range users {
+		token, err := LoginAndGetToken(t, MockUserLogin{Email: user.Email, Password: user.Password})
+		if err != nil {
+			t.Fatalf("Login failed: %v", err)
+		}
+		tokens[name] = token
+	}
+
+	resp, boardData, err := CreateBoard(tokens["owner"], MockBoard{Name: "Admin Board", Type: "private"})
+	if err != nil || resp.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to create board: %v", err)
+	}
+	adminURL := ServerURL + "/admin"
+	boardURL := fmt.Sprintf("%s%s/%s", ServerURL, BoardPost, boardData.BoardID)
+	ownershipURL := fmt.Sprintf("%s/boards/%s/ownership", adminURL, boardData.BoardID)
+
+	mockScenarios := []struct {
+		name               string
+		token              string
+		method             string
+		url                string
+		payload            interface{}
+		expectedStatusCode int
+	}{
+		{"UserCantListUsers", tokens["owner"], "GET", adminURL + "/users", nil, http.StatusForbidden},
+		{"AdminListsUsers", tokens["admin"], "GET", adminURL + "/users", nil, http.StatusOK},
+		{"AdminListsBoards", tokens["admin"], "GET", adminURL + "/boards", nil, http.StatusOK},
+		{"AdminSeesStats", tokens["admin"], "GET", adminURL + "/stats", nil, http.StatusOK},
+		{"CantSuspendYourself", tokens["admin"], "POST", adminURL + "/users/" + ids["admin"] + "/suspend", nil, http.StatusBadRequest},
+		{"ForceTransfer", tokens["admin"], "POST", ownershipURL, map[string]string{"user_id": ids["heir"]}, http.StatusNoContent},
+		{"HeirOwnsBoard", tokens["heir"], "PATCH", boardURL, map[string]string{"name": "Inherited Board"}, http.StatusOK},
+		{"FormerOwnerDemoted", tokens["owner"], "PATCH", boardURL, map[string]string{"name": "Taken Board"}, http.StatusForbidden},
+		{"SuspendOwner", tokens["admin"], "POST", adminURL + "/users/" + ids["owner"] + "/suspend", nil, http.StatusNoContent},
+		{"SuspendedCantLogin", "", "POST", ServerURL + Login, users["owner"], http.StatusForbidden},
+		{"SuspendedTokenRejected", tokens["owner"], "GET", boardURL, nil, http.StatusUnauthorized},
+		{"UnsuspendOwner", tokens["admin"], "POST", adminURL + "/users/" + ids["owner"] + "/unsuspend", nil, http.StatusNoContent},
+		{"UnsuspendedLogsIn", "", "POST", ServerURL + Login, users["owner"], http.StatusOK},
+		{"SessionsEndedBySuspension", tokens["owner"], "GET", boardURL, nil, http.StatusUnauthorized},
+	}
+
+	for _, scenario := range mockScenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			status := DoRequest(t, scenario.token, scenario.method, scenario.url, scenario.payload)
+			assert.Equal(t, scenario.expectedStatusCode, status)
+		})
+	}
+
+	t.Run("RoleIsReadOnEveryRequest", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, DoRequest(t, tokens["heir"], "GET", adminURL+"/users", nil))
+		if _, err := TestApp.AdminService().MakeAdmin(context.Background(), users["heir"].Email); err != nil {
+			t.Fatalf("Failed to make admin: %v", err)
+		}
+		assert.Equal(t, http.StatusOK, DoRequest(t, tokens["heir"], "GET", adminURL+"/users", nil), "promoted without logging in again")
+
+		TestApp.RawDBConnection().Exec("UPDATE users SET role = ? WHERE id = ?", uint8(user.RoleUser), ids["heir"])
+		assert.Equal(t, http.StatusForbidden, DoRequest(t, tokens["heir"], "GET", adminURL+"/users", nil), "demoted without logging in again")
+	})
+}
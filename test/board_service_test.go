@@ -116,3 +116,277 @@ func TestBoardCreation(t *testing.T) {
 		})
 	}
 }
+
+func TestBoardMembers(t *testing.T) {
+	owner := MockUser{
+		FirstName: "members",
+		LastName:  "owner",
+		Email:     "membersowner@gmail.com",
+		Password:  "12@Amir###90",
+	}
+	member := MockUser{
+		FirstName: "members",
+		LastName:  "member",
+		Email:     "membersmember@gmail.com",
+		Password:  "12@Amir###90",
+	}
+
+	ownerResult, ownerData, err := CreateUserWithResp(owner)
+	if err != nil || ownerResult.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to create owner. Status code: %d, Error: %v", ownerResult.StatusCode, err)
+	}
+	memberResult, memberData, err := CreateUserWithResp(member)
+	if err != nil || memberResult.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to create member. Status code: %d, Error: %v", memberResult.StatusCode, err)
+	}
+
+	ownerToken, err := LoginAndGetToken(t, MockUserLogin{Email: owner.Email, Password: owner.Password})
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	memberToken, err := LoginAndGetToken(t, MockUserLogin{Email: member.Email, Password: member.Password})
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	boardResp, boardData, err := CreateBoard(ownerToken, MockBoard{Name: "Members Board", Type: "private"})
+	if err != nil || boardResp.StatusCode != http.StatusCreated {
+		t.Fatalf("CreateBoard failed: %v", err)
+	}
+
+	boardURL := fmt.Sprintf("%s%s/%s", ServerURL, BoardPost, boardData.BoardID)
+	InviteMember(t, ownerToken, memberToken, member.Email, boardData.BoardID, "viewer")
+
+	mockScenarios := []struct {
+		name               string
+		token              string
+		method             string
+		path               string
+		payload            interface{}
+		expectedStatusCode int
+	}{
+		{"ListMembers", memberToken, "GET", "/members", nil, http.StatusOK},
+		{"ChangeRole", ownerToken, "PATCH", "/members/" + memberData.UserID, map[string]string{"role": "editor"}, http.StatusOK},
+		{"ChangeRoleToOwner", ownerToken, "PATCH", "/members/" + memberData.UserID, map[string]string{"role": "owner"}, http.StatusBadRequest},
+		{"ChangeRoleWithoutPermission", memberToken, "PATCH", "/members/" + ownerData.UserID, map[string]string{"role": "viewer"}, http.StatusForbidden},
+		{"ChangeOwnRole", ownerToken, "PATCH", "/members/" + ownerData.UserID, map[string]string{"role": "viewer"}, http.StatusBadRequest},
+		{"OwnerCantLeave", ownerToken, "POST", "/leave", nil, http.StatusBadRequest},
+		{"Leave", memberToken, "POST", "/leave", nil, http.StatusNoContent},
+		{"RemoveNotAMember", ownerToken, "DELETE", "/members/" + memberData.UserID, nil, http.StatusBadRequest},
+	}
+
+	for _, scenario := range mockScenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			status := DoRequest(t, scenario.token, scenario.method, boardURL+scenario.path, scenario.payload)
+			assert.Equal(t, scenario.expectedStatusCode, status, "Expected status code")
+		})
+	}
+}
+
+func TestOwnershipTransfer(t *testing.T) {
+	owner := MockUser{
+		FirstName: "ownership",
+		LastName:  "owner",
+		Email:     "ownershipowner@gmail.com",
+		Password:  "12@Amir###90",
+	}
+	member := MockUser{
+		FirstName: "ownership",
+		LastName:  "member",
+		Email:     "ownershipmember@gmail.com",
+		Password:  "12@Amir###90",
+	}
+
+	ownerResult, ownerData, err := CreateUserWithResp(owner)
+	if err != nil || ownerResult.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to create owner. Status code: %d, Error: %v", ownerResult.StatusCode, err)
+	}
+	memberResult, memberData, err := CreateUserWithResp(member)
+	if err != nil || memberResult.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to create member. Status code: %d, Error: %v", memberResult.StatusCode, err)
+	}
+
+	ownerToken, err := LoginAndGetToken(t, MockUserLogin{Email: owner.Email, Password: owner.Password})
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	memberToken, err := LoginAndGetToken(t, MockUserLogin{Email: member.Email, Password: member.Password})
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	boardResp, boardData, err := CreateBoard(ownerToken, MockBoard{Name: "Ownership Board", Type: "private"})
+	if err != nil || boardResp.StatusCode != http.StatusCreated {
+		t.Fatalf("CreateBoard failed: %v", err)
+	}
+
+	InviteMember(t, ownerToken, memberToken, member.Email, boardData.BoardID, "editor")
+
+	heir := MockUser{FirstName: "ownership", LastName: "heir", Email: "ownershipheir@gmail.com", Password: "12@Amir###90"}
+	heirResult, heirData, err := CreateUserWithResp(heir)
+	if err != nil || heirResult.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to create heir. Status code: %d, Error: %v", heirResult.StatusCode, err)
+	}
+	heirToken, err := LoginAndGetToken(t, MockUserLogin{Email: heir.Email, Password: heir.Password})
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	InviteMember(t, ownerToken, heirToken, heir.Email, boardData.BoardID, "viewer")
+
+	boardURL := fmt.Sprintf("%s%s/%s", ServerURL, BoardPost, boardData.BoardID)
+	mockScenarios := []struct {
+		name               string
+		token              string
+		method             string
+		path               string
+		payload            interface{}
+		expectedStatusCode int
+	}{
+		{"NoPendingTransfer", ownerToken, "POST", "/ownership-transfer/accept", nil, http.StatusNotFound},
+		{"ProposeWithoutPermission", memberToken, "POST", "/ownership-transfer", map[string]interface{}{"user_id": ownerData.UserID}, http.StatusForbidden},
+		{"ProposeToYourself", ownerToken, "POST", "/ownership-transfer", map[string]interface{}{"user_id": ownerData.UserID}, http.StatusBadRequest},
+		{"Propose", ownerToken, "POST", "/ownership-transfer", map[string]interface{}{"user_id": memberData.UserID}, http.StatusCreated},
+		{"AcceptNotAddressed", ownerToken, "POST", "/ownership-transfer/accept", nil, http.StatusForbidden},
+		{"Accept", memberToken, "POST", "/ownership-transfer/accept", nil, http.StatusNoContent},
+		{"NewOwnerCantLeave", memberToken, "POST", "/leave", nil, http.StatusBadRequest},
+		{"ProposeCoOwner", memberToken, "POST", "/ownership-transfer", map[string]interface{}{"user_id": ownerData.UserID, "keep_ownership": true}, http.StatusCreated},
+		{"AcceptCoOwner", ownerToken, "POST", "/ownership-transfer/accept", nil, http.StatusNoContent},
+		{"CoOwnerLeaves", ownerToken, "POST", "/leave", nil, http.StatusNoContent},
+		{"LastOwnerCantLeave", memberToken, "POST", "/leave", nil, http.StatusBadRequest},
+		{"ProposeToHeir", memberToken, "POST", "/ownership-transfer", map[string]interface{}{"user_id": heirData.UserID}, http.StatusCreated},
+		{"HeirLeaves", heirToken, "POST", "/leave", nil, http.StatusNoContent},
+		{"AcceptVoidOffer", heirToken, "POST", "/ownership-transfer/accept", nil, http.StatusNotFound},
+		{"VoidOfferIsDropped", memberToken, "GET", "/ownership-transfer", nil, http.StatusNotFound},
+	}
+
+	for _, scenario := range mockScenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			status := DoRequest(t, scenario.token, scenario.method, boardURL+scenario.path, scenario.payload)
+			assert.Equal(t, scenario.expectedStatusCode, status, "Expected status code")
+		})
+	}
+}
+
+func TestBoardUpdate(t *testing.T) {
+	owner := MockUser{
+		FirstName: "update",
+		LastName:  "owner",
+		Email:     "updateowner@gmail.com",
+		Password:  "12@Amir###90",
+	}
+	member := MockUser{
+		FirstName: "update",
+		LastName:  "member",
+		Email:     "updatemember@gmail.com",
+		Password:  "12@Amir###90",
+	}
+
+	if result := CreateUser(owner); result.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to create owner. Status code: %d, Response message: %s", result.StatusCode, result.Message)
+	}
+	if result := CreateUser(member); result.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to create member. Status code: %d, Response message: %s", result.StatusCode, result.Message)
+	}
+
+	ownerToken, err := LoginAndGetToken(t, MockUserLogin{Email: owner.Email, Password: owner.Password})
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	memberToken, err := LoginAndGetToken(t, MockUserLogin{Email: member.Email, Password: member.Password})
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	boardResp, boardData, err := CreateBoard(ownerToken, MockBoard{Name: "Update Board", Type: "private"})
+	if err != nil || boardResp.StatusCode != http.StatusCreated {
+		t.Fatalf("CreateBoard failed: %v", err)
+	}
+
+	InviteMember(t, ownerToken, memberToken, member.Email, boardData.BoardID, "editor")
+
+	boardURL := fmt.Sprintf("%s%s/%s", ServerURL, BoardPost, boardData.BoardID)
+	mockScenarios := []struct {
+		name               string
+		token              string
+		payload            interface{}
+		expectedStatusCode int
+	}{
+		{"Rename", ownerToken, map[string]string{"name": "Renamed Board", "description": "release planning"}, http.StatusOK},
+		{"MakePublic", ownerToken, map[string]string{"type": "public"}, http.StatusOK},
+		{"InvalidName", ownerToken, map[string]string{"name": "a"}, http.StatusBadRequest},
+		{"InvalidType", ownerToken, map[string]string{"type": "secret"}, http.StatusBadRequest},
+		{"NothingToUpdate", ownerToken, map[string]string{}, http.StatusBadRequest},
+		{"EditorCantUpdate", memberToken, map[string]string{"name": "Member Board"}, http.StatusForbidden},
+	}
+
+	for _, scenario := range mockScenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			status := DoRequest(t, scenario.token, "PATCH", boardURL, scenario.payload)
+			assert.Equal(t, scenario.expectedStatusCode, status, "Expected status code")
+		})
+	}
+}
+
+func TestBoardArchive(t *testing.T) {
+	owner := MockUser{
+		FirstName: "archive",
+		LastName:  "owner",
+		Email:     "archiveowner@gmail.com",
+		Password:  "12@Amir###90",
+	}
+	member := MockUser{
+		FirstName: "archive",
+		LastName:  "member",
+		Email:     "archivemember@gmail.com",
+		Password:  "12@Amir###90",
+	}
+
+	if result := CreateUser(owner); result.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to create owner. Status code: %d, Response message: %s", result.StatusCode, result.Message)
+	}
+	if result := CreateUser(member); result.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to create member. Status code: %d, Response message: %s", result.StatusCode, result.Message)
+	}
+
+	ownerToken, err := LoginAndGetToken(t, MockUserLogin{Email: owner.Email, Password: owner.Password})
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	memberToken, err := LoginAndGetToken(t, MockUserLogin{Email: member.Email, Password: member.Password})
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	boardResp, boardData, err := CreateBoard(ownerToken, MockBoard{Name: "Archive Board", Type: "private"})
+	if err != nil || boardResp.StatusCode != http.StatusCreated {
+		t.Fatalf("CreateBoard failed: %v", err)
+	}
+
+	InviteMember(t, ownerToken, memberToken, member.Email, boardData.BoardID, "maintainer")
+
+	boardURL := fmt.Sprintf("%s%s/%s", ServerURL, BoardPost, boardData.BoardID)
+	mockScenarios := []struct {
+		name               string
+		token              string
+		method             string
+		path               string
+		payload            interface{}
+		expectedStatusCode int
+	}{
+		{"MaintainerCantArchive", memberToken, "POST", "/archive", nil, http.StatusForbidden},
+		{"Archive", ownerToken, "POST", "/archive", nil, http.StatusOK},
+		{"ArchiveTwice", ownerToken, "POST", "/archive", nil, http.StatusConflict},
+		{"UpdateArchived", ownerToken, "PATCH", "", map[string]string{"name": "Archived Board"}, http.StatusConflict},
+		{"MaintainerCantUnarchive", memberToken, "POST", "/unarchive", nil, http.StatusForbidden},
+		{"Unarchive", ownerToken, "POST", "/unarchive", nil, http.StatusOK},
+		{"UnarchiveTwice", ownerToken, "POST", "/unarchive", nil, http.StatusConflict},
+		{"UpdateUnarchived", ownerToken, "PATCH", "", map[string]string{"name": "Active Board"}, http.StatusOK},
+	}
+
+	for _, scenario := range mockScenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			status := DoRequest(t, scenario.token, scenario.method, boardURL+scenario.path, scenario.payload)
+			assert.Equal(t, scenario.expectedStatusCode, status, "Expected status code")
+		})
+	}
+}
@@ -6,8 +6,11 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"sync"
 	"testing"
 
+	"server/pkg/adapters/storage"
+
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
@@ -247,3 +250,300 @@ func TestCreateColumns(t *testing.T) {
 
 	assert.Equal(t, http.StatusCreated, resp.StatusCode, "Expected status code 201")
 }
+
+func TestUpdateColumn(t *testing.T) {
+	user := MockUser{
+		FirstName: "column3",
+		LastName:  "column3",
+		Email:     "column3@gmail.com",
+		Password:  "12@Amir###90",
+	}
+
+	userResult, userData, err := CreateUserWithResp(user)
+	if err != nil || userResult.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to create user. Status code: %d, Error: %v", userResult.StatusCode, err)
+	}
+
+	token, err := LoginAndGetToken(t, MockUserLogin{
+		Email:    user.Email,
+		Password: user.Password,
+	})
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	boardResp, boardData, err := CreateBoard(token, MockBoard{Name: "Column3 Board", Type: "public"})
+	if err != nil || boardResp.StatusCode != http.StatusCreated {
+		t.Fatalf("CreateBoard failed: %v", err)
+	}
+	boardID, _ := uuid.Parse(boardData.BoardID)
+	assigneeID, _ := uuid.Parse(userData.UserID)
+
+	send := func(method, url string, payload interface{}) (*http.Response, Response) {
+		payloadJSON, err := json.Marshal(payload)
+		if err != nil {
+			t.Fatalf("Failed to marshal payload to JSON: %v", err)
+		}
+		req, err := http.NewRequest(method, url, bytes.NewBuffer(payloadJSON))
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to execute request: %v", err)
+		}
+		defer resp.Body.Close()
+
+		var res Response
+		_ = json.NewDecoder(resp.Body).Decode(&res)
+		return resp, res
+	}
+
+	// the board already has a "done" column, add one more to play with
+	resp, res := send("POST", fmt.Sprintf("%s%s", ServerURL, ColumnPost), map[string]interface{}{
+		"board_id": boardID,
+		"columns":  []map[string]string{{"name": "doing"}},
+	})
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to create column. Status code: %d", resp.StatusCode)
+	}
+	columnID := res.Data.([]interface{})[0].(map[string]interface{})["id"].(string)
+	url := fmt.Sprintf("%s%s/%s", ServerURL, ColumnPost, columnID)
+
+	resp, _ = send("PATCH", url, map[string]interface{}{"name": "in review"})
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "Expected rename to succeed")
+
+	resp, _ = send("PATCH", url, map[string]interface{}{"name": "done"})
+	assert.Equal(t, http.StatusConflict, resp.StatusCode, "Expected duplicate name to be rejected")
+
+	resp, _ = send("PATCH", url, map[string]interface{}{})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Expected empty update to be rejected")
+
+	resp, _ = send("PATCH", url, map[string]interface{}{"wip_limit": 1})
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "Expected wip limit to be set")
+
+	var taskIDs []string
+	for _, title := range []string{"First", "Second"} {
+		taskID, err := CreateTask(token, MockTask{Title: title, AssigneeUserID: assigneeID, StoryPoint: 1, BoardID: boardID})
+		if err != nil {
+			t.Fatalf("CreateTask failed: %v", err)
+		}
+		taskIDs = append(taskIDs, taskID)
+	}
+
+	resp, _ = send("PUT", fmt.Sprintf("%s%s/%s/column", ServerURL, TaskPost, taskIDs[0]), map[string]interface{}{"column_id": columnID})
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "Expected first task to fit in the column")
+
+	resp, _ = send("PUT", fmt.Sprintf("%s%s/%s/column", ServerURL, TaskPost, taskIDs[1]), map[string]interface{}{"column_id": columnID})
+	assert.Equal(t, http.StatusConflict, resp.StatusCode, "Expected second task to exceed the wip limit")
+
+	// moves racing for the last place left in the column must not all get it
+	resp, _ = send("PATCH", url, map[string]interface{}{"wip_limit": 2})
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "Expected wip limit to be raised")
+	for _, title := range []string{"Third", "Fourth"} {
+		taskID, err := CreateTask(token, MockTask{Title: title, AssigneeUserID: assigneeID, StoryPoint: 1, BoardID: boardID})
+		if err != nil {
+			t.Fatalf("CreateTask failed: %v", err)
+		}
+		taskIDs = append(taskIDs, taskID)
+	}
+
+	statuses := make(chan int, len(taskIDs)-1)
+	var wg sync.WaitGroup
+	for _, taskID := range taskIDs[1:] {
+		wg.Add(1)
+		go func(taskID string) {
+			defer wg.Done()
+			payloadJSON, _ := json.Marshal(map[string]interface{}{"column_id": columnID})
+			req, _ := http.NewRequest("PUT", fmt.Sprintf("%s%s/%s/column", ServerURL, TaskPost, taskID), bytes.NewBuffer(payloadJSON))
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Errorf("Failed to execute request: %v", err)
+				return
+			}
+			resp.Body.Close()
+			statuses <- resp.StatusCode
+		}(taskID)
+	}
+	wg.Wait()
+	close(statuses)
+
+	var moved, refused int
+	for status := range statuses {
+		switch status {
+		case http.StatusOK:
+			moved++
+		case http.StatusConflict:
+			refused++
+		}
+	}
+	assert.Equal(t, 1, moved, "Expected exactly one task to take the last place")
+	assert.Equal(t, len(taskIDs)-2, refused, "Expected the other tasks to exceed the wip limit")
+}
+
+func TestColumnKind(t *testing.T) {
+	user := MockUser{FirstName: "columnkind", LastName: "columnkind", Email: "columnkind@gmail.com", Password: "12@Amir###90"}
+	userResult, userData, err := CreateUserWithResp(user)
+	if err != nil || userResult.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to create user. Status code: %d, Error: %v", userResult.StatusCode, err)
+	}
+	token, err := LoginAndGetToken(t, MockUserLogin{Email: user.Email, Password: user.Password})
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	boardResp, boardData, err := CreateBoard(token, MockBoard{Name: "Column Kind Board", Type: "private"})
+	if err != nil || boardResp.StatusCode != http.StatusCreated {
+		t.Fatalf("CreateBoard failed: %v", err)
+	}
+	payload := map[string]interface{}{"board_id": boardData.BoardID, "columns": []map[string]string{{"name": "shipped"}}}
+	if status := DoRequest(t, token, "POST", ServerURL+ColumnPost, payload); status != http.StatusCreated {
+		t.Fatalf("Failed to create column. Status code: %d", status)
+	}
+	columns := GetBoardColumns(t, token, boardData.BoardID)
+
+	assigneeUUID, _ := uuid.Parse(userData.UserID)
+	boardUUID, _ := uuid.Parse(boardData.BoardID)
+	taskID, err := CreateTask(token, MockTask{Title: "kind", AssigneeUserID: assigneeUUID, StoryPoint: 1, BoardID: boardUUID})
+	if err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+
+	setKind := func(name, kind string) int {
+		return DoRequest(t, token, "PUT", fmt.Sprintf("%s%s/%s/kind", ServerURL, ColumnPost, columns[name]), map[string]string{"kind": kind})
+	}
+	moveTo := func(name string) {
+		status := DoRequest(t, token, "PUT", fmt.Sprintf("%s%s/%s/column", ServerURL, TaskPost, taskID), map[string]string{"column_id": columns[name]})
+		if status != http.StatusOK {
+			t.Fatalf("Failed to move task to %s. Status code: %d", name, status)
+		}
+	}
+	isDone := func() interface{} {
+		status, tree := doJSONRequest(t, token, "GET", fmt.Sprintf("%s%s/%s/tree", ServerURL, TaskPost, taskID), nil)
+		if status != http.StatusOK {
+			t.Fatalf("Failed to fetch tree. Status code: %d", status)
+		}
+		return tree["is_done"]
+	}
+
+	t.Run("InvalidKind", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, setKind("shipped", "finished"))
+	})
+
+	t.Run("DoneFollowsTheKind", func(t *testing.T) {
+		moveTo("shipped")
+		assert.Equal(t, false, isDone(), "shipped is not a done column yet")
+
+		assert.Equal(t, http.StatusOK, setKind("shipped", "done"))
+		assert.Equal(t, true, isDone(), "shipped is a done column now")
+	})
+
+	t.Run("DoneIsNotTheName", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, setKind("done", "in_progress"))
+		moveTo("done")
+		assert.Equal(t, false, isDone(), "a column named done is not a done column")
+	})
+
+	t.Run("MigrationMarksColumnsNamedDone", func(t *testing.T) {
+		// replay the migration on a database from before kinds existed, then throw it away
+		tx := TestApp.RawDBConnection().Begin()
+		defer tx.Rollback()
+		if err := tx.Exec("ALTER TABLE columns DROP COLUMN kind").Error; err != nil {
+			t.Fatalf("Failed to drop kind: %v", err)
+		}
+		if err := storage.Migrate(tx); err != nil {
+			t.Fatalf("Migrate failed: %v", err)
+		}
+
+		var rows []struct {
+			Name string
+			Kind string
+		}
+		tx.Table("columns").Select("name, kind").Where("board_id = ?", boardData.BoardID).Scan(&rows)
+		kinds := make(map[string]string, len(rows))
+		for _, r := range rows {
+			kinds[r.Name] = r.Kind
+		}
+		assert.Equal(t, map[string]string{"done": "done", "shipped": "in_progress"}, kinds)
+	})
+}
+
+func TestColumnTransitions(t *testing.T) {
+	user := MockUser{FirstName: "transition", LastName: "transition", Email: "transition@gmail.com", Password: "12@Amir###90"}
+	userResult, userData, err := CreateUserWithResp(user)
+	if err != nil || userResult.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to create user. Status code: %d, Error: %v", userResult.StatusCode, err)
+	}
+	token, err := LoginAndGetToken(t, MockUserLogin{Email: user.Email, Password: user.Password})
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	boardResp, boardData, err := CreateBoard(token, MockBoard{Name: "Column Transition Board", Type: "private"})
+	if err != nil || boardResp.StatusCode != http.StatusCreated {
+		t.Fatalf("CreateBoard failed: %v", err)
+	}
+	payload := map[string]interface{}{"board_id": boardData.BoardID, "columns": []map[string]string{{"name": "todo"}, {"name": "review"}}}
+	if status := DoRequest(t, token, "POST", ServerURL+ColumnPost, payload); status != http.StatusCreated {
+		t.Fatalf("Failed to create columns. Status code: %d", status)
+	}
+	columns := GetBoardColumns(t, token, boardData.BoardID)
+
+	assigneeUUID, _ := uuid.Parse(userData.UserID)
+	boardUUID, _ := uuid.Parse(boardData.BoardID)
+	taskID, err := CreateTask(token, MockTask{Title: "transition", AssigneeUserID: assigneeUUID, StoryPoint: 1, BoardID: boardUUID})
+	if err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+	moveURL := fmt.Sprintf("%s%s/%s/column", ServerURL, TaskPost, taskID)
+	if status := DoRequest(t, token, "PUT", moveURL, map[string]string{"column_id": columns["todo"]}); status != http.StatusOK {
+		t.Fatalf("Failed to move task to todo. Status code: %d", status)
+	}
+
+	// todo -> review for everyone, review -> done for maintainers only
+	transitions := map[string]interface{}{"transitions": []map[string]interface{}{
+		{"from_column_id": columns["todo"], "to_column_id": columns["review"]},
+		{"from_column_id": columns["review"], "to_column_id": columns["done"], "roles": []string{"maintainer"}},
+	}}
+	transitionsURL := fmt.Sprintf("%s%s/%s/transitions", ServerURL, BoardPost, boardData.BoardID)
+	if status := DoRequest(t, token, "PUT", transitionsURL, transitions); status != http.StatusOK {
+		t.Fatalf("Failed to set transitions. Status code: %d", status)
+	}
+
+	moveTo := func(name string) (int, []string) {
+		payloadJSON, _ := json.Marshal(map[string]string{"column_id": columns[name]})
+		req, _ := http.NewRequest("PUT", moveURL, bytes.NewBuffer(payloadJSON))
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to execute request: %v", err)
+		}
+		defer resp.Body.Close()
+		var res struct {
+			AllowedColumns []string `json:"allowed_columns"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&res)
+		return resp.StatusCode, res.AllowedColumns
+	}
+
+	t.Run("MoveOutsideTheWorkflow", func(t *testing.T) {
+		status, allowed := moveTo("done")
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, []string{"review"}, allowed)
+	})
+
+	t.Run("MoveAlongTheWorkflow", func(t *testing.T) {
+		status, _ := moveTo("review")
+		assert.Equal(t, http.StatusOK, status)
+	})
+
+	t.Run("MoveForAnotherRole", func(t *testing.T) {
+		status, allowed := moveTo("done")
+		assert.Equal(t, http.StatusBadRequest, status, "only maintainers may close a review")
+		assert.Empty(t, allowed)
+	})
+}
@@ -0,0 +1,160 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// doJSONRequest is DoRequest that also returns the data of the response.
+func doJSONRequest(t *testing.T, token, method, url string, payload interface{}) (int, map[string]interface{}) {
+	var body bytes.Buffer
+	if payload != nil {
+		if err := json.NewEncoder(&body).Encode(payload); err != nil {
+			t.Fatalf("Failed to marshal payload to JSON: %v", err)
+		}
+	}
+	req, err := http.NewRequest(method, url, &body)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to execute request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var res struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&res)
+	return resp.StatusCode, res.Data
+}
+
+func TestInvitations(t *testing.T) {
+	owner := MockUser{
+		FirstName: "invitation",
+		LastName:  "owner",
+		Email:     "invitationowner@gmail.com",
+		Password:  "12@Amir###90",
+	}
+	invitee := MockUser{
+		FirstName: "invitation",
+		LastName:  "invitee",
+		Email:     "invitationinvitee@gmail.com",
+		Password:  "12@Amir###90",
+	}
+
+	if result := CreateUser(owner); result.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to create owner. Status code: %d", result.StatusCode)
+	}
+	ownerToken, err := LoginAndGetToken(t, MockUserLogin{Email: owner.Email, Password: owner.Password})
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	boardResp, boardData, err := CreateBoard(ownerToken, MockBoard{Name: "Invitation Board", Type: "private"})
+	if err != nil || boardResp.StatusCode != http.StatusCreated {
+		t.Fatalf("CreateBoard failed: %v", err)
+	}
+
+	inviteURL := fmt.Sprintf("%s%s/invite", ServerURL, BoardPost)
+	invite := map[string]interface{}{"email": invitee.Email, "board_id": boardData.BoardID, "role": "editor"}
+
+	// the invitee has no account yet
+	status, data := doJSONRequest(t, ownerToken, "POST", inviteURL, invite)
+	assert.Equal(t, http.StatusCreated, status, "Expected status code")
+	assert.Equal(t, "pending", data["status"], "Expected status")
+	status, _ = doJSONRequest(t, ownerToken, "POST", inviteURL, invite)
+	assert.Equal(t, http.StatusConflict, status, "Expected status code")
+
+	if result := CreateUser(invitee); result.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to create invitee. Status code: %d", result.StatusCode)
+	}
+	inviteeToken, err := LoginAndGetToken(t, MockUserLogin{Email: invitee.Email, Password: invitee.Password})
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/invitations", ServerURL), nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+inviteeToken)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to execute request: %v", err)
+	}
+	var list struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&list)
+	resp.Body.Close()
+	if err != nil || len(list.Data) != 1 {
+		t.Fatalf("Expected the claimed invitation, got %v (%v)", list.Data, err)
+	}
+	invitationURL := fmt.Sprintf("%s/invitations/%s", ServerURL, list.Data[0]["invitation_id"])
+
+	assert.Equal(t, http.StatusNotFound, DoRequest(t, ownerToken, "POST", invitationURL+"/accept", nil), "Only the invitee can accept")
+	assert.Equal(t, http.StatusNoContent, DoRequest(t, inviteeToken, "POST", invitationURL+"/decline", nil), "Decline")
+	assert.Equal(t, http.StatusBadRequest, DoRequest(t, inviteeToken, "POST", invitationURL+"/accept", nil), "Declined invitations can not be accepted")
+
+	InviteMember(t, ownerToken, inviteeToken, invitee.Email, boardData.BoardID, "editor")
+	status, _ = doJSONRequest(t, ownerToken, "POST", inviteURL, invite)
+	assert.Equal(t, http.StatusBadRequest, status, "Members can not be invited again")
+}
+
+func TestInviteLinks(t *testing.T) {
+	users := []MockUser{
+		{FirstName: "links", LastName: "owner", Email: "linksowner@gmail.com", Password: "12@Amir###90"},
+		{FirstName: "links", LastName: "first", Email: "linksfirst@gmail.com", Password: "12@Amir###90"},
+		{FirstName: "links", LastName: "second", Email: "linkssecond@gmail.com", Password: "12@Amir###90"},
+	}
+	tokens := make([]string, len(users))
+	for i, user := range users {
+		if result := CreateUser(user); result.StatusCode != http.StatusCreated {
+			t.Fatalf("Failed to create user. Status code: %d", result.StatusCode)
+		}
+		token, err := LoginAndGetToken(t, MockUserLogin{Email: user.Email, Password: user.Password})
+		if err != nil {
+			t.Fatalf("Login failed: %v", err)
+		}
+		tokens[i] = token
+	}
+	ownerToken := tokens[0]
+
+	boardResp, boardData, err := CreateBoard(ownerToken, MockBoard{Name: "Links Board", Type: "private"})
+	if err != nil || boardResp.StatusCode != http.StatusCreated {
+		t.Fatalf("CreateBoard failed: %v", err)
+	}
+	linksURL := fmt.Sprintf("%s%s/%s/invite-links", ServerURL, BoardPost, boardData.BoardID)
+
+	status, _ := doJSONRequest(t, ownerToken, "POST", linksURL, map[string]interface{}{"role": "owner"})
+	assert.Equal(t, http.StatusBadRequest, status, "Links can not give the owner role")
+
+	status, link := doJSONRequest(t, ownerToken, "POST", linksURL, map[string]interface{}{"role": "viewer", "max_uses": 1})
+	if status != http.StatusCreated {
+		t.Fatalf("Creating invite link failed. Status code: %d", status)
+	}
+	joinURL := fmt.Sprintf("%s/invite-links/%s/join", ServerURL, link["token"])
+
+	tamperedURL := fmt.Sprintf("%s/invite-links/%sx/join", ServerURL, link["token"])
+	assert.Equal(t, http.StatusNotFound, DoRequest(t, tokens[1], "POST", tamperedURL, nil), "Tampered token")
+	assert.Equal(t, http.StatusOK, DoRequest(t, tokens[1], "POST", joinURL, nil), "Join")
+	assert.Equal(t, http.StatusBadRequest, DoRequest(t, tokens[1], "POST", joinURL, nil), "Members can not join again")
+	assert.Equal(t, http.StatusBadRequest, DoRequest(t, tokens[2], "POST", joinURL, nil), "Link is used up")
+
+	status, link = doJSONRequest(t, ownerToken, "POST", linksURL, map[string]interface{}{"role": "viewer"})
+	if status != http.StatusCreated {
+		t.Fatalf("Creating invite link failed. Status code: %d", status)
+	}
+	assert.Equal(t, http.StatusForbidden, DoRequest(t, tokens[1], "DELETE", fmt.Sprintf("%s/%s", linksURL, link["link_id"]), nil), "Viewers can not revoke links")
+	assert.Equal(t, http.StatusNoContent, DoRequest(t, ownerToken, "DELETE", fmt.Sprintf("%s/%s", linksURL, link["link_id"]), nil), "Revoke")
+	joinURL = fmt.Sprintf("%s/invite-links/%s/join", ServerURL, link["token"])
+	assert.Equal(t, http.StatusBadRequest, DoRequest(t, tokens[2], "POST", joinURL, nil), "Link is revoked")
+}
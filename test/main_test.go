@@ -8,6 +8,8 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -20,7 +22,8 @@ import (
 )
 
 var (
-	TestDB *gorm.DB
+	TestDB  *gorm.DB
+	TestApp *service.AppContainer
 )
 
 const (
@@ -29,6 +32,7 @@ const (
 	Login      = "/login"
 	BoardPost  = "/boards"
 	configPath = "test_config.yaml"
+	mailLog    = "mail_test.log"
 	TaskPost   = "/tasks"
 	ColumnPost = "/columns"
 )
@@ -36,12 +40,15 @@ const (
 func TestMain(m *testing.M) {
 	// Load configuration
 	cfg := readConfig()
+	os.Remove(mailLog)
 
 	app, err := service.NewAppContainer(cfg)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	TestApp = app
+
 	go func() {
 		http_server.Run(cfg, app)
 	}()
@@ -120,6 +127,11 @@ func CreateUserWithResp(user MockUser) (UserCreationResult, UserCreationData, er
 		StatusCode: resp.StatusCode,
 		Message:    res.Message,
 	}
+	if resp.StatusCode == http.StatusCreated {
+		if err := VerifyEmail(user.Email); err != nil {
+			return UserCreationResult{}, UserCreationData{}, err
+		}
+	}
 
 	return userResult, data, nil
 }
@@ -234,9 +246,192 @@ func CreateUser(user MockUser) UserCreationResult {
 	if err != nil {
 		log.Fatalf("Failed to unmarshal response body: %v", err)
 	}
+	if resp.StatusCode == http.StatusCreated {
+		if err := VerifyEmail(user.Email); err != nil {
+			log.Fatalf("Failed to verify email: %v", err)
+		}
+	}
 
 	return UserCreationResult{
 		StatusCode: resp.StatusCode,
 		Message:    res.Message,
 	}
 }
+
+func CreateTask(token string, task MockTask) (string, error) {
+	url := fmt.Sprintf("%s%s", ServerURL, TaskPost)
+
+	reqBody, err := json.Marshal(task)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return "", fmt.Errorf("failed to create HTTP request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to perform request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("expected status code 201 Created, got %s", resp.Status)
+	}
+
+	var res Response
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return "", fmt.Errorf("failed to decode task response: %v", err)
+	}
+
+	taskData, ok := res.Data.(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("failed to convert response data to map[string]interface{}")
+	}
+
+	taskID, ok := taskData["id"].(string)
+	if !ok {
+		return "", fmt.Errorf("id not found or not a string")
+	}
+	return taskID, nil
+}
+
+// DoRequest sends payload as JSON with token and returns the status code of the response.
+func DoRequest(t *testing.T, token, method, url string, payload interface{}) int {
+	var body io.Reader
+	if payload != nil {
+		payloadJSON, err := json.Marshal(payload)
+		if err != nil {
+			t.Fatalf("Failed to marshal payload to JSON: %v", err)
+		}
+		body = bytes.NewBuffer(payloadJSON)
+	}
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to execute request: %v", err)
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode
+}
+
+// InviteMember invites email to the board and accepts the invitation as the invitee.
+func InviteMember(t *testing.T, inviterToken, inviteeToken, email, boardID, role string) {
+	payloadJSON, err := json.Marshal(map[string]string{"email": email, "board_id": boardID, "role": role})
+	if err != nil {
+		t.Fatalf("Failed to marshal payload to JSON: %v", err)
+	}
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s%s/invite", ServerURL, BoardPost), bytes.NewBuffer(payloadJSON))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+inviterToken)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to execute request: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("Invite failed. Status code: %d", resp.StatusCode)
+	}
+
+	var res struct {
+		Data struct {
+			InvitationID string `json:"invitation_id"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	acceptURL := fmt.Sprintf("%s/invitations/%s/accept", ServerURL, res.Data.InvitationID)
+	if status := DoRequest(t, inviteeToken, "POST", acceptURL, nil); status != http.StatusOK {
+		t.Fatalf("Accepting invitation failed. Status code: %d", status)
+	}
+}
+
+var mailTokenRegex = regexp.MustCompile(`token=([\w-]+)`)
+
+// LastMailedToken returns the token of the link last mailed to email with subject.
+func LastMailedToken(email, subject string) (string, error) {
+	data, err := os.ReadFile(mailLog)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the mail log: %v", err)
+	}
+	token := ""
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		var mail struct {
+			To      string `json:"to"`
+			Subject string `json:"subject"`
+			Body    string `json:"body"`
+		}
+		if json.Unmarshal(line, &mail) != nil || !strings.EqualFold(mail.To, email) || mail.Subject != subject {
+			continue
+		}
+		if match := mailTokenRegex.FindStringSubmatch(mail.Body); match != nil {
+			token = match[1]
+		}
+	}
+	if token == "" {
+		return "", fmt.Errorf("no %q mail was sent to %s", subject, email)
+	}
+	return token, nil
+}
+
+// VerifyEmail follows the verification link last mailed to email.
+func VerifyEmail(email string) error {
+	token, err := LastMailedToken(email, "Verify your email")
+	if err != nil {
+		return err
+	}
+	reqBody, _ := json.Marshal(map[string]string{"token": token})
+	resp, err := http.Post(ServerURL+"/verify-email", "application/json", bytes.NewBuffer(reqBody))
+	if err != nil {
+		return fmt.Errorf("failed to make POST request: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("verifying %s failed with status code %d", email, resp.StatusCode)
+	}
+	return nil
+}
+
+// GetBoardColumns maps the column names of a board to their ids.
+func GetBoardColumns(t *testing.T, token, boardID string) map[string]string {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s%s/%s", ServerURL, BoardPost, boardID), nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to execute request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var res struct {
+		Data struct {
+			Columns []struct {
+				ID   string `json:"id"`
+				Name string `json:"name"`
+			} `json:"columns"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		t.Fatalf("Failed to decode board: %v", err)
+	}
+	columns := make(map[string]string, len(res.Data.Columns))
+	for _, c := range res.Data.Columns {
+		columns[c.Name] = c.ID
+	}
+	return columns
+}
@@ -0,0 +1,82 @@
+package test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomRoles(t *testing.T) {
+	users := map[string]MockUser{
+		"owner":     {FirstName: "roles", LastName: "owner", Email: "rolesowner@gmail.com", Password: "12@Amir###90"},
+		"recruiter": {FirstName: "roles", LastName: "recruiter", Email: "rolesrecruiter@gmail.com", Password: "12@Amir###90"},
+		"newcomer":  {FirstName: "roles", LastName: "newcomer", Email: "rolesnewcomer@gmail.com", Password: "12@Amir###90"},
+	}
+	tokens := make(map[string]string, len(users))
+	for name, user := range users {
+		result, _, err := CreateUserWithResp(user)
+		if err != nil || result.StatusCode != http.StatusCreated {
+			t.Fatalf("Failed to create %s. Status code: %d, Response message: %s", name, result.StatusCode, result.Message)
+		}
+		token, err := LoginAndGetToken(t, MockUserLogin{Email: user.Email, Password: user.Password})
+		if err != nil {
+			t.Fatalf("Login failed: %v", err)
+		}
+		tokens[name] = token
+	}
+
+	resp, boardData, err := CreateBoard(tokens["owner"], MockBoard{Name: "Roles Board", Type: "private"})
+	if err != nil || resp.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to create board: %v", err)
+	}
+	boardURL := fmt.Sprintf("%s%s/%s", ServerURL, BoardPost, boardData.BoardID)
+
+	status, role := doJSONRequest(t, tokens["owner"], "POST", boardURL+"/roles",
+		map[string]interface{}{"name": "recruiter", "permissions": []string{"view_board", "view_task", "invite_users"}})
+	if status != http.StatusCreated {
+		t.Fatalf("Failed to create role. Status code: %d", status)
+	}
+	roleKey, _ := role["role"].(string)
+	roleURL := fmt.Sprintf("%s/roles/%v", boardURL, role["role_id"])
+	InviteMember(t, tokens["owner"], tokens["recruiter"], users["recruiter"].Email, boardData.BoardID, roleKey)
+
+	invite := map[string]string{"email": users["newcomer"].Email, "board_id": boardData.BoardID, "role": "viewer"}
+	mockScenarios := []struct {
+		name               string
+		token              string
+		method             string
+		url                string
+		payload            interface{}
+		expectedStatusCode int
+	}{
+		{"UnknownPermission", tokens["owner"], "POST", boardURL + "/roles", map[string]interface{}{"name": "wizard", "permissions": []string{"cast_spells"}}, http.StatusBadRequest},
+		{"MemberCantCreateRole", tokens["recruiter"], "POST", boardURL + "/roles", map[string]interface{}{"name": "boss", "permissions": []string{"remove_board"}}, http.StatusForbidden},
+		{"CustomRoleCanView", tokens["recruiter"], "GET", boardURL + "/members", nil, http.StatusOK},
+		{"CustomRoleCanInvite", tokens["recruiter"], "POST", ServerURL + BoardPost + "/invite", invite, http.StatusCreated},
+		{"CustomRoleCantSetRoles", tokens["recruiter"], "POST", boardURL + "/roles", map[string]interface{}{"name": "x", "permissions": []string{"view_board"}}, http.StatusForbidden},
+		{"RoleInUseCantBeDeleted", tokens["owner"], "DELETE", roleURL, nil, http.StatusConflict},
+		{"EditRole", tokens["owner"], "PATCH", roleURL, map[string]interface{}{"permissions": []string{"view_task"}}, http.StatusOK},
+		{"EditedRoleCantView", tokens["recruiter"], "GET", boardURL + "/members", nil, http.StatusForbidden},
+		{"UnknownRoleCantBeGiven", tokens["owner"], "POST", ServerURL + BoardPost + "/invite", map[string]string{"email": users["newcomer"].Email, "board_id": boardData.BoardID, "role": "custom:00000000-0000-0000-0000-000000000000"}, http.StatusBadRequest},
+	}
+
+	for _, scenario := range mockScenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			status := DoRequest(t, scenario.token, scenario.method, scenario.url, scenario.payload)
+			assert.Equal(t, scenario.expectedStatusCode, status)
+		})
+	}
+
+	t.Run("CloneDropsCustomRoles", func(t *testing.T) {
+		status, clone := doJSONRequest(t, tokens["owner"], "POST", boardURL+"/clone", map[string]interface{}{"name": "Roles Copy", "include_members": true})
+		if status != http.StatusCreated {
+			t.Fatalf("Failed to clone board. Status code: %d", status)
+		}
+		cloneURL := fmt.Sprintf("%s%s/%v", ServerURL, BoardPost, clone["board_id"])
+		// the role of the source board does not exist on the copy, its holder becomes a viewer there
+		assert.Equal(t, http.StatusOK, DoRequest(t, tokens["recruiter"], "GET", cloneURL+"/members", nil))
+		assert.Equal(t, http.StatusForbidden, DoRequest(t, tokens["recruiter"], "GET", boardURL+"/members", nil))
+	})
+}
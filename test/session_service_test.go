@@ -0,0 +1,104 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// loginSession logs user in and returns the authentication and refresh token of the new session.
+func loginSession(t *testing.T, user MockUser) (string, string) {
+	status, data := doJSONRequest(t, "", "POST", ServerURL+Login, MockUserLogin{Email: user.Email, Password: user.Password})
+	if status != http.StatusOK {
+		t.Fatalf("Login failed. Status code: %d", status)
+	}
+	authToken, _ := data["auth_token"].(string)
+	refreshToken, _ := data["refresh_token"].(string)
+	return authToken, refreshToken
+}
+
+func countSessions(t *testing.T, token string) int {
+	req, _ := http.NewRequest("GET", ServerURL+"/sessions", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to execute request: %v", err)
+	}
+	defer resp.Body.Close()
+	var res struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	return len(res.Data)
+}
+
+func TestSessions(t *testing.T) {
+	user := MockUser{FirstName: "session", LastName: "user", Email: "sessionuser@gmail.com", Password: "12@Amir###90"}
+	if result := CreateUser(user); result.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to create user. Status code: %d, Response message: %s", result.StatusCode, result.Message)
+	}
+	laptopAuth, laptopRefresh := loginSession(t, user)
+	phoneAuth, phoneRefresh := loginSession(t, user)
+	tabletAuth, tabletRefresh := loginSession(t, user)
+	refreshURL := ServerURL + "/refresh"
+
+	t.Run("ListSessions", func(t *testing.T) {
+		assert.Equal(t, 3, countSessions(t, laptopAuth))
+	})
+
+	t.Run("AccessTokenCantRefresh", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, DoRequest(t, laptopAuth, "GET", refreshURL, nil))
+	})
+
+	t.Run("RefreshTokenCantAuthorize", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, DoRequest(t, laptopRefresh, "GET", ServerURL+"/me", nil))
+	})
+
+	t.Run("RotationAndReuse", func(t *testing.T) {
+		status, data := doJSONRequest(t, laptopRefresh, "GET", refreshURL, nil)
+		assert.Equal(t, http.StatusOK, status)
+		rotated, _ := data["refresh_token"].(string)
+		assert.NotEqual(t, laptopRefresh, rotated)
+
+		// the old token again is taken for a leak and revokes the session, the rotated one included
+		assert.Equal(t, http.StatusUnauthorized, DoRequest(t, laptopRefresh, "GET", refreshURL, nil))
+		assert.Equal(t, http.StatusUnauthorized, DoRequest(t, rotated, "GET", refreshURL, nil))
+		assert.Equal(t, http.StatusUnauthorized, DoRequest(t, laptopAuth, "GET", ServerURL+"/sessions", nil))
+		assert.Equal(t, 2, countSessions(t, phoneAuth))
+	})
+
+	t.Run("Logout", func(t *testing.T) {
+		assert.Equal(t, http.StatusNoContent, DoRequest(t, phoneAuth, "POST", ServerURL+"/logout", nil))
+		assert.Equal(t, http.StatusUnauthorized, DoRequest(t, phoneRefresh, "GET", refreshURL, nil))
+		assert.Equal(t, http.StatusUnauthorized, DoRequest(t, phoneAuth, "GET", ServerURL+"/sessions", nil), "the access token ends with its session")
+	})
+
+	t.Run("LogoutAll", func(t *testing.T) {
+		auth, refresh := loginSession(t, user)
+		assert.Equal(t, http.StatusNoContent, DoRequest(t, auth, "POST", ServerURL+"/logout-all", nil))
+		assert.Equal(t, http.StatusUnauthorized, DoRequest(t, refresh, "GET", refreshURL, nil))
+		assert.Equal(t, http.StatusUnauthorized, DoRequest(t, tabletRefresh, "GET", refreshURL, nil))
+		assert.Equal(t, http.StatusUnauthorized, DoRequest(t, auth, "GET", ServerURL+"/sessions", nil), "the access token ends with its session")
+		assert.Equal(t, http.StatusUnauthorized, DoRequest(t, tabletAuth, "GET", ServerURL+"/sessions", nil))
+	})
+}
+
+func TestJWKS(t *testing.T) {
+	resp, err := http.Get("http://0.0.0.0:8080/.well-known/jwks.json")
+	if err != nil {
+		t.Fatalf("Failed to make GET request: %v", err)
+	}
+	defer resp.Body.Close()
+	var jwks struct {
+		Keys []map[string]interface{} `json:"keys"`
+	}
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&jwks))
+	// the test config signs with the shared secret, which is never published
+	assert.NotNil(t, jwks.Keys)
+	assert.Empty(t, jwks.Keys)
+}
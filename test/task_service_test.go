@@ -2,11 +2,13 @@ package test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -168,3 +170,611 @@ func TestTaskCreation(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskUpdate(t *testing.T) {
+	// Create mock user
+	user := MockUser{
+		FirstName: "taskupdate",
+		LastName:  "taskupdate",
+		Email:     "taskupdate@gmail.com",
+		Password:  "12@Amir###90",
+	}
+
+	userResult, userData, err := CreateUserWithResp(user)
+	if err != nil || userResult.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to create user. Status code: %d, Error: %v", userResult.StatusCode, err)
+	}
+
+	token, err := LoginAndGetToken(t, MockUserLogin{
+		Email:    user.Email,
+		Password: user.Password,
+	})
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	boardResp, boardData, err := CreateBoard(token, MockBoard{Name: "Task Update Board", Type: "private"})
+	if err != nil || boardResp.StatusCode != http.StatusCreated {
+		t.Fatalf("CreateBoard failed: %v", err)
+	}
+
+	assigneeUUID, _ := uuid.Parse(userData.UserID)
+	boardUUID, _ := uuid.Parse(boardData.BoardID)
+
+	taskID, err := CreateTask(token, MockTask{
+		Title:          "Write Tests",
+		AssigneeUserID: assigneeUUID,
+		StoryPoint:     3,
+		BoardID:        boardUUID,
+	})
+	if err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+
+	mockScenarios := []struct {
+		name               string
+		payload            map[string]interface{}
+		expectedStatusCode int
+	}{
+		{
+			name:               "ValidTitleAndStoryPoint",
+			payload:            map[string]interface{}{"title": "Write More Tests", "story_point": 5},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "InvalidStoryPoint",
+			payload:            map[string]interface{}{"story_point": 4},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "EmptyTitle",
+			payload:            map[string]interface{}{"title": ""},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "EndBeforeStart",
+			payload:            map[string]interface{}{"start_at": "2024-08-10T00:00:00Z", "end_at": "2024-08-01T00:00:00Z"},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "NothingToUpdate",
+			payload:            map[string]interface{}{},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	url := fmt.Sprintf("%s%s/%s", ServerURL, TaskPost, taskID)
+	for _, scenario := range mockScenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			payloadJSON, err := json.Marshal(scenario.payload)
+			if err != nil {
+				t.Fatalf("Failed to marshal payload to JSON: %v", err)
+			}
+
+			req, err := http.NewRequest("PATCH", url, bytes.NewBuffer(payloadJSON))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			assert.Equal(t, scenario.expectedStatusCode, resp.StatusCode, "Expected status code")
+		})
+	}
+}
+
+func TestTaskMove(t *testing.T) {
+	user := MockUser{
+		FirstName: "taskmove",
+		LastName:  "taskmove",
+		Email:     "taskmove@gmail.com",
+		Password:  "12@Amir###90",
+	}
+
+	userResult, userData, err := CreateUserWithResp(user)
+	if err != nil || userResult.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to create user. Status code: %d, Error: %v", userResult.StatusCode, err)
+	}
+
+	token, err := LoginAndGetToken(t, MockUserLogin{
+		Email:    user.Email,
+		Password: user.Password,
+	})
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	boardResp, boardData, err := CreateBoard(token, MockBoard{Name: "Task Move Board", Type: "private"})
+	if err != nil || boardResp.StatusCode != http.StatusCreated {
+		t.Fatalf("CreateBoard failed: %v", err)
+	}
+
+	assigneeUUID, _ := uuid.Parse(userData.UserID)
+	boardUUID, _ := uuid.Parse(boardData.BoardID)
+
+	var taskIDs []string
+	for _, title := range []string{"First", "Second", "Third"} {
+		taskID, err := CreateTask(token, MockTask{Title: title, AssigneeUserID: assigneeUUID, StoryPoint: 1, BoardID: boardUUID})
+		if err != nil {
+			t.Fatalf("CreateTask failed: %v", err)
+		}
+		taskIDs = append(taskIDs, taskID)
+	}
+
+	mockScenarios := []struct {
+		name               string
+		taskID             string
+		payload            map[string]interface{}
+		expectedStatusCode int
+	}{
+		{
+			name:               "AfterFirst",
+			taskID:             taskIDs[2],
+			payload:            map[string]interface{}{"after_id": taskIDs[0]},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "BetweenNeighbours",
+			taskID:             taskIDs[0],
+			payload:            map[string]interface{}{"after_id": taskIDs[2], "before_id": taskIDs[1]},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "AfterItself",
+			taskID:             taskIDs[1],
+			payload:            map[string]interface{}{"after_id": taskIDs[1]},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "WrongOrder",
+			taskID:             taskIDs[0],
+			payload:            map[string]interface{}{"after_id": taskIDs[1], "before_id": taskIDs[2]},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, scenario := range mockScenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			payloadJSON, err := json.Marshal(scenario.payload)
+			if err != nil {
+				t.Fatalf("Failed to marshal payload to JSON: %v", err)
+			}
+
+			url := fmt.Sprintf("%s%s/%s/move", ServerURL, TaskPost, scenario.taskID)
+			req, err := http.NewRequest("POST", url, bytes.NewBuffer(payloadJSON))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to execute request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			assert.Equal(t, scenario.expectedStatusCode, resp.StatusCode, "Expected status code")
+		})
+	}
+}
+
+func TestTaskTrash(t *testing.T) {
+	user := MockUser{FirstName: "tasktrash", LastName: "tasktrash", Email: "tasktrash@gmail.com", Password: "12@Amir###90"}
+	userResult, userData, err := CreateUserWithResp(user)
+	if err != nil || userResult.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to create user. Status code: %d, Error: %v", userResult.StatusCode, err)
+	}
+	token, err := LoginAndGetToken(t, MockUserLogin{Email: user.Email, Password: user.Password})
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	boardResp, boardData, err := CreateBoard(token, MockBoard{Name: "Task Trash Board", Type: "private"})
+	if err != nil || boardResp.StatusCode != http.StatusCreated {
+		t.Fatalf("CreateBoard failed: %v", err)
+	}
+	assigneeUUID, _ := uuid.Parse(userData.UserID)
+	boardUUID, _ := uuid.Parse(boardData.BoardID)
+
+	parentID, err := CreateTask(token, MockTask{Title: "Parent", AssigneeUserID: assigneeUUID, StoryPoint: 1, BoardID: boardUUID})
+	if err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+	parentUUID, _ := uuid.Parse(parentID)
+	subtaskID, err := CreateTask(token, MockTask{Title: "Subtask", AssigneeUserID: assigneeUUID, StoryPoint: 1, BoardID: boardUUID, ParentID: &parentUUID})
+	if err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+	subtaskUUID, _ := uuid.Parse(subtaskID)
+	blockerID, err := CreateTask(token, MockTask{Title: "Blocker", AssigneeUserID: assigneeUUID, StoryPoint: 1, BoardID: boardUUID})
+	if err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+	blockerUUID, _ := uuid.Parse(blockerID)
+	status := DoRequest(t, token, "POST", ServerURL+TaskPost+"/dependency", map[string]interface{}{"task_id": subtaskID, "depends_on_task_ids": []string{blockerID}})
+	if status != http.StatusCreated {
+		t.Fatalf("Failed to add dependency. Status code: %d", status)
+	}
+
+	db := TestApp.RawDBConnection()
+	trashURL := fmt.Sprintf("%s%s/%s/trash", ServerURL, BoardPost, boardData.BoardID)
+	trashIDs := func(t *testing.T) []string {
+		status, data := doJSONRequest(t, token, "GET", trashURL, nil)
+		if status != http.StatusOK {
+			t.Fatalf("Failed to fetch trash. Status code: %d", status)
+		}
+		items, _ := data["data"].([]interface{})
+		ids := make([]string, 0, len(items))
+		for _, item := range items {
+			ids = append(ids, item.(map[string]interface{})["id"].(string))
+		}
+		return ids
+	}
+
+	t.Run("DeleteCascadesToSubtasks", func(t *testing.T) {
+		assert.Equal(t, http.StatusNoContent, DoRequest(t, token, "DELETE", ServerURL+TaskPost+"/"+parentID, nil))
+		assert.ElementsMatch(t, []string{parentID, subtaskID}, trashIDs(t))
+		assert.Equal(t, http.StatusBadRequest, DoRequest(t, token, "DELETE", ServerURL+TaskPost+"/"+parentID, nil), "already deleted")
+	})
+
+	t.Run("DeleteRemovesDependencies", func(t *testing.T) {
+		var count int64
+		db.Table("task_dependencies").Where("dependent_task_id = ? AND dependency_task_id = ?", subtaskUUID, blockerUUID).Count(&count)
+		assert.Zero(t, count)
+	})
+
+	t.Run("SubtaskNeedsItsParent", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, DoRequest(t, token, "POST", ServerURL+TaskPost+"/"+subtaskID+"/restore", nil))
+	})
+
+	t.Run("RestoreBringsSubtasksBack", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, DoRequest(t, token, "POST", ServerURL+TaskPost+"/"+parentID+"/restore", nil))
+		assert.Empty(t, trashIDs(t))
+		assert.Equal(t, http.StatusOK, DoRequest(t, token, "GET", ServerURL+TaskPost+"/"+subtaskID, nil))
+		assert.Equal(t, http.StatusBadRequest, DoRequest(t, token, "POST", ServerURL+TaskPost+"/"+parentID+"/restore", nil), "not in trash")
+	})
+
+	t.Run("PurgeRemovesExpiredTasks", func(t *testing.T) {
+		assert.Equal(t, http.StatusNoContent, DoRequest(t, token, "DELETE", ServerURL+TaskPost+"/"+blockerID, nil))
+		assert.Equal(t, http.StatusNoContent, DoRequest(t, token, "DELETE", ServerURL+TaskPost+"/"+parentID, nil))
+		// only the blocker is past the retention
+		db.Exec("UPDATE tasks SET deleted_at = ? WHERE id = ?", time.Now().AddDate(0, 0, -40), blockerUUID)
+
+		if _, err := TestApp.TaskService().PurgeTrash(context.Background(), 30*24*time.Hour); err != nil {
+			t.Fatalf("PurgeTrash failed: %v", err)
+		}
+		assert.ElementsMatch(t, []string{parentID, subtaskID}, trashIDs(t))
+
+		var count int64
+		db.Table("tasks").Where("id = ?", blockerUUID).Count(&count)
+		assert.Zero(t, count)
+	})
+
+	t.Run("RestoreOutOfDeletedColumn", func(t *testing.T) {
+		payload := map[string]interface{}{"board_id": boardData.BoardID, "columns": []map[string]string{{"name": "todo"}}}
+		if status := DoRequest(t, token, "POST", ServerURL+ColumnPost, payload); status != http.StatusCreated {
+			t.Fatalf("Failed to create column. Status code: %d", status)
+		}
+		columns := GetBoardColumns(t, token, boardData.BoardID)
+		taskID, err := CreateTask(token, MockTask{Title: "Orphan", AssigneeUserID: assigneeUUID, StoryPoint: 1, BoardID: boardUUID})
+		if err != nil {
+			t.Fatalf("CreateTask failed: %v", err)
+		}
+		status := DoRequest(t, token, "PUT", fmt.Sprintf("%s%s/%s/column", ServerURL, TaskPost, taskID), map[string]string{"column_id": columns["todo"]})
+		if status != http.StatusOK {
+			t.Fatalf("Failed to move task to todo. Status code: %d", status)
+		}
+
+		assert.Equal(t, http.StatusNoContent, DoRequest(t, token, "DELETE", ServerURL+TaskPost+"/"+taskID, nil))
+		// the column only holds a trashed task, so it can go
+		assert.Equal(t, http.StatusNoContent, DoRequest(t, token, "DELETE", ServerURL+ColumnPost+"/"+columns["todo"], nil))
+		assert.Equal(t, http.StatusOK, DoRequest(t, token, "POST", ServerURL+TaskPost+"/"+taskID+"/restore", nil))
+
+		status, data := doJSONRequest(t, token, "GET", ServerURL+TaskPost+"/"+taskID, nil)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, columns["done"], data["column_id"], "restored into the first column of the board")
+	})
+}
+
+func TestTaskTree(t *testing.T) {
+	user := MockUser{FirstName: "tasktree", LastName: "tasktree", Email: "tasktree@gmail.com", Password: "12@Amir###90"}
+	userResult, userData, err := CreateUserWithResp(user)
+	if err != nil || userResult.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to create user. Status code: %d, Error: %v", userResult.StatusCode, err)
+	}
+	token, err := LoginAndGetToken(t, MockUserLogin{Email: user.Email, Password: user.Password})
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	boardResp, boardData, err := CreateBoard(token, MockBoard{Name: "Task Tree Board", Type: "private"})
+	if err != nil || boardResp.StatusCode != http.StatusCreated {
+		t.Fatalf("CreateBoard failed: %v", err)
+	}
+	assigneeUUID, _ := uuid.Parse(userData.UserID)
+	boardUUID, _ := uuid.Parse(boardData.BoardID)
+
+	// root (5) -> child (3) -> grandchild (2), root -> sibling (1)
+	createTask := func(title string, storyPoint uint, parentID string) string {
+		task := MockTask{Title: title, AssigneeUserID: assigneeUUID, StoryPoint: storyPoint, BoardID: boardUUID}
+		if parentID != "" {
+			parentUUID, _ := uuid.Parse(parentID)
+			task.ParentID = &parentUUID
+		}
+		id, err := CreateTask(token, task)
+		if err != nil {
+			t.Fatalf("CreateTask failed: %v", err)
+		}
+		return id
+	}
+	rootID := createTask("Root", 5, "")
+	childID := createTask("Child", 3, rootID)
+	grandchildID := createTask("Grandchild", 2, childID)
+	siblingID := createTask("Sibling", 1, rootID)
+
+	doneColumnID := GetBoardColumns(t, token, boardData.BoardID)["done"]
+	for _, id := range []string{grandchildID, siblingID} {
+		status := DoRequest(t, token, "PUT", fmt.Sprintf("%s%s/%s/column", ServerURL, TaskPost, id), map[string]string{"column_id": doneColumnID})
+		if status != http.StatusOK {
+			t.Fatalf("Failed to move task to done. Status code: %d", status)
+		}
+	}
+
+	status, root := doJSONRequest(t, token, "GET", fmt.Sprintf("%s%s/%s/tree", ServerURL, TaskPost, rootID), nil)
+	if status != http.StatusOK {
+		t.Fatalf("Failed to fetch tree. Status code: %d", status)
+	}
+	assert.Equal(t, map[string]interface{}{
+		"total_tasks": 4.0, "done_tasks": 2.0, "total_story_points": 11.0, "done_story_points": 3.0, "percentage": 50.0,
+	}, root["progress"])
+
+	subtasks, _ := root["subtasks"].([]interface{})
+	if len(subtasks) != 2 {
+		t.Fatalf("Expected 2 subtasks, got %d", len(subtasks))
+	}
+	var child map[string]interface{}
+	for _, s := range subtasks {
+		if s.(map[string]interface{})["id"] == childID {
+			child = s.(map[string]interface{})
+		}
+	}
+	if child == nil {
+		t.Fatalf("Child not found in the tree")
+	}
+	assert.Equal(t, map[string]interface{}{
+		"total_tasks": 2.0, "done_tasks": 1.0, "total_story_points": 5.0, "done_story_points": 2.0, "percentage": 50.0,
+	}, child["progress"])
+	grandchildren, _ := child["subtasks"].([]interface{})
+	if assert.Len(t, grandchildren, 1) {
+		assert.Equal(t, grandchildID, grandchildren[0].(map[string]interface{})["id"])
+		assert.Equal(t, true, grandchildren[0].(map[string]interface{})["is_done"])
+	}
+
+	status, _ = doJSONRequest(t, token, "GET", fmt.Sprintf("%s%s/%s/tree", ServerURL, TaskPost, childID), nil)
+	assert.Equal(t, http.StatusOK, status, "a subtree can be fetched from any node")
+}
+
+// taskIDsOf collects the ids of a list of tasks in a response.
+func taskIDsOf(items interface{}) []string {
+	list, _ := items.([]interface{})
+	ids := make([]string, 0, len(list))
+	for _, item := range list {
+		ids = append(ids, item.(map[string]interface{})["id"].(string))
+	}
+	return ids
+}
+
+func TestTaskDependencies(t *testing.T) {
+	user := MockUser{FirstName: "taskdeps", LastName: "taskdeps", Email: "taskdeps@gmail.com", Password: "12@Amir###90"}
+	userResult, userData, err := CreateUserWithResp(user)
+	if err != nil || userResult.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to create user. Status code: %d, Error: %v", userResult.StatusCode, err)
+	}
+	token, err := LoginAndGetToken(t, MockUserLogin{Email: user.Email, Password: user.Password})
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	boardResp, boardData, err := CreateBoard(token, MockBoard{Name: "Task Dependencies Board", Type: "private"})
+	if err != nil || boardResp.StatusCode != http.StatusCreated {
+		t.Fatalf("CreateBoard failed: %v", err)
+	}
+	assigneeUUID, _ := uuid.Parse(userData.UserID)
+	boardUUID, _ := uuid.Parse(boardData.BoardID)
+
+	// design <- build <- release
+	ids := make(map[string]string)
+	for _, title := range []string{"design", "build", "release"} {
+		id, err := CreateTask(token, MockTask{Title: title, AssigneeUserID: assigneeUUID, StoryPoint: 1, BoardID: boardUUID})
+		if err != nil {
+			t.Fatalf("CreateTask failed: %v", err)
+		}
+		ids[title] = id
+	}
+	dependencyURL := ServerURL + TaskPost + "/dependency"
+	for _, edge := range [][2]string{{"build", "design"}, {"release", "build"}} {
+		payload := map[string]interface{}{"task_id": ids[edge[0]], "depends_on_task_ids": []string{ids[edge[1]]}}
+		if status := DoRequest(t, token, "POST", dependencyURL, payload); status != http.StatusCreated {
+			t.Fatalf("Failed to add dependency. Status code: %d", status)
+		}
+	}
+	dependenciesURL := func(title string, transitive bool) string {
+		return fmt.Sprintf("%s%s/%s/dependencies?transitive=%t", ServerURL, TaskPost, ids[title], transitive)
+	}
+	graphURL := fmt.Sprintf("%s%s/%s/dependency-graph", ServerURL, BoardPost, boardData.BoardID)
+
+	t.Run("DirectDependencies", func(t *testing.T) {
+		status, data := doJSONRequest(t, token, "GET", dependenciesURL("build", false), nil)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, []string{ids["design"]}, taskIDsOf(data["depends_on"]))
+		assert.Equal(t, []string{ids["release"]}, taskIDsOf(data["dependent_by"]))
+	})
+
+	t.Run("TransitiveDependencies", func(t *testing.T) {
+		status, data := doJSONRequest(t, token, "GET", dependenciesURL("release", true), nil)
+		assert.Equal(t, http.StatusOK, status)
+		assert.ElementsMatch(t, []string{ids["build"], ids["design"]}, taskIDsOf(data["depends_on"]))
+
+		status, data = doJSONRequest(t, token, "GET", dependenciesURL("design", true), nil)
+		assert.Equal(t, http.StatusOK, status)
+		assert.ElementsMatch(t, []string{ids["build"], ids["release"]}, taskIDsOf(data["dependent_by"]))
+	})
+
+	t.Run("DependencyGraph", func(t *testing.T) {
+		status, data := doJSONRequest(t, token, "GET", graphURL, nil)
+		assert.Equal(t, http.StatusOK, status)
+		assert.ElementsMatch(t, []string{ids["design"], ids["build"], ids["release"]}, taskIDsOf(data["nodes"]))
+		assert.ElementsMatch(t, []interface{}{
+			map[string]interface{}{"from": ids["build"], "to": ids["design"]},
+			map[string]interface{}{"from": ids["release"], "to": ids["build"]},
+		}, data["edges"])
+		assert.Contains(t, data["dot"], "digraph dependencies")
+	})
+
+	t.Run("DependencyGraphAsDOT", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", graphURL+"?format=dot", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to execute request: %v", err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Contains(t, string(body), "digraph dependencies")
+		assert.Contains(t, string(body), ids["release"])
+	})
+
+	t.Run("CircularDependencyIsRejected", func(t *testing.T) {
+		payload := map[string]interface{}{"task_id": ids["design"], "depends_on_task_ids": []string{ids["release"]}}
+		body, _ := json.Marshal(payload)
+		req, _ := http.NewRequest("POST", dependencyURL, bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to execute request: %v", err)
+		}
+		defer resp.Body.Close()
+		var res struct {
+			Cycle []string `json:"cycle"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&res)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+		assert.Equal(t, []string{ids["design"], ids["release"], ids["build"], ids["design"]}, res.Cycle)
+	})
+
+	t.Run("CrossBoardDependencyIsRejected", func(t *testing.T) {
+		otherResp, otherData, err := CreateBoard(token, MockBoard{Name: "Task Dependencies Other Board", Type: "private"})
+		if err != nil || otherResp.StatusCode != http.StatusCreated {
+			t.Fatalf("CreateBoard failed: %v", err)
+		}
+		otherBoardUUID, _ := uuid.Parse(otherData.BoardID)
+		otherID, err := CreateTask(token, MockTask{Title: "elsewhere", AssigneeUserID: assigneeUUID, StoryPoint: 1, BoardID: otherBoardUUID})
+		if err != nil {
+			t.Fatalf("CreateTask failed: %v", err)
+		}
+
+		payload := map[string]interface{}{"task_id": ids["design"], "depends_on_task_ids": []string{otherID}}
+		assert.Equal(t, http.StatusBadRequest, DoRequest(t, token, "POST", dependencyURL, payload))
+
+		designUUID, _ := uuid.Parse(ids["design"])
+		_, err = CreateTask(token, MockTask{Title: "crossing", AssigneeUserID: assigneeUUID, StoryPoint: 1, BoardID: otherBoardUUID, DependsOnTaskIDs: []uuid.UUID{designUUID}})
+		assert.ErrorContains(t, err, "400")
+	})
+
+	t.Run("RemoveDependency", func(t *testing.T) {
+		// the same id twice still removes the one dependency
+		payload := map[string]interface{}{"task_id": ids["release"], "depends_on_task_ids": []string{ids["build"], ids["build"]}}
+		assert.Equal(t, http.StatusNoContent, DoRequest(t, token, "DELETE", dependencyURL, payload))
+		assert.Equal(t, http.StatusBadRequest, DoRequest(t, token, "DELETE", dependencyURL, payload), "already removed")
+
+		status, data := doJSONRequest(t, token, "GET", dependenciesURL("release", true), nil)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Empty(t, taskIDsOf(data["depends_on"]))
+	})
+}
+
+func TestReorderValidation(t *testing.T) {
+	user := MockUser{FirstName: "reorder", LastName: "reorder", Email: "reorder@gmail.com", Password: "12@Amir###90"}
+	userResult, userData, err := CreateUserWithResp(user)
+	if err != nil || userResult.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to create user. Status code: %d, Error: %v", userResult.StatusCode, err)
+	}
+	token, err := LoginAndGetToken(t, MockUserLogin{Email: user.Email, Password: user.Password})
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	boardResp, boardData, err := CreateBoard(token, MockBoard{Name: "Reorder Board", Type: "private"})
+	if err != nil || boardResp.StatusCode != http.StatusCreated {
+		t.Fatalf("CreateBoard failed: %v", err)
+	}
+	payload := map[string]interface{}{"board_id": boardData.BoardID, "columns": []map[string]string{{"name": "todo"}}}
+	if status := DoRequest(t, token, "POST", ServerURL+ColumnPost, payload); status != http.StatusCreated {
+		t.Fatalf("Failed to create column. Status code: %d", status)
+	}
+	columns := GetBoardColumns(t, token, boardData.BoardID)
+
+	assigneeUUID, _ := uuid.Parse(userData.UserID)
+	boardUUID, _ := uuid.Parse(boardData.BoardID)
+	var taskIDs []string
+	for _, title := range []string{"first", "second"} {
+		id, err := CreateTask(token, MockTask{Title: title, AssigneeUserID: assigneeUUID, StoryPoint: 1, BoardID: boardUUID})
+		if err != nil {
+			t.Fatalf("CreateTask failed: %v", err)
+		}
+		status := DoRequest(t, token, "PUT", fmt.Sprintf("%s%s/%s/column", ServerURL, TaskPost, id), map[string]string{"column_id": columns["todo"]})
+		if status != http.StatusOK {
+			t.Fatalf("Failed to move task to todo. Status code: %d", status)
+		}
+		taskIDs = append(taskIDs, id)
+	}
+
+	items := func(ids ...string) []map[string]string {
+		list := make([]map[string]string, len(ids))
+		for i, id := range ids {
+			list[i] = map[string]string{"id": id}
+		}
+		return list
+	}
+	first, second := taskIDs[0], taskIDs[1]
+	done, todo := columns["done"], columns["todo"]
+
+	taskScenarios := []struct {
+		name               string
+		tasks              []map[string]string
+		expectedStatusCode int
+	}{
+		{name: "DuplicateTask", tasks: items(first, second, first), expectedStatusCode: http.StatusBadRequest},
+		{name: "DuplicateTaskKeepingLength", tasks: items(first, first), expectedStatusCode: http.StatusBadRequest},
+		{name: "MissingTask", tasks: items(first), expectedStatusCode: http.StatusBadRequest},
+		{name: "ValidOrder", tasks: items(second, first), expectedStatusCode: http.StatusOK},
+	}
+	for _, scenario := range taskScenarios {
+		t.Run("Tasks"+scenario.name, func(t *testing.T) {
+			payload := map[string]interface{}{"column_id": todo, "tasks": scenario.tasks}
+			assert.Equal(t, scenario.expectedStatusCode, DoRequest(t, token, "PATCH", ServerURL+TaskPost+"/reorder", payload))
+		})
+	}
+
+	columnScenarios := []struct {
+		name               string
+		columns            []map[string]string
+		expectedStatusCode int
+	}{
+		{name: "DuplicateColumn", columns: items(done, todo, done), expectedStatusCode: http.StatusBadRequest},
+		{name: "DuplicateColumnKeepingLength", columns: items(todo, todo), expectedStatusCode: http.StatusBadRequest},
+		{name: "MissingColumn", columns: items(todo), expectedStatusCode: http.StatusBadRequest},
+		{name: "ValidOrder", columns: items(todo, done), expectedStatusCode: http.StatusOK},
+	}
+	for _, scenario := range columnScenarios {
+		t.Run("Columns"+scenario.name, func(t *testing.T) {
+			payload := map[string]interface{}{"board_id": boardData.BoardID, "columns": scenario.columns}
+			assert.Equal(t, scenario.expectedStatusCode, DoRequest(t, token, "PUT", ServerURL+ColumnPost, payload))
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoardTemplates(t *testing.T) {
+	owner := MockUser{
+		FirstName: "template",
+		LastName:  "owner",
+		Email:     "templateowner@gmail.com",
+		Password:  "12@Amir###90",
+	}
+	member := MockUser{
+		FirstName: "template",
+		LastName:  "member",
+		Email:     "templatemember@gmail.com",
+		Password:  "12@Amir###90",
+	}
+
+	if result := CreateUser(owner); result.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to create owner. Status code: %d, Response message: %s", result.StatusCode, result.Message)
+	}
+	if result := CreateUser(member); result.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to create member. Status code: %d, Response message: %s", result.StatusCode, result.Message)
+	}
+
+	ownerToken, err := LoginAndGetToken(t, MockUserLogin{Email: owner.Email, Password: owner.Password})
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	memberToken, err := LoginAndGetToken(t, MockUserLogin{Email: member.Email, Password: member.Password})
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	boardResp, boardData, err := CreateBoard(ownerToken, MockBoard{Name: "Template Board", Type: "private"})
+	if err != nil || boardResp.StatusCode != http.StatusCreated {
+		t.Fatalf("CreateBoard failed: %v", err)
+	}
+	InviteMember(t, ownerToken, memberToken, member.Email, boardData.BoardID, "viewer")
+
+	boardURL := fmt.Sprintf("%s%s/%s", ServerURL, BoardPost, boardData.BoardID)
+	status, template := doJSONRequest(t, ownerToken, "POST", boardURL+"/template",
+		map[string]interface{}{"name": "Sprint", "include_tasks": true})
+	if status != http.StatusCreated {
+		t.Fatalf("Failed to save template. Status code: %d", status)
+	}
+	templateURL := fmt.Sprintf("%s/templates/%v", ServerURL, template["template_id"])
+
+	t.Run("OtherUserCantSeeTemplate", func(t *testing.T) {
+		status, _ := doJSONRequest(t, memberToken, "GET", templateURL, nil)
+		assert.Equal(t, http.StatusForbidden, status)
+	})
+
+	t.Run("CreateBoardFromTemplate", func(t *testing.T) {
+		status, created := doJSONRequest(t, ownerToken, "POST", templateURL+"/boards", map[string]string{"name": "From Template"})
+		assert.Equal(t, http.StatusCreated, status)
+		assert.Equal(t, "private", created["type"])
+		assert.Len(t, created["columns"], len(template["columns"].([]interface{})))
+	})
+
+	mockScenarios := []struct {
+		name               string
+		token              string
+		payload            interface{}
+		expectedStatusCode int
+	}{
+		{"Clone", ownerToken, map[string]interface{}{"name": "Cloned Board", "include_tasks": true, "include_members": true, "include_dependencies": true}, http.StatusCreated},
+		{"DependenciesWithoutTasks", ownerToken, map[string]interface{}{"name": "Cloned Board 2", "include_dependencies": true}, http.StatusBadRequest},
+		{"ViewerCloneWithoutMembers", memberToken, map[string]interface{}{"name": "Viewer Copy"}, http.StatusCreated},
+		{"ViewerCantCloneMembers", memberToken, map[string]interface{}{"name": "Viewer Copy 2", "include_members": true}, http.StatusForbidden},
+	}
+
+	for _, scenario := range mockScenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			status := DoRequest(t, scenario.token, "POST", boardURL+"/clone", scenario.payload)
+			assert.Equal(t, scenario.expectedStatusCode, status, "Expected status code")
+		})
+	}
+
+	t.Run("DeleteTemplate", func(t *testing.T) {
+		assert.Equal(t, http.StatusNoContent, DoRequest(t, ownerToken, "DELETE", templateURL, nil))
+		assert.Equal(t, http.StatusNotFound, DoRequest(t, ownerToken, "GET", templateURL, nil))
+	})
+}
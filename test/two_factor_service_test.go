@@ -0,0 +1,165 @@
+package test
+
+import (
+	"net/http"
+	"server/pkg/totp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTwoFactor(t *testing.T) {
+	user := MockUser{FirstName: "totp", LastName: "user", Email: "totpuser@gmail.com", Password: "12@Amir###90"}
+	if result := CreateUser(user); result.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to create user. Status code: %d, Response message: %s", result.StatusCode, result.Message)
+	}
+	token, _ := loginSession(t, user)
+	twoFactorURL := ServerURL + "/me/2fa"
+	loginURL := ServerURL + Login + "/2fa"
+	credentials := MockUserLogin{Email: user.Email, Password: user.Password}
+
+	status, data := doJSONRequest(t, token, "POST", twoFactorURL+"/enable", map[string]string{"code": "123456"})
+	assert.Equal(t, http.StatusBadRequest, status, "enabling before enrolling")
+
+	status, data = doJSONRequest(t, token, "POST", twoFactorURL, nil)
+	if status != http.StatusCreated {
+		t.Fatalf("Failed to enroll. Status code: %d", status)
+	}
+	secret, _ := data["secret"].(string)
+	uri, _ := data["provisioning_uri"].(string)
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/"), "provisioning uri")
+	assert.Contains(t, uri, "secret="+secret)
+
+	code := func(stepsAhead int64) string {
+		c, err := totp.Code(secret, totp.Step(time.Now())+stepsAhead)
+		if err != nil {
+			t.Fatalf("Failed to compute code: %v", err)
+		}
+		return c
+	}
+
+	status, _ = doJSONRequest(t, token, "POST", twoFactorURL+"/enable", map[string]string{"code": code(10)})
+	assert.Equal(t, http.StatusBadRequest, status, "enabling with a wrong code")
+
+	firstCode := code(0)
+	status, data = doJSONRequest(t, token, "POST", twoFactorURL+"/enable", map[string]string{"code": firstCode})
+	if status != http.StatusOK {
+		t.Fatalf("Failed to enable two-factor authentication. Status code: %d", status)
+	}
+	recoveryCodes, _ := data["recovery_codes"].([]interface{})
+	if len(recoveryCodes) != 10 {
+		t.Fatalf("Expected 10 recovery codes, got %d", len(recoveryCodes))
+	}
+
+	challenge := func() string {
+		status, data := doJSONRequest(t, "", "POST", ServerURL+Login, credentials)
+		if status != http.StatusOK || data["challenge_token"] == nil {
+			t.Fatalf("Expected a challenge. Status code: %d", status)
+		}
+		assert.Nil(t, data["auth_token"])
+		return data["challenge_token"].(string)
+	}
+
+	t.Run("EnableTwice", func(t *testing.T) {
+		status, _ := doJSONRequest(t, token, "POST", twoFactorURL+"/enable", map[string]string{"code": code(1)})
+		assert.Equal(t, http.StatusConflict, status)
+	})
+
+	t.Run("Status", func(t *testing.T) {
+		status, data := doJSONRequest(t, token, "GET", twoFactorURL, nil)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, true, data["enabled"])
+		assert.EqualValues(t, 10, data["recovery_codes_left"])
+	})
+
+	t.Run("CodeCantBeReplayed", func(t *testing.T) {
+		status, _ := doJSONRequest(t, "", "POST", loginURL, map[string]string{"challenge_token": challenge(), "code": firstCode})
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("ChallengeIsNoAccessToken", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, DoRequest(t, challenge(), "GET", ServerURL+"/me", nil))
+	})
+
+	t.Run("InvalidChallenge", func(t *testing.T) {
+		status, _ := doJSONRequest(t, "", "POST", loginURL, map[string]string{"challenge_token": token, "code": code(1)})
+		assert.Equal(t, http.StatusUnauthorized, status)
+	})
+
+	t.Run("LoginWithCode", func(t *testing.T) {
+		status, data := doJSONRequest(t, "", "POST", loginURL, map[string]string{"challenge_token": challenge(), "code": code(1)})
+		assert.Equal(t, http.StatusOK, status)
+		assert.NotEmpty(t, data["auth_token"])
+	})
+
+	t.Run("LoginWithRecoveryCode", func(t *testing.T) {
+		payload := map[string]string{"challenge_token": challenge(), "code": recoveryCodes[0].(string)}
+		status, data := doJSONRequest(t, "", "POST", loginURL, payload)
+		assert.Equal(t, http.StatusOK, status)
+		assert.NotEmpty(t, data["auth_token"])
+
+		payload["challenge_token"] = challenge()
+		status, _ = doJSONRequest(t, "", "POST", loginURL, payload)
+		assert.Equal(t, http.StatusBadRequest, status, "a recovery code works once")
+	})
+
+	t.Run("ChallengeWorksOnce", func(t *testing.T) {
+		challengeToken := challenge()
+		status, _ := doJSONRequest(t, "", "POST", loginURL, map[string]string{"challenge_token": challengeToken, "code": recoveryCodes[1].(string)})
+		assert.Equal(t, http.StatusOK, status)
+
+		status, _ = doJSONRequest(t, "", "POST", loginURL, map[string]string{"challenge_token": challengeToken, "code": recoveryCodes[2].(string)})
+		assert.Equal(t, http.StatusUnauthorized, status)
+	})
+
+	t.Run("NewChallengeReplacesOldOne", func(t *testing.T) {
+		challengeToken := challenge()
+		challenge()
+		status, _ := doJSONRequest(t, "", "POST", loginURL, map[string]string{"challenge_token": challengeToken, "code": recoveryCodes[2].(string)})
+		assert.Equal(t, http.StatusUnauthorized, status)
+	})
+
+	t.Run("ChallengeLocksAfterWrongCodes", func(t *testing.T) {
+		challengeToken := challenge()
+		for i := 0; i < 5; i++ {
+			status, _ := doJSONRequest(t, "", "POST", loginURL, map[string]string{"challenge_token": challengeToken, "code": "aaaaa-bbbbb"})
+			assert.Equal(t, http.StatusBadRequest, status)
+		}
+		status, _ := doJSONRequest(t, "", "POST", loginURL, map[string]string{"challenge_token": challengeToken, "code": recoveryCodes[2].(string)})
+		assert.Equal(t, http.StatusUnauthorized, status, "the right code comes too late")
+
+		status, _ = doJSONRequest(t, "", "POST", loginURL, map[string]string{"challenge_token": challenge(), "code": recoveryCodes[2].(string)})
+		assert.Equal(t, http.StatusUnauthorized, status, "logging in again gives no more attempts")
+
+		// the window of the wrong codes is over
+		TestApp.RawDBConnection().Exec("UPDATE two_factors SET challenge_started_at = ? WHERE user_id = (SELECT id FROM users WHERE email = ?)",
+			time.Now().Add(-time.Hour), user.Email)
+		status, _ = doJSONRequest(t, "", "POST", loginURL, map[string]string{"challenge_token": challenge(), "code": recoveryCodes[2].(string)})
+		assert.Equal(t, http.StatusOK, status, "a challenge of a new window takes the code")
+	})
+
+	t.Run("RegenerateRecoveryCodes", func(t *testing.T) {
+		status, _ := doJSONRequest(t, token, "POST", twoFactorURL+"/recovery-codes", map[string]string{"password": "wrong"})
+		assert.Equal(t, http.StatusBadRequest, status)
+
+		status, data := doJSONRequest(t, token, "POST", twoFactorURL+"/recovery-codes", map[string]string{"password": user.Password})
+		assert.Equal(t, http.StatusOK, status)
+		fresh, _ := data["recovery_codes"].([]interface{})
+		assert.Len(t, fresh, 10)
+		recoveryCodes = fresh
+	})
+
+	t.Run("Disable", func(t *testing.T) {
+		status, _ := doJSONRequest(t, token, "DELETE", twoFactorURL, map[string]string{"password": user.Password, "code": "aaaaa-bbbbb"})
+		assert.Equal(t, http.StatusBadRequest, status)
+
+		status, _ = doJSONRequest(t, token, "DELETE", twoFactorURL, map[string]string{"password": user.Password, "code": recoveryCodes[0].(string)})
+		assert.Equal(t, http.StatusNoContent, status)
+
+		status, data := doJSONRequest(t, "", "POST", ServerURL+Login, credentials)
+		assert.Equal(t, http.StatusOK, status)
+		assert.NotEmpty(t, data["auth_token"])
+	})
+}
@@ -416,3 +416,120 @@ func TestRegisterUser(t *testing.T) {
 		assert.Contains(t, res.Error, "invalid password format", "error message should contain 'invalid password format'")
 	})
 }
+
+func TestProfile(t *testing.T) {
+	users := map[string]MockUser{
+		"leaver": {FirstName: "profile", LastName: "leaver", Email: "profileleaver@gmail.com", Password: "12@Amir###90"},
+		"heir":   {FirstName: "profile", LastName: "heir", Email: "profileheir@gmail.com", Password: "12@Amir###90"},
+	}
+	tokens := make(map[string]string, len(users))
+	for name, user := range users {
+		if result := CreateUser(user); result.StatusCode != http.StatusCreated {
+			t.Fatalf("Failed to create %s. Status code: %d, Response message: %s", name, result.StatusCode, result.Message)
+		}
+		token, err := LoginAndGetToken(t, MockUserLogin{Email: user.Email, Password: user.Password})
+		if err != nil {
+			t.Fatalf("Login failed: %v", err)
+		}
+		tokens[name] = token
+	}
+	resp, boardData, err := CreateBoard(tokens["leaver"], MockBoard{Name: "Profile Board", Type: "private"})
+	if err != nil || resp.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to create board: %v", err)
+	}
+	InviteMember(t, tokens["leaver"], tokens["heir"], users["heir"].Email, boardData.BoardID, "maintainer")
+
+	meURL := ServerURL + "/me"
+	boardURL := fmt.Sprintf("%s%s/%s", ServerURL, BoardPost, boardData.BoardID)
+	newPassword := "34@Amir###12"
+	mockScenarios := []struct {
+		name               string
+		token              string
+		method             string
+		url                string
+		payload            interface{}
+		expectedStatusCode int
+	}{
+		{"GetProfile", tokens["leaver"], "GET", meURL, nil, http.StatusOK},
+		{"UpdateName", tokens["leaver"], "PATCH", meURL, map[string]string{"first_name": "renamed"}, http.StatusOK},
+		{"EmptyName", tokens["leaver"], "PATCH", meURL, map[string]string{"last_name": " "}, http.StatusBadRequest},
+		{"TakenEmail", tokens["leaver"], "PATCH", meURL, map[string]string{"email": users["heir"].Email}, http.StatusConflict},
+		{"WrongOldPassword", tokens["leaver"], "POST", meURL + "/password", map[string]string{"old_password": "nope", "new_password": newPassword}, http.StatusBadRequest},
+		{"ChangePassword", tokens["leaver"], "POST", meURL + "/password", map[string]string{"old_password": users["leaver"].Password, "new_password": newPassword}, http.StatusNoContent},
+		{"DeleteWithWrongPassword", tokens["leaver"], "DELETE", meURL, map[string]string{"password": users["leaver"].Password}, http.StatusBadRequest},
+		{"DeleteAccount", tokens["leaver"], "DELETE", meURL, map[string]string{"password": newPassword}, http.StatusNoContent},
+		{"HeirOwnsBoard", tokens["heir"], "PATCH", boardURL, map[string]string{"name": "Handed Over"}, http.StatusOK},
+		{"DeletedCantLogin", "", "POST", ServerURL + Login, MockUserLogin{Email: users["leaver"].Email, Password: newPassword}, http.StatusBadRequest},
+	}
+
+	for _, scenario := range mockScenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			status := DoRequest(t, scenario.token, scenario.method, scenario.url, scenario.payload)
+			assert.Equal(t, scenario.expectedStatusCode, status)
+		})
+	}
+}
+
+func TestEmailVerificationAndPasswordReset(t *testing.T) {
+	owner := MockUser{FirstName: "verify", LastName: "owner", Email: "verifyowner@gmail.com", Password: "12@Amir###90"}
+	pending := MockUser{FirstName: "verify", LastName: "pending", Email: "verifypending@gmail.com", Password: "12@Amir###90"}
+	if result := CreateUser(owner); result.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to create owner. Status code: %d, Response message: %s", result.StatusCode, result.Message)
+	}
+	ownerToken, err := LoginAndGetToken(t, MockUserLogin{Email: owner.Email, Password: owner.Password})
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	// registered without following the mailed link
+	if status := DoRequest(t, "", "POST", ServerURL+Register, pending); status != http.StatusCreated {
+		t.Fatalf("Failed to register pending user. Status code: %d", status)
+	}
+	verifyToken, err := LastMailedToken(pending.Email, "Verify your email")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, boardData, err := CreateBoard(ownerToken, MockBoard{Name: "Verification Board", Type: "private"})
+	if err != nil || resp.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to create board: %v", err)
+	}
+	invite := map[string]string{"email": pending.Email, "board_id": boardData.BoardID, "role": "viewer"}
+	newPassword := "34@Amir###12"
+
+	mockScenarios := []struct {
+		name               string
+		token              string
+		method             string
+		url                string
+		payload            interface{}
+		expectedStatusCode int
+	}{
+		{"UnverifiedCantBeInvited", ownerToken, "POST", ServerURL + BoardPost + "/invite", invite, http.StatusBadRequest},
+		{"VerifiedCantResend", ownerToken, "POST", ServerURL + "/me/verification", nil, http.StatusBadRequest},
+		{"WrongVerificationToken", "", "POST", ServerURL + "/verify-email", map[string]string{"token": "nope"}, http.StatusBadRequest},
+		{"VerifyEmail", "", "POST", ServerURL + "/verify-email", map[string]string{"token": verifyToken}, http.StatusNoContent},
+		{"VerificationTokenIsSingleUse", "", "POST", ServerURL + "/verify-email", map[string]string{"token": verifyToken}, http.StatusBadRequest},
+		{"VerifiedCanBeInvited", ownerToken, "POST", ServerURL + BoardPost + "/invite", invite, http.StatusCreated},
+		{"ForgotPasswordOfUnknownEmail", "", "POST", ServerURL + "/forgot-password", map[string]string{"email": "nobody@gmail.com"}, http.StatusNoContent},
+		{"ForgotPassword", "", "POST", ServerURL + "/forgot-password", map[string]string{"email": pending.Email}, http.StatusNoContent},
+		{"WrongResetToken", "", "POST", ServerURL + "/reset-password", map[string]string{"token": "nope", "new_password": newPassword}, http.StatusBadRequest},
+	}
+
+	for _, scenario := range mockScenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			status := DoRequest(t, scenario.token, scenario.method, scenario.url, scenario.payload)
+			assert.Equal(t, scenario.expectedStatusCode, status)
+		})
+	}
+
+	t.Run("ResetPassword", func(t *testing.T) {
+		resetToken, err := LastMailedToken(pending.Email, "Reset your password")
+		if err != nil {
+			t.Fatal(err)
+		}
+		reset := map[string]string{"token": resetToken, "new_password": newPassword}
+		assert.Equal(t, http.StatusNoContent, DoRequest(t, "", "POST", ServerURL+"/reset-password", reset))
+		assert.Equal(t, http.StatusBadRequest, DoRequest(t, "", "POST", ServerURL+"/reset-password", reset))
+		_, err = LoginAndGetToken(t, MockUserLogin{Email: pending.Email, Password: newPassword})
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,84 @@
+package test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkspaces(t *testing.T) {
+	users := map[string]MockUser{
+		"admin":    {FirstName: "workspace", LastName: "admin", Email: "workspaceadmin@gmail.com", Password: "12@Amir###90"},
+		"member":   {FirstName: "workspace", LastName: "member", Email: "workspacemember@gmail.com", Password: "12@Amir###90"},
+		"guest":    {FirstName: "workspace", LastName: "guest", Email: "workspaceguest@gmail.com", Password: "12@Amir###90"},
+		"outsider": {FirstName: "workspace", LastName: "outsider", Email: "workspaceoutsider@gmail.com", Password: "12@Amir###90"},
+	}
+	tokens := make(map[string]string, len(users))
+	ids := make(map[string]string, len(users))
+	for name, user := range users {
+		result, data, err := CreateUserWithResp(user)
+		if err != nil || result.StatusCode != http.StatusCreated {
+			t.Fatalf("Failed to create %s. Status code: %d, Response message: %s", name, result.StatusCode, result.Message)
+		}
+		ids[name] = data.UserID
+		token, err := LoginAndGetToken(t, MockUserLogin{Email: user.Email, Password: user.Password})
+		if err != nil {
+			t.Fatalf("Login failed: %v", err)
+		}
+		tokens[name] = token
+	}
+
+	status, workspace := doJSONRequest(t, tokens["admin"], "POST", ServerURL+"/workspaces", map[string]string{"name": "Acme"})
+	if status != http.StatusCreated {
+		t.Fatalf("Failed to create workspace. Status code: %d", status)
+	}
+	workspaceURL := fmt.Sprintf("%s/workspaces/%v", ServerURL, workspace["workspace_id"])
+	for _, name := range []string{"member", "guest"} {
+		status := DoRequest(t, tokens["admin"], "POST", workspaceURL+"/members", map[string]string{"email": users[name].Email, "role": name})
+		if status != http.StatusCreated {
+			t.Fatalf("Failed to add %s. Status code: %d", name, status)
+		}
+	}
+
+	status, b := doJSONRequest(t, tokens["admin"], "POST", ServerURL+BoardPost,
+		map[string]interface{}{"name": "Acme Board", "type": "workspace", "workspace_id": workspace["workspace_id"]})
+	if status != http.StatusCreated {
+		t.Fatalf("Failed to create workspace board. Status code: %d", status)
+	}
+	boardURL := fmt.Sprintf("%s%s/%v", ServerURL, BoardPost, b["board_id"])
+	memberURL := func(name string) string { return workspaceURL + "/members/" + ids[name] }
+
+	mockScenarios := []struct {
+		name               string
+		token              string
+		method             string
+		url                string
+		payload            interface{}
+		expectedStatusCode int
+	}{
+		{"OutsiderCantSeeWorkspace", tokens["outsider"], "GET", workspaceURL, nil, http.StatusForbidden},
+		{"GuestSeesDirectory", tokens["guest"], "GET", workspaceURL + "/members", nil, http.StatusOK},
+		{"AddTwice", tokens["admin"], "POST", workspaceURL + "/members", map[string]string{"email": users["guest"].Email, "role": "member"}, http.StatusConflict},
+		{"MemberCantAdd", tokens["member"], "POST", workspaceURL + "/members", map[string]string{"email": users["outsider"].Email, "role": "guest"}, http.StatusForbidden},
+		{"MemberSeesWorkspaceBoard", tokens["member"], "GET", boardURL + "/members", nil, http.StatusOK},
+		{"GuestCantSeeWorkspaceBoard", tokens["guest"], "GET", boardURL + "/members", nil, http.StatusForbidden},
+		{"OutsiderCantSeeWorkspaceBoard", tokens["outsider"], "GET", boardURL + "/members", nil, http.StatusForbidden},
+		{"GuestCantCreateBoard", tokens["guest"], "POST", ServerURL + BoardPost, map[string]interface{}{"name": "Guest Board", "type": "private", "workspace_id": workspace["workspace_id"]}, http.StatusForbidden},
+		{"WorkspaceTypeNeedsWorkspace", tokens["admin"], "POST", ServerURL + BoardPost, map[string]string{"name": "Lonely Board", "type": "workspace"}, http.StatusBadRequest},
+		{"DemoteMember", tokens["admin"], "PATCH", memberURL("member"), map[string]string{"role": "guest"}, http.StatusOK},
+		{"DemotedMemberLosesBoard", tokens["member"], "GET", boardURL + "/members", nil, http.StatusForbidden},
+		{"LastAdminCantBeDemoted", tokens["admin"], "PATCH", memberURL("admin"), map[string]string{"role": "member"}, http.StatusBadRequest},
+		{"GuestCantRemoveOthers", tokens["guest"], "DELETE", memberURL("member"), nil, http.StatusForbidden},
+		{"GuestLeaves", tokens["guest"], "DELETE", memberURL("guest"), nil, http.StatusNoContent},
+		{"LastAdminCantLeave", tokens["admin"], "DELETE", memberURL("admin"), nil, http.StatusBadRequest},
+	}
+
+	for _, scenario := range mockScenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			status := DoRequest(t, scenario.token, scenario.method, scenario.url, scenario.payload)
+			assert.Equal(t, scenario.expectedStatusCode, status, "Expected status code")
+		})
+	}
+}